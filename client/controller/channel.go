@@ -25,9 +25,10 @@ import (
 
 type channelSharedOutlets struct {
 	// Channel properties inputs & outputs
-	inputName     dom.Element
-	codeType      dom.Element
-	inputCapacity dom.Element
+	inputName      dom.Element
+	codeType       dom.Element
+	inputCapacity  dom.Element
+	linkBreakpoint dom.Element
 }
 
 type channelController struct {
@@ -78,6 +79,10 @@ func (c *channelController) Commit(ctx context.Context) error {
 		return err // TODO: contextualise
 	}
 	if cfg.Name != c.existingName {
+		if c.gc.breakpoints[c.existingName] {
+			delete(c.gc.breakpoints, c.existingName)
+			c.gc.breakpoints[cfg.Name] = true
+		}
 		delete(c.graph.Channels, c.existingName)
 		c.channel.Name = cfg.Name
 		c.existingName = cfg.Name
@@ -137,4 +142,24 @@ func (c *channelController) GainFocus() {
 	c.sharedOutlets.inputName.Set("value", c.channel.Name)
 	c.sharedOutlets.inputCapacity.Set("value", c.channel.Capacity)
 	c.sharedOutlets.codeType.Set("innerText", c.channel.Type.String())
+	c.updateBreakpointLink()
+}
+
+func (c *channelController) Breakpoint() bool { return c.gc.breakpoints[c.channel.Name] }
+
+func (c *channelController) SetBreakpoint(on bool) {
+	c.gc.setBreakpoint(c.channel.Name, on)
+	c.updateBreakpointLink()
+}
+
+func (c *channelController) updateBreakpointLink() {
+	text := "Set breakpoint"
+	if c.Breakpoint() {
+		text = "Clear breakpoint"
+	}
+	c.sharedOutlets.linkBreakpoint.Set("textContent", text)
+}
+
+func (c *channelController) Tap(ctx context.Context) error {
+	return c.gc.tap(ctx, c.channel.Name)
 }
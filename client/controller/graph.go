@@ -17,9 +17,13 @@ package controller
 import (
 	"context"
 	"errors"
+	"fmt"
 	"io"
 	"log"
+	"sort"
 	"strconv"
+	"strings"
+	"time"
 
 	"github.com/google/shenzhen-go/client/view"
 	"github.com/google/shenzhen-go/dom"
@@ -56,6 +60,13 @@ type graphController struct {
 	nodePropertiesPanel    dom.Element
 	previewGoPanel         dom.Element
 	previewJSONPanel       dom.Element
+	runsPanel              dom.Element
+	runsList               dom.Element
+	tapPanel               dom.Element
+	tapChannelName         dom.Element
+	tapList                dom.Element
+	debugBanner            dom.Element
+	debugHeldList          dom.Element
 	previewGoSession       *dom.AceSession
 	previewJSONSession     *dom.AceSession
 
@@ -63,10 +74,31 @@ type graphController struct {
 	graphNameTextInput        dom.Element
 	graphPackagePathTextInput dom.Element
 	graphIsCommandCheckbox    dom.Element
+	graphSignalsCheckbox      dom.Element
+	graphShutdownTextInput    dom.Element
+
+	// Run configuration inputs
+	runNameTextInput   dom.Element
+	runNamesDatalist   dom.Element
+	runArgsTextarea    dom.Element
+	runEnvTextarea     dom.Element
+	runDirTextInput    dom.Element
+	runRaceCheckbox    dom.Element
+	runProfCheckbox    dom.Element
+	runInstCheckbox    dom.Element
+	runDebugCheckbox   dom.Element
+	runRecordTextarea  dom.Element
+	runRecordTextInput dom.Element
+	runReplayTextarea  dom.Element
+	runReplayTextInput dom.Element
 
 	// Components that are connected to whatever is selected.
 	channelSharedOutlets *channelSharedOutlets
 	nodeSharedOutlets    *nodeSharedOutlets
+
+	stopTap     context.CancelFunc // stops the current tap, if any
+	runStream   runClient          // the stream of the current run, if any
+	breakpoints map[string]bool    // channels to break on in debug runs
 }
 
 func setupAceView(id, mode string) *dom.AceSession {
@@ -116,17 +148,41 @@ func NewGraphController(doc dom.Document, graph *model.Graph, client pb.Shenzhen
 		nodePropertiesPanel:    doc.ElementByID("node-properties"),
 		previewGoPanel:         doc.ElementByID("preview-go"),
 		previewJSONPanel:       doc.ElementByID("preview-json"),
+		runsPanel:              doc.ElementByID("runs-panel"),
+		runsList:               doc.ElementByID("runs-list"),
+		tapPanel:               doc.ElementByID("tap-panel"),
+		tapChannelName:         doc.ElementByID("tap-channel"),
+		tapList:                doc.ElementByID("tap-list"),
+		debugBanner:            doc.ElementByID("debug-banner"),
+		debugHeldList:          doc.ElementByID("debug-held"),
 		previewGoSession:       setupAceView("preview-go-ace", dom.AceGoMode),
 		previewJSONSession:     setupAceView("preview-json-ace", dom.AceJSONMode),
 
 		graphNameTextInput:        doc.ElementByID("graph-prop-name"),
 		graphPackagePathTextInput: doc.ElementByID("graph-prop-package-path"),
 		graphIsCommandCheckbox:    doc.ElementByID("graph-prop-is-command"),
+		graphSignalsCheckbox:      doc.ElementByID("graph-prop-handle-signals"),
+		graphShutdownTextInput:    doc.ElementByID("graph-prop-shutdown-timeout"),
+
+		runNameTextInput:   doc.ElementByID("graph-prop-run-name"),
+		runNamesDatalist:   doc.ElementByID("graph-prop-run-names"),
+		runArgsTextarea:    doc.ElementByID("graph-prop-run-args"),
+		runEnvTextarea:     doc.ElementByID("graph-prop-run-env"),
+		runDirTextInput:    doc.ElementByID("graph-prop-run-dir"),
+		runRaceCheckbox:    doc.ElementByID("graph-prop-run-race"),
+		runProfCheckbox:    doc.ElementByID("graph-prop-run-profile"),
+		runInstCheckbox:    doc.ElementByID("graph-prop-run-instrument"),
+		runDebugCheckbox:   doc.ElementByID("graph-prop-run-debug"),
+		runRecordTextarea:  doc.ElementByID("graph-prop-run-record"),
+		runRecordTextInput: doc.ElementByID("graph-prop-run-record-file"),
+		runReplayTextarea:  doc.ElementByID("graph-prop-run-replay"),
+		runReplayTextInput: doc.ElementByID("graph-prop-run-replay-file"),
 
 		channelSharedOutlets: &channelSharedOutlets{
-			inputName:     doc.ElementByID("channel-name"),
-			codeType:      doc.ElementByID("channel-type"),
-			inputCapacity: doc.ElementByID("channel-capacity"),
+			inputName:      doc.ElementByID("channel-name"),
+			codeType:       doc.ElementByID("channel-type"),
+			inputCapacity:  doc.ElementByID("channel-capacity"),
+			linkBreakpoint: doc.ElementByID("channel-breakpoint-link"),
 		},
 		nodeSharedOutlets: &nodeSharedOutlets{
 			subpanelMetadata:  subpanelMetadata,
@@ -136,8 +192,13 @@ func NewGraphController(doc dom.Document, graph *model.Graph, client pb.Shenzhen
 			inputEnabled:      doc.ElementByID("node-enabled"),
 			inputMultiplicity: doc.ElementByID("node-multiplicity"),
 			inputWait:         doc.ElementByID("node-wait"),
+			selectPanic:       doc.ElementByID("node-panic-policy"),
+			inputMaxRestarts:  doc.ElementByID("node-max-restarts"),
+			inputConfigurable: doc.ElementByID("node-configurable"),
 			partEditors:       pes,
 		},
+
+		breakpoints: make(map[string]bool),
 	}
 }
 
@@ -264,14 +325,16 @@ func (c *graphController) CreateNode(ctx context.Context, partType string) (view
 }
 
 func (c *graphController) action(ctx context.Context, a pb.ActionRequest_Action) error {
-	stream, err := c.client.Action(ctx, &pb.ActionRequest{
-		Graph:  c.graph.FilePath,
-		Action: a,
-	})
+	return c.actionRequest(ctx, &pb.ActionRequest{Action: a}, nil)
+}
+
+func (c *graphController) actionRequest(ctx context.Context, req *pb.ActionRequest, rv view.RunViewer) error {
+	req.Graph = c.graph.FilePath
+	stream, err := c.client.Action(ctx, req)
 	if err != nil {
 		return err
 	}
-	if a == pb.ActionRequest_SAVE || a == pb.ActionRequest_REVERT {
+	if a := req.Action; a == pb.ActionRequest_SAVE || a == pb.ActionRequest_REVERT {
 		// No need for a terminal
 		return nil
 	}
@@ -287,10 +350,27 @@ func (c *graphController) action(ctx context.Context, a pb.ActionRequest_Action)
 			return err
 		}
 		tio.Print(resp.Output)
+		reportDiagnostics(tio, resp.Diagnostics, rv)
 	}
 	return nil
 }
 
+// reportDiagnostics prints diagnostics to the terminal, and passes them on.
+func reportDiagnostics(tio dom.IO, ds []*pb.Diagnostic, rv view.RunViewer) {
+	for _, d := range ds {
+		tio.Print(fmt.Sprintf("(node %s, %s line %d: %s)\n", d.Node, d.Section, d.Line, d.Message))
+		if rv == nil {
+			continue
+		}
+		rv.Diagnose(&view.Diagnostic{
+			Node:    d.Node,
+			Section: d.Section,
+			Line:    int(d.Line),
+			Message: d.Message,
+		})
+	}
+}
+
 func (c *graphController) Save(ctx context.Context) error {
 	return c.action(ctx, pb.ActionRequest_SAVE)
 }
@@ -304,12 +384,46 @@ func (c *graphController) Revert(ctx context.Context) error {
 	return nil
 }
 
+func (c *graphController) Restore(ctx context.Context) error {
+	if err := c.action(ctx, pb.ActionRequest_RESTORE); err != nil {
+		return err
+	}
+	js.Global.Get("location").Call("reload", true)
+	return nil
+}
+
+func (c *graphController) Discard(ctx context.Context) error {
+	if err := c.action(ctx, pb.ActionRequest_DISCARD); err != nil {
+		return err
+	}
+	js.Global.Get("location").Call("reload", true)
+	return nil
+}
+
 func (c *graphController) Generate(ctx context.Context) error {
 	return c.action(ctx, pb.ActionRequest_GENERATE)
 }
 
 func (c *graphController) Build(ctx context.Context) error {
-	return c.action(ctx, pb.ActionRequest_BUILD)
+	return c.actionRequest(ctx, &pb.ActionRequest{
+		Action: pb.ActionRequest_BUILD,
+		Race:   c.runRaceCheckbox.Get("checked").Bool(),
+	}, nil)
+}
+
+func (c *graphController) Test(ctx context.Context, rv view.RunViewer) error {
+	return c.actionRequest(ctx, &pb.ActionRequest{
+		Action: pb.ActionRequest_TEST,
+		Race:   c.runRaceCheckbox.Get("checked").Bool(),
+	}, rv)
+}
+
+func (c *graphController) Bench(ctx context.Context) error {
+	return c.action(ctx, pb.ActionRequest_BENCH)
+}
+
+func (c *graphController) GenerateTestStubs(ctx context.Context) error {
+	return c.action(ctx, pb.ActionRequest_TEST_STUBS)
 }
 
 func (c *graphController) Install(ctx context.Context) error {
@@ -334,18 +448,57 @@ func (c *graphController) ShowHterm() {
 	}
 }
 
-func (c *graphController) Run(ctx context.Context) error {
-	c.ShowHterm()
-	c.htermTerminal.ClearHome()
+// runClient is satisfied by both pb.ShenzhenGo_RunClient and
+// pb.ShenzhenGo_AttachRunClient.
+type runClient interface {
+	Send(*pb.Input) error
+	Recv() (*pb.Output, error)
+	CloseSend() error
+}
 
+func (c *graphController) Run(ctx context.Context, rv view.RunViewer) error {
 	rc, err := c.client.Run(ctx)
 	if err != nil {
 		return err
 	}
 	defer rc.CloseSend()
-	if err := rc.Send(&pb.Input{Graph: c.graph.FilePath}); err != nil {
+	first := &pb.Input{Graph: c.graph.FilePath, Config: c.runConfigFromInputs()}
+	if first.Config.Debug {
+		for ch := range c.breakpoints {
+			first.Breakpoints = append(first.Breakpoints, ch)
+		}
+		sort.Strings(first.Breakpoints)
+	}
+	if err := rc.Send(first); err != nil {
 		return err
 	}
+	return c.runTerminal(rc, rv)
+}
+
+// AttachRun attaches the terminal to an existing run session.
+func (c *graphController) AttachRun(ctx context.Context, id string) error {
+	rc, err := c.client.AttachRun(ctx)
+	if err != nil {
+		return err
+	}
+	defer rc.CloseSend()
+	if err := rc.Send(&pb.Input{RunId: id}); err != nil {
+		return err
+	}
+	return c.runTerminal(rc, nil)
+}
+
+// runTerminal connects the terminal to a run session stream.
+func (c *graphController) runTerminal(rc runClient, rv view.RunViewer) error {
+	c.ShowHterm()
+	c.htermTerminal.ClearHome()
+
+	c.runStream = rc
+	defer func() {
+		c.runStream = nil
+		c.debugHeldList.Set("innerHTML", "")
+		c.debugBanner.Hide()
+	}()
 
 	tio := c.htermTerminal.IO().Push()
 	defer func() {
@@ -390,10 +543,232 @@ func (c *graphController) Run(ctx context.Context) error {
 		if err != nil {
 			return err
 		}
+		if out.RunId != "" {
+			tio.Print("(run session " + out.RunId + ")\n")
+		}
 		// TODO(josh): Format these differently?
 		tio.Print(out.Out)
 		tio.Print(out.Err)
+		reportDiagnostics(tio, out.Diagnostics, rv)
+		if len(out.Profile) > 0 && rv != nil {
+			nps := make([]*view.NodeProfile, len(out.Profile))
+			for i, np := range out.Profile {
+				nps[i] = &view.NodeProfile{
+					Node:       np.Node,
+					CPUNanos:   np.CpuNanos,
+					AllocBytes: np.AllocBytes,
+				}
+			}
+			rv.ShowProfile(nps)
+		}
+		if len(out.Channels) > 0 && rv != nil {
+			css := make([]*view.ChannelStats, len(out.Channels))
+			for i, cs := range out.Channels {
+				css[i] = &view.ChannelStats{
+					Channel: cs.Channel,
+					Len:     cs.Len,
+					Cap:     cs.Cap,
+					Sends:   cs.Sends,
+					Rate:    cs.Rate,
+				}
+			}
+			rv.ShowChannelStats(css)
+		}
+		if out.Held != nil {
+			c.showHeld(out.Held, rv)
+		}
+		if out.Released != "" {
+			c.hideHeld(out.Released, rv)
+		}
+	}
+}
+
+// showHeld shows controls for a value held at a breakpoint.
+func (c *graphController) showHeld(hv *pb.HeldValue, rv view.RunViewer) {
+	c.hideHeld(hv.Channel, rv)
+	li := c.makeElement("li").
+		SetAttribute("data-channel", hv.Channel).
+		AddChildren(c.doc.MakeTextNode("Holding " + hv.Value + " sent on " + hv.Channel + " "))
+	for _, a := range []struct {
+		name, title string
+		op          pb.DebugCommand_Op
+	}{
+		{"Step", "Send this value, and hold the next", pb.DebugCommand_STEP},
+		{"Continue", "Send this value, and remove the breakpoint", pb.DebugCommand_CONTINUE},
+		{"Drop", "Throw away this value, and hold the next", pb.DebugCommand_DROP},
+	} {
+		op := a.op
+		link := c.makeElement("span").
+			SetAttribute("title", a.title).
+			AddChildren(c.doc.MakeTextNode(a.name)).
+			AddEventListener("click", func(dom.Object) {
+				go c.debugCommand(hv.Channel, op)
+			})
+		link.ClassList().Add("link")
+		li.AddChildren(link, c.doc.MakeTextNode(" "))
+	}
+	c.debugHeldList.AddChildren(li)
+	c.debugBanner.Show()
+	if rv != nil {
+		rv.ShowHeld(hv.Channel, true)
+	}
+}
+
+// hideHeld removes the controls for a value that is no longer held.
+func (c *graphController) hideHeld(channel string, rv view.RunViewer) {
+	for li := c.debugHeldList.Get("firstElementChild"); li != nil; {
+		next := li.Get("nextElementSibling")
+		if li.Call("getAttribute", "data-channel").String() == channel {
+			li.Call("remove")
+		}
+		li = next
+	}
+	if c.debugHeldList.Get("childElementCount").Int() == 0 {
+		c.debugBanner.Hide()
+	}
+	if rv != nil {
+		rv.ShowHeld(channel, false)
+	}
+}
+
+// debugCommand sends a debug command to the current run session.
+func (c *graphController) debugCommand(channel string, op pb.DebugCommand_Op) {
+	if c.runStream == nil {
+		return
+	}
+	if op == pb.DebugCommand_CONTINUE {
+		delete(c.breakpoints, channel)
+	}
+	if err := c.runStream.Send(&pb.Input{Debug: &pb.DebugCommand{Channel: channel, Op: op}}); err != nil {
+		log.Printf("Couldn't send debug command: %v", err)
+	}
+}
+
+// setBreakpoint sets or clears a breakpoint on a channel. If a program is
+// running, the change is sent to it.
+func (c *graphController) setBreakpoint(channel string, on bool) {
+	op := pb.DebugCommand_CLEAR
+	if on {
+		c.breakpoints[channel] = true
+		op = pb.DebugCommand_BREAK
+	} else {
+		delete(c.breakpoints, channel)
 	}
+	go c.debugCommand(channel, op)
+}
+
+// maxTapSamples is how many tap samples are shown at once.
+const maxTapSamples = 100
+
+// tap shows samples of the values sent on a channel, until the tap is
+// stopped or the program exits.
+func (c *graphController) tap(ctx context.Context, channel string) error {
+	c.StopTap()
+	ctx, cancel := context.WithCancel(ctx)
+	c.stopTap = cancel
+	defer cancel()
+
+	c.tapChannelName.Set("textContent", channel)
+	c.tapList.Set("innerHTML", "")
+	c.showRHSPanel(c.tapPanel)
+	tc, err := c.client.TapChannel(ctx, &pb.TapRequest{
+		Graph:   c.graph.FilePath,
+		Channel: channel,
+	})
+	if err != nil {
+		return err
+	}
+	add := func(s string) {
+		c.tapList.AddChildren(c.makeElement("li").AddChildren(c.doc.MakeTextNode(s)))
+		for c.tapList.Get("childElementCount").Int() > maxTapSamples {
+			c.tapList.Get("firstElementChild").Call("remove")
+		}
+	}
+	for {
+		s, err := tc.Recv()
+		if err == io.EOF {
+			add("(program exited)")
+			return nil
+		}
+		if err != nil {
+			if ctx.Err() != nil {
+				add("(stopped)")
+				return nil
+			}
+			return err
+		}
+		t := time.Unix(0, s.Time)
+		add(t.Format("15:04:05.000") + " " + s.Value)
+	}
+}
+
+// StopTap stops the current tap, if any.
+func (c *graphController) StopTap() {
+	if c.stopTap != nil {
+		c.stopTap()
+		c.stopTap = nil
+	}
+}
+
+// Runs shows the run sessions for the graph.
+func (c *graphController) Runs(ctx context.Context) error {
+	c.showRHSPanel(c.runsPanel)
+	resp, err := c.client.ListRuns(ctx, &pb.ListRunsRequest{Graph: c.graph.FilePath})
+	if err != nil {
+		return err
+	}
+	c.runsList.Set("innerHTML", "")
+	if len(resp.Runs) == 0 {
+		c.runsList.AddChildren(c.makeElement("li").AddChildren(c.doc.MakeTextNode("No run sessions.")))
+		return nil
+	}
+	for _, ri := range resp.Runs {
+		id := ri.Id
+		desc := "#" + id
+		if len(ri.Args) > 0 {
+			desc += " " + strings.Join(ri.Args, " ")
+		}
+		if ri.Running {
+			desc += " (running) "
+		} else {
+			desc += " (" + ri.ExitStatus + ") "
+		}
+		attach := c.makeElement("span").
+			AddChildren(c.doc.MakeTextNode("Attach")).
+			AddEventListener("click", func(dom.Object) {
+				go func() {
+					if err := c.AttachRun(context.Background(), id); err != nil {
+						log.Printf("Couldn't attach to run %s: %v", id, err)
+					}
+				}()
+			})
+		attach.ClassList().Add("link")
+		killText := "Kill"
+		if !ri.Running {
+			killText = "Forget"
+		}
+		kill := c.makeElement("span").
+			AddChildren(c.doc.MakeTextNode(killText)).
+			AddEventListener("click", func(dom.Object) {
+				go func() {
+					if _, err := c.client.KillRun(context.Background(), &pb.KillRunRequest{Id: id}); err != nil {
+						log.Printf("Couldn't kill run %s: %v", id, err)
+					}
+					if err := c.Runs(context.Background()); err != nil {
+						log.Printf("Couldn't list runs: %v", err)
+					}
+				}()
+			})
+		kill.ClassList().Add("link", "destructive")
+		c.runsList.AddChildren(c.makeElement("li").AddChildren(
+			c.doc.MakeTextNode(desc), attach, c.doc.MakeTextNode(" | "), kill,
+		))
+	}
+	return nil
+}
+
+func (c *graphController) makeElement(tag string) dom.Element {
+	return dom.WrapElement(c.doc.Call("createElement", tag))
 }
 
 func (c *graphController) Commit(ctx context.Context) error {
@@ -402,6 +777,9 @@ func (c *graphController) Commit(ctx context.Context) error {
 		Name:        c.graphNameTextInput.Get("value").String(),
 		PackagePath: c.graphPackagePathTextInput.Get("value").String(),
 		IsCommand:   c.graphIsCommandCheckbox.Get("checked").Bool(),
+
+		HandleSignals:   c.graphSignalsCheckbox.Get("checked").Bool(),
+		ShutdownTimeout: strings.TrimSpace(c.graphShutdownTextInput.Get("value").String()),
 	}
 	if _, err := c.client.SetGraphProperties(ctx, req); err != nil {
 		return err
@@ -409,6 +787,134 @@ func (c *graphController) Commit(ctx context.Context) error {
 	c.graph.Name = req.Name
 	c.graph.PackagePath = req.PackagePath
 	c.graph.IsCommand = req.IsCommand
+	c.graph.HandleSignals = req.HandleSignals
+	c.graph.ShutdownTimeout = req.ShutdownTimeout
+	return nil
+}
+
+// lines splits text into non-blank lines.
+func lines(text string) []string {
+	var ls []string
+	for _, l := range strings.Split(text, "\n") {
+		l = strings.TrimSuffix(l, "\r")
+		if strings.TrimSpace(l) == "" {
+			continue
+		}
+		ls = append(ls, l)
+	}
+	return ls
+}
+
+func (c *graphController) runConfigFromInputs() *pb.RunConfig {
+	return &pb.RunConfig{
+		Args:       lines(c.runArgsTextarea.Get("value").String()),
+		Env:        lines(c.runEnvTextarea.Get("value").String()),
+		Dir:        strings.TrimSpace(c.runDirTextInput.Get("value").String()),
+		Race:       c.runRaceCheckbox.Get("checked").Bool(),
+		Profile:    c.runProfCheckbox.Get("checked").Bool(),
+		Instrument: c.runInstCheckbox.Get("checked").Bool(),
+		Debug:      c.runDebugCheckbox.Get("checked").Bool(),
+		Record:     lines(c.runRecordTextarea.Get("value").String()),
+		RecordFile: strings.TrimSpace(c.runRecordTextInput.Get("value").String()),
+		Replay:     lines(c.runReplayTextarea.Get("value").String()),
+		ReplayFile: strings.TrimSpace(c.runReplayTextInput.Get("value").String()),
+	}
+}
+
+func (c *graphController) refreshRunConfigNames() {
+	c.runNamesDatalist.Set("innerHTML", "")
+	names := make([]string, 0, len(c.graph.RunConfigs))
+	for n := range c.graph.RunConfigs {
+		names = append(names, n)
+	}
+	sort.Strings(names)
+	for _, n := range names {
+		o := c.makeElement("option")
+		o.Set("value", n)
+		c.runNamesDatalist.AddChildren(o)
+	}
+}
+
+func (c *graphController) SelectRunConfig() {
+	rc := c.graph.RunConfigs[c.runNameTextInput.Get("value").String()]
+	if rc == nil {
+		// A new name; the current inputs will be saved under it on the next commit.
+		return
+	}
+	c.runArgsTextarea.Set("value", strings.Join(rc.Args, "\n"))
+	c.runEnvTextarea.Set("value", strings.Join(rc.Env, "\n"))
+	c.runDirTextInput.Set("value", rc.Dir)
+	c.runRaceCheckbox.Set("checked", rc.Race)
+	c.runProfCheckbox.Set("checked", rc.Profile)
+	c.runInstCheckbox.Set("checked", rc.Instrument)
+	c.runDebugCheckbox.Set("checked", rc.Debug)
+	c.runRecordTextarea.Set("value", strings.Join(rc.Record, "\n"))
+	c.runRecordTextInput.Set("value", rc.RecordFile)
+	c.runReplayTextarea.Set("value", strings.Join(rc.Replay, "\n"))
+	c.runReplayTextInput.Set("value", rc.ReplayFile)
+}
+
+func (c *graphController) CommitRunConfig(ctx context.Context) error {
+	name := c.runNameTextInput.Get("value").String()
+	if name == "" {
+		// Unnamed configs are used for running, but not saved.
+		return nil
+	}
+	req := &pb.SetRunConfigRequest{
+		Graph:  c.graph.FilePath,
+		Name:   name,
+		Config: c.runConfigFromInputs(),
+	}
+	if _, err := c.client.SetRunConfig(ctx, req); err != nil {
+		return err
+	}
+	if c.graph.RunConfigs == nil {
+		c.graph.RunConfigs = make(map[string]*model.RunConfig)
+	}
+	_, existed := c.graph.RunConfigs[name]
+	c.graph.RunConfigs[name] = &model.RunConfig{
+		Args:       req.Config.Args,
+		Env:        req.Config.Env,
+		Dir:        req.Config.Dir,
+		Race:       req.Config.Race,
+		Profile:    req.Config.Profile,
+		Instrument: req.Config.Instrument,
+		Debug:      req.Config.Debug,
+		Record:     req.Config.Record,
+		RecordFile: req.Config.RecordFile,
+		Replay:     req.Config.Replay,
+		ReplayFile: req.Config.ReplayFile,
+	}
+	if !existed {
+		c.refreshRunConfigNames()
+	}
+	return nil
+}
+
+func (c *graphController) DeleteRunConfig(ctx context.Context) error {
+	name := c.runNameTextInput.Get("value").String()
+	if _, found := c.graph.RunConfigs[name]; !found {
+		return nil
+	}
+	req := &pb.SetRunConfigRequest{
+		Graph: c.graph.FilePath,
+		Name:  name,
+	}
+	if _, err := c.client.SetRunConfig(ctx, req); err != nil {
+		return err
+	}
+	delete(c.graph.RunConfigs, name)
+	c.refreshRunConfigNames()
+	for _, e := range []dom.Element{
+		c.runNameTextInput, c.runArgsTextarea, c.runEnvTextarea, c.runDirTextInput,
+		c.runRecordTextarea, c.runRecordTextInput, c.runReplayTextarea, c.runReplayTextInput,
+	} {
+		e.Set("value", "")
+	}
+	c.runRaceCheckbox.Set("checked", false)
+	c.runProfCheckbox.Set("checked", false)
+	c.runInstCheckbox.Set("checked", false)
+	c.runDebugCheckbox.Set("checked", false)
 	return nil
 }
 
@@ -17,6 +17,7 @@ package controller
 import (
 	"context"
 	"fmt"
+	"strings"
 
 	"github.com/google/shenzhen-go/client/view"
 	"github.com/google/shenzhen-go/dom"
@@ -43,6 +44,9 @@ type nodeSharedOutlets struct {
 	inputEnabled      dom.Element
 	inputMultiplicity dom.Element
 	inputWait         dom.Element
+	selectPanic       dom.Element
+	inputMaxRestarts  dom.Element
+	inputConfigurable dom.Element
 	partEditors       map[string]*partEditor
 }
 
@@ -107,6 +111,9 @@ func (c *nodeController) Commit(ctx context.Context) error {
 		Enabled:      c.sharedOutlets.inputEnabled.Get("checked").Bool(),
 		Multiplicity: c.sharedOutlets.inputMultiplicity.Get("value").String(),
 		Wait:         c.sharedOutlets.inputWait.Get("checked").Bool(),
+		PanicPolicy:  c.sharedOutlets.selectPanic.Get("value").String(),
+		MaxRestarts:  c.sharedOutlets.inputMaxRestarts.Get("value").Int64(),
+		Configurable: strings.Fields(c.sharedOutlets.inputConfigurable.Get("value").String()),
 		PartCfg:      pj.Part,
 		PartType:     pj.Type,
 		X:            c.node.X,
@@ -126,6 +133,9 @@ func (c *nodeController) Commit(ctx context.Context) error {
 	c.node.Enabled = cfg.Enabled
 	c.node.Multiplicity = cfg.Multiplicity
 	c.node.Wait = cfg.Wait
+	c.node.PanicPolicy = cfg.PanicPolicy
+	c.node.MaxRestarts = int(cfg.MaxRestarts)
+	c.node.Configurable = cfg.Configurable
 	c.node.RefreshConnections()
 	return nil
 }
@@ -156,6 +166,18 @@ func (c *nodeController) GainFocus() {
 	c.sharedOutlets.inputEnabled.Set("checked", c.node.Enabled)
 	c.sharedOutlets.inputMultiplicity.Set("value", c.node.Multiplicity)
 	c.sharedOutlets.inputWait.Set("checked", c.node.Wait)
+	pp := c.node.PanicPolicy
+	if pp == "" {
+		pp = model.PanicCrash
+	}
+	c.sharedOutlets.selectPanic.Set("value", pp)
+	c.sharedOutlets.inputMaxRestarts.Set("value", c.node.MaxRestarts)
+	var settings []string
+	for _, s := range c.node.Settings() {
+		settings = append(settings, s.Name)
+	}
+	c.sharedOutlets.inputConfigurable.Set("value", strings.Join(c.node.Configurable, " "))
+	c.sharedOutlets.inputConfigurable.Set("placeholder", strings.Join(settings, " "))
 	// Hide all parteditor links except for this parttype
 	for k, e := range c.sharedOutlets.partEditors {
 		if k == c.node.Part.TypeKey() {
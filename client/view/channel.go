@@ -16,6 +16,7 @@ package view
 
 import (
 	"context"
+	"fmt"
 	"log"
 
 	"github.com/google/shenzhen-go/dom"
@@ -38,6 +39,7 @@ type Channel struct {
 	logical            Point       // centre of steiner point, for snapping
 	visual             Point       // temporary centre of steiner point, for display
 	dragLine, dragCirc dom.Element // temporarily visible, for dragging to more pins
+	stats              dom.Element // shows channel activity while running
 	potentialPin       *Pin        // considering attaching to this pin
 	subsumeInto        *Channel    // considering merging with this channel
 	presubsumption     map[*Pin]struct{}
@@ -48,12 +50,14 @@ func (c *Channel) MakeElements(doc dom.Document, parent dom.Element) {
 	c.Group.Remove()
 	c.Group = NewGroup(doc, parent)
 	c.Group.Element.ClassList().Add("channel")
+	c.updateBreakpoint()
 
 	// This part works so well it's scary.
 	c.Group.Element.
 		AddEventListener("mousedown", c.view.selecter(c)).
 		AddEventListener("mouseenter", c.mouseEnter).
-		AddEventListener("mouseleave", c.mouseLeave)
+		AddEventListener("mouseleave", c.mouseLeave).
+		AddEventListener("contextmenu", c.tap)
 
 	c.steiner = doc.MakeSVGElement("circle").
 		SetAttribute("r", pinRadius).
@@ -67,7 +71,11 @@ func (c *Channel) MakeElements(doc dom.Document, parent dom.Element) {
 	c.dragCirc.ClassList().Add("draggable")
 	c.hideDrag()
 
-	c.Group.AddChildren(c.steiner, c.dragLine, c.dragCirc)
+	c.stats = doc.MakeSVGElement("text")
+	c.stats.ClassList().Add("stats")
+	c.stats.Hide()
+
+	c.Group.AddChildren(c.steiner, c.dragLine, c.dragCirc, c.stats)
 }
 
 // Pt implements Pointer.
@@ -89,6 +97,42 @@ func (c *Channel) reallyCommit() {
 	}
 }
 
+// tap shows the values being sent on the channel while running.
+func (c *Channel) tap(e dom.Object) {
+	e.Call("preventDefault")
+	go c.reallyTap()
+}
+
+func (c *Channel) reallyTap() {
+	if err := c.cc.Tap(context.TODO()); err != nil {
+		c.errors.setError("Couldn't tap channel: " + err.Error())
+	}
+}
+
+// toggleBreakpoint sets or clears a breakpoint on the channel.
+func (c *Channel) toggleBreakpoint() {
+	c.cc.SetBreakpoint(!c.cc.Breakpoint())
+	c.updateBreakpoint()
+}
+
+func (c *Channel) updateBreakpoint() {
+	if c.cc.Breakpoint() {
+		c.Group.ClassList().Add("breakpoint")
+	} else {
+		c.Group.ClassList().Remove("breakpoint")
+	}
+}
+
+// showHeld marks the channel as holding a value at a breakpoint, or not.
+func (c *Channel) showHeld(held bool) {
+	if held {
+		c.Group.ClassList().Add("held")
+	} else {
+		c.Group.ClassList().Remove("held")
+	}
+	c.updateBreakpoint()
+}
+
 func (c *Channel) mouseEnter(e dom.Object) {
 	log.Print("*Channel.mouseEnter")
 	c.view.showHoverTip(e, c.cc.Name())
@@ -358,6 +402,28 @@ func (c *Channel) deleteView() {
 	c.deleted = true
 }
 
+// showStats shows the send rate and fullness of the channel.
+func (c *Channel) showStats(cs *ChannelStats) {
+	label := fmt.Sprintf("%.0f/s", cs.Rate)
+	full := 0.0
+	if cs.Cap > 0 {
+		label += fmt.Sprintf(" %d/%d", cs.Len, cs.Cap)
+		full = float64(cs.Len) / float64(cs.Cap)
+	}
+	c.stats.Set("textContent", label)
+	c.stats.Show()
+	for _, r := range c.Pins {
+		r.showFullness(full)
+	}
+}
+
+func (c *Channel) clearStats() {
+	c.stats.Hide()
+	for _, r := range c.Pins {
+		r.clearFullness()
+	}
+}
+
 func (c *Channel) layout(additional Pointer) {
 	if c == nil {
 		return
@@ -395,6 +461,9 @@ func (c *Channel) layout(additional Pointer) {
 	c.dragLine.
 		SetAttribute("x2", real(c.visual)).
 		SetAttribute("y2", imag(c.visual))
+	c.stats.
+		SetAttribute("x", real(c.visual)+2*pinRadius).
+		SetAttribute("y", imag(c.visual)-2*pinRadius)
 	for _, r := range c.Pins {
 		r.Reroute()
 	}
@@ -16,6 +16,40 @@ package view
 
 import "context"
 
+// Diagnostic describes a problem found in part of a node's implementation,
+// such as a data race.
+type Diagnostic struct {
+	Node    string
+	Section string // "head", "body" or "tail"
+	Line    int    // within the section
+	Message string
+}
+
+// NodeProfile is the profile data attributed to a node.
+type NodeProfile struct {
+	Node       string
+	CPUNanos   int64
+	AllocBytes int64
+}
+
+// ChannelStats is a sample of the activity of a channel.
+type ChannelStats struct {
+	Channel string
+	Len     int64
+	Cap     int64
+	Sends   int64
+	Rate    float64 // sends per second
+}
+
+// RunViewer is implemented by the view, to show information collected
+// while testing or running a graph.
+type RunViewer interface {
+	Diagnose(*Diagnostic)
+	ShowProfile([]*NodeProfile)
+	ShowChannelStats([]*ChannelStats)
+	ShowHeld(channel string, held bool)
+}
+
 // GraphController is implemented by the controller of a whole graph.
 type GraphController interface {
 	GainFocus()
@@ -33,13 +67,25 @@ type GraphController interface {
 	// Send properties to server
 	Commit(ctx context.Context) error
 
+	// Run configurations
+	SelectRunConfig() // loads the inputs from the config with the chosen name
+	CommitRunConfig(ctx context.Context) error
+	DeleteRunConfig(ctx context.Context) error
+
 	// Action links
 	Save(ctx context.Context) error
 	Revert(ctx context.Context) error
+	Restore(ctx context.Context) error
+	Discard(ctx context.Context) error
 	Generate(ctx context.Context) error
 	Build(ctx context.Context) error
 	Install(ctx context.Context) error
-	Run(ctx context.Context) error
+	Test(ctx context.Context, rv RunViewer) error
+	Bench(ctx context.Context) error
+	GenerateTestStubs(ctx context.Context) error
+	Run(ctx context.Context, rv RunViewer) error
+	Runs(ctx context.Context) error
+	StopTap()
 	PreviewGo()
 	PreviewRawGo()
 	PreviewJSON()
@@ -60,6 +106,14 @@ type ChannelController interface {
 
 	Commit(ctx context.Context) error
 	Delete(ctx context.Context) error
+
+	// Tap shows samples of the values sent on the channel in the running
+	// program, until the tap is stopped or the program exits.
+	Tap(ctx context.Context) error
+
+	// Breakpoints hold values sent on the channel in debug runs.
+	Breakpoint() bool
+	SetBreakpoint(on bool)
 }
 
 // NodeController is implemented by the controller of a node.
@@ -39,16 +39,29 @@ func (c fakeGraphController) CreateNode(ctx context.Context, partType string) (N
 func (c fakeGraphController) Commit(ctx context.Context) error   { return nil }
 func (c fakeGraphController) Save(ctx context.Context) error     { return nil }
 func (c fakeGraphController) Revert(ctx context.Context) error   { return nil }
+func (c fakeGraphController) Restore(ctx context.Context) error  { return nil }
+func (c fakeGraphController) Discard(ctx context.Context) error  { return nil }
 func (c fakeGraphController) Generate(ctx context.Context) error { return nil }
 func (c fakeGraphController) Build(ctx context.Context) error    { return nil }
 func (c fakeGraphController) Install(ctx context.Context) error  { return nil }
-func (c fakeGraphController) Run(ctx context.Context) error      { return nil }
+func (c fakeGraphController) Runs(ctx context.Context) error     { return nil }
+func (c fakeGraphController) StopTap()                           {}
 func (c fakeGraphController) PreviewGo()                         {}
 func (c fakeGraphController) PreviewRawGo()                      {}
 func (c fakeGraphController) PreviewJSON()                       {}
 func (c fakeGraphController) HelpLicenses()                      {}
 func (c fakeGraphController) HelpAbout()                         {}
 
+func (c fakeGraphController) Test(context.Context, RunViewer) error { return nil }
+func (c fakeGraphController) Run(context.Context, RunViewer) error  { return nil }
+
+func (c fakeGraphController) Bench(context.Context) error             { return nil }
+func (c fakeGraphController) GenerateTestStubs(context.Context) error { return nil }
+
+func (c fakeGraphController) SelectRunConfig()                          {}
+func (c fakeGraphController) CommitRunConfig(ctx context.Context) error { return nil }
+func (c fakeGraphController) DeleteRunConfig(ctx context.Context) error { return nil }
+
 type fakeNodeController struct{}
 
 func (f fakeNodeController) Name() string             { return "Node 1" }
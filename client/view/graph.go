@@ -16,6 +16,7 @@ package view
 
 import (
 	"context"
+	"fmt"
 	"log"
 	"math"
 	"math/cmplx"
@@ -58,10 +59,16 @@ func (g *Graph) nearestPoint(x, y float64) (dist float64, pt Pointer) {
 // goroutines because cannot block in callback
 func (g *Graph) save(e dom.Object)     { g.view.commitSelected(e); go g.reallySave() }
 func (g *Graph) revert(e dom.Object)   { g.view.commitSelected(e); go g.reallyRevert() }
+func (g *Graph) restore(e dom.Object)  { go g.reallyRestore() }
+func (g *Graph) discard(e dom.Object)  { go g.reallyDiscard() }
 func (g *Graph) generate(e dom.Object) { g.view.commitSelected(e); go g.reallyGenerate() }
 func (g *Graph) build(e dom.Object)    { g.view.commitSelected(e); go g.reallyBuild() }
 func (g *Graph) install(e dom.Object)  { g.view.commitSelected(e); go g.reallyInstall() }
+func (g *Graph) test(e dom.Object)     { g.view.commitSelected(e); go g.reallyTest() }
+func (g *Graph) bench(e dom.Object)    { g.view.commitSelected(e); go g.reallyBench() }
+func (g *Graph) stubs(e dom.Object)    { g.view.commitSelected(e); go g.reallyTestStubs() }
 func (g *Graph) run(e dom.Object)      { g.view.commitSelected(e); go g.reallyRun() }
+func (g *Graph) runs(e dom.Object)     { g.view.commitSelected(e); go g.reallyRuns() }
 
 func (g *Graph) reallySave() {
 	if err := g.gc.Save(context.TODO()); err != nil {
@@ -75,6 +82,18 @@ func (g *Graph) reallyRevert() {
 	}
 }
 
+func (g *Graph) reallyRestore() {
+	if err := g.gc.Restore(context.TODO()); err != nil {
+		g.errors.setError("Couldn't restore: " + err.Error())
+	}
+}
+
+func (g *Graph) reallyDiscard() {
+	if err := g.gc.Discard(context.TODO()); err != nil {
+		g.errors.setError("Couldn't discard: " + err.Error())
+	}
+}
+
 func (g *Graph) reallyGenerate() {
 	if err := g.gc.Generate(context.TODO()); err != nil {
 		g.errors.setError("Couldn't generate: " + err.Error())
@@ -93,12 +112,94 @@ func (g *Graph) reallyInstall() {
 	}
 }
 
+func (g *Graph) reallyTest() {
+	g.clearRunInfo()
+	if err := g.gc.Test(context.TODO(), g); err != nil {
+		g.errors.setError("Couldn't test: " + err.Error())
+	}
+}
+
+func (g *Graph) reallyBench() {
+	if err := g.gc.Bench(context.TODO()); err != nil {
+		g.errors.setError("Couldn't benchmark: " + err.Error())
+	}
+}
+
+func (g *Graph) reallyTestStubs() {
+	if err := g.gc.GenerateTestStubs(context.TODO()); err != nil {
+		g.errors.setError("Couldn't generate node tests: " + err.Error())
+	}
+}
+
 func (g *Graph) reallyRun() {
-	if err := g.gc.Run(context.TODO()); err != nil {
+	g.clearRunInfo()
+	if err := g.gc.Run(context.TODO(), g); err != nil {
 		g.errors.setError("Couldn't run: " + err.Error())
 	}
 }
 
+func (g *Graph) reallyRuns() {
+	if err := g.gc.Runs(context.TODO()); err != nil {
+		g.errors.setError("Couldn't list run sessions: " + err.Error())
+	}
+}
+
+// Diagnose shows a diagnostic on the node it is about.
+func (g *Graph) Diagnose(d *Diagnostic) {
+	n := g.Nodes[d.Node]
+	if n == nil {
+		return
+	}
+	n.addDiagnostic(fmt.Sprintf("%s line %d: %s", d.Section, d.Line, d.Message))
+}
+
+// ShowProfile colours nodes by their share of CPU time.
+func (g *Graph) ShowProfile(nps []*NodeProfile) {
+	var total int64
+	for _, np := range nps {
+		total += np.CPUNanos
+	}
+	for _, np := range nps {
+		n := g.Nodes[np.Node]
+		if n == nil {
+			continue
+		}
+		share := 0.0
+		if total > 0 {
+			share = float64(np.CPUNanos) / float64(total)
+		}
+		n.setHeat(share, fmt.Sprintf("CPU: %.1f%% (%d ms), allocated: %d KiB", 100*share, np.CPUNanos/1e6, np.AllocBytes/1024))
+	}
+}
+
+// ShowChannelStats shows the activity of channels.
+func (g *Graph) ShowChannelStats(css []*ChannelStats) {
+	for _, cs := range css {
+		if c := g.Channels[cs.Channel]; c != nil {
+			c.showStats(cs)
+		}
+	}
+}
+
+// ShowHeld marks a channel as holding a value at a breakpoint, or not.
+func (g *Graph) ShowHeld(channel string, held bool) {
+	if c := g.Channels[channel]; c != nil {
+		c.showHeld(held)
+	}
+}
+
+// clearRunInfo removes diagnostics, profiles and channel stats from a
+// previous run.
+func (g *Graph) clearRunInfo() {
+	for _, n := range g.Nodes {
+		n.clearRunInfo()
+	}
+	for _, c := range g.Channels {
+		c.clearStats()
+		c.showHeld(false)
+	}
+}
+
 func (g *Graph) commit(dom.Object) {
 	go g.reallyCommit() // cannot block in callback
 }
@@ -109,6 +210,21 @@ func (g *Graph) reallyCommit() {
 	}
 }
 
+func (g *Graph) commitRunConfig(dom.Object) { go g.reallyCommitRunConfig() }
+func (g *Graph) deleteRunConfig(dom.Object) { go g.reallyDeleteRunConfig() }
+
+func (g *Graph) reallyCommitRunConfig() {
+	if err := g.gc.CommitRunConfig(context.TODO()); err != nil {
+		g.errors.setError("Couldn't save run configuration: " + err.Error())
+	}
+}
+
+func (g *Graph) reallyDeleteRunConfig() {
+	if err := g.gc.DeleteRunConfig(context.TODO()); err != nil {
+		g.errors.setError("Couldn't delete run configuration: " + err.Error())
+	}
+}
+
 func (g *Graph) gainFocus() { g.gc.GainFocus() }
 func (g *Graph) loseFocus() { go g.reallyCommit() }
 
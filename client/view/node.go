@@ -18,6 +18,7 @@ import (
 	"context"
 	"fmt"
 	"sort"
+	"strings"
 
 	"github.com/google/shenzhen-go/dom"
 )
@@ -38,6 +39,9 @@ type Node struct {
 
 	nc      NodeController
 	view    *View
+	diags   []string    // problems found while testing or running
+	heat    string      // profile summary
+	tooltip dom.Element // shows heat and diags
 	errors  errorViewer
 	graph   *Graph
 	deleted bool
@@ -109,6 +113,41 @@ func (n *Node) drop() {
 	}()
 }
 
+func (n *Node) addDiagnostic(msg string) {
+	n.diags = append(n.diags, msg)
+	n.updateTooltip()
+	n.Group.Element.ClassList().Add("error")
+}
+
+// setHeat tints the node by share (0 to 1), and adds the label to the tooltip.
+func (n *Node) setHeat(share float64, label string) {
+	n.heat = label
+	n.updateTooltip()
+	n.TextBox.Rect.SetAttribute("style", fmt.Sprintf("fill: rgba(221, 0, 51, %.2f)", 0.1+0.7*share))
+}
+
+func (n *Node) clearRunInfo() {
+	if len(n.diags) == 0 && n.heat == "" {
+		return
+	}
+	n.diags, n.heat = nil, ""
+	n.updateTooltip()
+	n.Group.Element.ClassList().Remove("error")
+	n.TextBox.Rect.RemoveAttribute("style")
+}
+
+func (n *Node) updateTooltip() {
+	if n.tooltip == nil {
+		n.tooltip = n.view.doc.MakeSVGElement("title")
+		n.TextBox.Group.AddChildren(n.tooltip)
+	}
+	lines := n.diags
+	if n.heat != "" {
+		lines = append([]string{n.heat}, lines...)
+	}
+	n.tooltip.Set("textContent", strings.Join(lines, "\n"))
+}
+
 func (n *Node) gainFocus() {
 	n.nc.GainFocus()
 	n.Group.Element.ClassList().Add("selected")
@@ -78,3 +78,19 @@ func (r *Route) Reroute() {
 		real(c1), imag(c1),
 		real(c2), imag(c2)))
 }
+
+// showFullness thickens the route according to how full the channel is
+// (0 to 1), and highlights it when the channel is full.
+func (r *Route) showFullness(full float64) {
+	r.line.SetAttribute("style", fmt.Sprintf("stroke-width: %.1f", 2+4*full))
+	if full >= 1 {
+		r.line.ClassList().Add("full")
+	} else {
+		r.line.ClassList().Remove("full")
+	}
+}
+
+func (r *Route) clearFullness() {
+	r.line.RemoveAttribute("style")
+	r.line.ClassList().Remove("full")
+}
@@ -70,14 +70,30 @@ func Setup(doc dom.Document, gc GraphController) {
 		AddEventListener("click", v.graph.save)
 	doc.ElementByID("graph-revert").
 		AddEventListener("click", v.graph.revert)
+	doc.ElementByID("graph-restore").
+		AddEventListener("click", v.graph.restore)
+	doc.ElementByID("graph-discard").
+		AddEventListener("click", v.graph.discard)
 	doc.ElementByID("graph-generate").
 		AddEventListener("click", v.graph.generate)
 	doc.ElementByID("graph-build").
 		AddEventListener("click", v.graph.build)
 	doc.ElementByID("graph-install").
 		AddEventListener("click", v.graph.install)
+	doc.ElementByID("graph-test").
+		AddEventListener("click", v.graph.test)
+	doc.ElementByID("graph-bench").
+		AddEventListener("click", v.graph.bench)
+	doc.ElementByID("graph-test-stubs").
+		AddEventListener("click", v.graph.stubs)
 	doc.ElementByID("graph-run").
 		AddEventListener("click", v.graph.run)
+	doc.ElementByID("graph-runs").
+		AddEventListener("click", v.graph.runs)
+	doc.ElementByID("runs-refresh").
+		AddEventListener("click", v.graph.runs)
+	doc.ElementByID("tap-stop").
+		AddEventListener("click", func(dom.Object) { gc.StopTap() })
 
 	doc.ElementByID("preview-go-link").
 		AddEventListener("click", func(dom.Object) { gc.PreviewGo() })
@@ -97,12 +113,43 @@ func Setup(doc dom.Document, gc GraphController) {
 		AddEventListener("change", v.graph.commit)
 	doc.ElementByID("graph-prop-is-command").
 		AddEventListener("change", v.graph.commit)
+	doc.ElementByID("graph-prop-handle-signals").
+		AddEventListener("change", v.graph.commit)
+	doc.ElementByID("graph-prop-shutdown-timeout").
+		AddEventListener("change", v.graph.commit)
+
+	doc.ElementByID("graph-prop-run-name").
+		AddEventListener("change", func(dom.Object) { gc.SelectRunConfig() })
+	doc.ElementByID("graph-prop-run-args").
+		AddEventListener("change", v.graph.commitRunConfig)
+	doc.ElementByID("graph-prop-run-env").
+		AddEventListener("change", v.graph.commitRunConfig)
+	doc.ElementByID("graph-prop-run-dir").
+		AddEventListener("change", v.graph.commitRunConfig)
+	doc.ElementByID("graph-prop-run-record").
+		AddEventListener("change", v.graph.commitRunConfig)
+	doc.ElementByID("graph-prop-run-record-file").
+		AddEventListener("change", v.graph.commitRunConfig)
+	doc.ElementByID("graph-prop-run-replay").
+		AddEventListener("change", v.graph.commitRunConfig)
+	doc.ElementByID("graph-prop-run-replay-file").
+		AddEventListener("change", v.graph.commitRunConfig)
+	doc.ElementByID("graph-prop-run-delete").
+		AddEventListener("click", v.graph.deleteRunConfig)
 
 	doc.ElementByID("channel-name").
 		AddEventListener("change", v.commitSelected)
 	doc.ElementByID("channel-capacity").
 		AddEventListener("change", v.commitSelected)
 
+	doc.ElementByID("channel-tap-link").
+		AddEventListener("click", func(e dom.Object) {
+			v.selectedItem.(*Channel).tap(e)
+		})
+	doc.ElementByID("channel-breakpoint-link").
+		AddEventListener("click", func(dom.Object) {
+			v.selectedItem.(*Channel).toggleBreakpoint()
+		})
 	doc.ElementByID("channel-delete-link").
 		AddEventListener("click", v.deleteSelected)
 
@@ -114,6 +161,12 @@ func Setup(doc dom.Document, gc GraphController) {
 		AddEventListener("change", v.commitSelected)
 	doc.ElementByID("node-wait").
 		AddEventListener("change", v.commitSelected)
+	doc.ElementByID("node-panic-policy").
+		AddEventListener("change", v.commitSelected)
+	doc.ElementByID("node-max-restarts").
+		AddEventListener("change", v.commitSelected)
+	doc.ElementByID("node-configurable").
+		AddEventListener("change", v.commitSelected)
 
 	// TODO(josh): reinstate Clone and Convert-To-Code links
 	doc.ElementByID("node-delete-link").
@@ -1,17 +1,30 @@
 module github.com/google/shenzhen-go
 
+go 1.27.1
+
+require (
+	github.com/golang/protobuf v1.2.0
+	github.com/gopherjs/gopherjs v0.0.0-20181103185306-d547d1d9531e
+	github.com/improbable-eng/grpc-web v0.0.0-20180502145718-72eb701d6f32
+	github.com/johanbrandhorst/protobuf v0.7.1
+	github.com/magefile/mage v1.8.0
+	github.com/prometheus/client_golang v0.0.0-20180713201052-bcbbc08eb2dd
+	github.com/zserge/webview v0.0.0-20180509070823-016c6ffd99f3
+	golang.org/x/image v0.0.0-20180708004352-c73c2afc3b81
+	golang.org/x/net v0.0.0-20180906233101-161cd47e91fd
+	google.golang.org/grpc v1.13.0
+	gopkg.in/d4l3k/messagediff.v1 v1.2.1
+)
+
 require (
 	github.com/beorn7/perks v0.0.0-20180321164747-3a771d992973 // indirect
 	github.com/d4l3k/messagediff v1.2.1 // indirect
 	github.com/davecgh/go-spew v1.1.1 // indirect
+	github.com/fsnotify/fsnotify v1.4.7 // indirect
 	github.com/golang/glog v0.0.0-20160126235308-23def4e6c14b // indirect
-	github.com/golang/protobuf v1.2.0
-	github.com/gopherjs/gopherjs v0.0.0-20181103185306-d547d1d9531e
 	github.com/gorilla/websocket v1.2.0 // indirect
-	github.com/improbable-eng/grpc-web v0.0.0-20180502145718-72eb701d6f32
-	github.com/johanbrandhorst/protobuf v0.7.1
+	github.com/hpcloud/tail v1.0.0 // indirect
 	github.com/kisielk/gotool v1.0.0 // indirect
-	github.com/magefile/mage v1.8.0
 	github.com/matttproud/golang_protobuf_extensions v1.0.1 // indirect
 	github.com/mwitkow/go-conntrack v0.0.0-20161129095857-cc309e4a2223 // indirect
 	github.com/neelance/astrewrite v0.0.0-20160511093645-99348263ae86 // indirect
@@ -19,7 +32,6 @@ require (
 	github.com/onsi/ginkgo v1.7.0 // indirect
 	github.com/onsi/gomega v1.4.3 // indirect
 	github.com/pmezard/go-difflib v1.0.0 // indirect
-	github.com/prometheus/client_golang v0.0.0-20180713201052-bcbbc08eb2dd
 	github.com/prometheus/client_model v0.0.0-20180712105110-5c3871d89910 // indirect
 	github.com/prometheus/common v0.0.0-20180518154759-7600349dcfe1 // indirect
 	github.com/prometheus/procfs v0.0.0-20180725123919-05ee40e3a273 // indirect
@@ -28,13 +40,14 @@ require (
 	github.com/spf13/cobra v0.0.3 // indirect
 	github.com/spf13/pflag v1.0.3 // indirect
 	github.com/stretchr/testify v1.2.2 // indirect
-	github.com/zserge/webview v0.0.0-20180509070823-016c6ffd99f3
 	golang.org/x/crypto v0.0.0-20181203042331-505ab145d0a9 // indirect
-	golang.org/x/image v0.0.0-20180708004352-c73c2afc3b81
-	golang.org/x/net v0.0.0-20180906233101-161cd47e91fd
 	golang.org/x/sync v0.0.0-20181108010431-42b317875d0f // indirect
+	golang.org/x/sys v0.0.0-20180909124046-d0be0721c37e // indirect
+	golang.org/x/text v0.3.0 // indirect
 	golang.org/x/tools v0.0.0-20181221001348-537d06c36207 // indirect
 	google.golang.org/genproto v0.0.0-20180726180014-2a72893556e4 // indirect
-	google.golang.org/grpc v1.13.0
-	gopkg.in/d4l3k/messagediff.v1 v1.2.1
+	gopkg.in/check.v1 v0.0.0-20161208181325-20d25e280405 // indirect
+	gopkg.in/fsnotify.v1 v1.4.7 // indirect
+	gopkg.in/tomb.v1 v1.0.0-20141024135613-dd632973f1e7 // indirect
+	gopkg.in/yaml.v2 v2.2.1 // indirect
 )
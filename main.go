@@ -31,6 +31,7 @@ import (
 	"github.com/improbable-eng/grpc-web/go/grpcweb"
 	"google.golang.org/grpc"
 
+	"github.com/google/shenzhen-go/model"
 	_ "github.com/google/shenzhen-go/parts"
 	pb "github.com/google/shenzhen-go/proto/go"
 	"github.com/google/shenzhen-go/server"
@@ -166,12 +167,14 @@ Usage:
   
 The (optional) commands are:
   
+  bench     generate Go packages and benchmarks, and run the benchmarks
   build     generate and build Go packages
   edit      launch a Shenzhen Go server and open the editor interface
   generate  generate Go packages
   install   generate and install Go packages
   run       generate Go package and run binaries
   serve     launch a Shenzhen Go server
+  test      generate Go packages and tests from fixtures, and test them
   
 "edit" is the default command.
 
@@ -183,6 +186,40 @@ Flags:
 	flag.PrintDefaults()
 }
 
+// forEachGraph loads each graph file, and calls f with it.
+func forEachGraph(cmd string, paths []string, f func(*model.Graph) error) error {
+	if len(paths) == 0 {
+		return fmt.Errorf("%s: no graph files given", cmd)
+	}
+	failed := 0
+	for _, p := range paths {
+		g, err := loadGraph(p)
+		if err != nil {
+			return err
+		}
+		if err := f(g); err != nil {
+			failed++
+		}
+	}
+	if failed > 0 {
+		return fmt.Errorf("%s: %d of %d graphs failed", cmd, failed, len(paths))
+	}
+	return nil
+}
+
+func loadGraph(path string) (*model.Graph, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	g, err := model.LoadJSON(f, path, "")
+	if err != nil {
+		return nil, fmt.Errorf("loading %s: %v", path, err)
+	}
+	return g, nil
+}
+
 func main() {
 	flag.Usage = usage
 	flag.Parse()
@@ -191,6 +228,13 @@ func main() {
 	args := flag.Args()
 	if len(args) > 0 {
 		switch args[0] {
+		case "bench":
+			if err := forEachGraph("bench", args[1:], func(g *model.Graph) error {
+				return server.Bench(os.Stdout, g)
+			}); err != nil {
+				log.Fatal(err)
+			}
+			return
 		case "build":
 			log.Fatalf("TODO: build is not yet implemented")
 		case "edit":
@@ -209,6 +253,13 @@ func main() {
 				log.Print(`Note: extra arguments to "serve" command are ignored`)
 			}
 			openUI = false
+		case "test":
+			if err := forEachGraph("test", args[1:], func(g *model.Graph) error {
+				return server.Test(os.Stdout, g, false, nil)
+			}); err != nil {
+				log.Fatal(err)
+			}
+			return
 		default:
 			// Edit, but every arg is a file.
 		}
@@ -0,0 +1,234 @@
+// Copyright 2018 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package model
+
+import (
+	"sort"
+	"text/template"
+	"time"
+
+	"github.com/google/shenzhen-go/model/pin"
+)
+
+// benchTemplateSrc is included at the end of testTemplate.
+const benchTemplateSrc = `
+{{- range $f := .Fixtures}}{{if $f.Inputs}}
+// Benchmark{{$f.Func}} sends b.N values on each input channel of the fixture
+// {{printf "%q" $f.Name}}, cycling through the fixture's values, and waits
+// for the output channels to be closed. It fails if they aren't closed
+// within the fixture's timeout of the last value being sent.
+func Benchmark{{$f.Func}}(b *testing.B) {
+	{{- range $n, $c := $.Channels}}
+	{{$n}} := make(chan {{$c.Type}}, {{$c.Capacity}})
+	{{- with $f.Input $n}}
+	var {{$n}}In []{{$c.Type}}
+	if err := json.Unmarshal([]byte({{printf "%q" .}}), &{{$n}}In); err != nil {
+		b.Fatalf("Couldn't decode the inputs for {{$n}}: %v", err)
+	}
+	if len({{$n}}In) == 0 {
+		{{$n}}In = make([]{{$c.Type}}, 1)
+	}
+	{{- else}}{{if $f.Output $n}}
+	{{$n}}Sends := make(chan {{$c.Type}})
+	{{$n}}Done := make(chan struct{})
+	go func(in <-chan {{$c.Type}}, out chan<- {{$c.Type}}) {
+		{{- if $f.Forwarded $n}}
+		for x := range in {
+			out <- x
+		}
+		close(out)
+		{{- else}}
+		for range in {
+		}
+		{{- end}}
+		close({{$n}}Done)
+	}({{$n}}Sends, {{$n}})
+	{{- end}}{{end}}
+	{{- if $f.Orphaned $n}}
+	close({{$f.SendTo $n}}) // only sent on by nodes that aren't started
+	{{- end}}
+	{{- end}}
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	var sent sync.WaitGroup
+	{{- range $n, $c := $.Channels}}{{if $f.Input $n}}
+	sent.Add(1)
+	go func(out chan<- {{$c.Type}}, in []{{$c.Type}}, n int) {
+		defer sent.Done()
+		for i := 0; i < n; i++ {
+			out <- in[i%len(in)]
+		}
+		close(out)
+	}({{$n}}, {{$n}}In, b.N)
+	{{- end}}{{end}}
+	{{range $node := $.Nodes}}
+		{{- if and $node.Enabled (not ($f.Skipped $node))}}
+	go {{$node.Identifier}}({{range $pin := $node.Part.Pins}}{{$f.Arg $node $pin}},{{end}})
+		{{- end}}
+	{{- end}}
+	sent.Wait()
+	timeout := time.After(time.Duration({{$f.Nanos}}))
+	{{- range $n, $c := $.Channels}}{{if $f.Output $n}}
+	select {
+	case <-{{$n}}Done:
+	case <-timeout:
+		b.Fatal("channel {{$n}}: timed out waiting for it to close")
+	}
+	{{- end}}{{end}}
+}
+{{end}}{{end}}
+
+{{- range $nb := .Benches}}
+// Benchmark{{$nb.Func}} sends b.N values on each input of the node
+// {{printf "%q" $nb.Node.Name}}, and waits for it to return. It fails if the
+// node doesn't return within {{$nb.Timeout}} of the last value being sent.
+func Benchmark{{$nb.Func}}(b *testing.B) {
+	{{- range $p := $nb.Inputs}}
+	{{$p.Name}} := make(chan {{$p.Type}}, {{$p.Cap}})
+	{{- if $p.Values}}
+	var {{$p.Name}}In []{{$p.Type}}
+	if err := json.Unmarshal([]byte({{printf "%q" $p.Values}}), &{{$p.Name}}In); err != nil {
+		b.Fatalf("Couldn't decode the values for {{$p.Name}}: %v", err)
+	}
+	if len({{$p.Name}}In) == 0 {
+		{{$p.Name}}In = make([]{{$p.Type}}, 1)
+	}
+	{{- else}}
+	{{$p.Name}}In := make([]{{$p.Type}}, 1)
+	{{- end}}
+	{{- end}}
+	{{- range $p := $nb.Outputs}}
+	{{$p.Name}} := make(chan {{$p.Type}}, {{$p.Cap}})
+	go func(in <-chan {{$p.Type}}) {
+		for range in {
+		}
+	}({{$p.Name}})
+	{{- end}}
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	done := make(chan struct{})
+	go func() {
+		{{$nb.Node.Identifier}}({{range $name, $type := $nb.Node.PinFullTypes}}{{$name}},{{end}})
+		close(done)
+	}()
+	var sent sync.WaitGroup
+	{{- range $p := $nb.Inputs}}
+	sent.Add(1)
+	go func(out chan<- {{$p.Type}}, in []{{$p.Type}}, n int) {
+		defer sent.Done()
+		for i := 0; i < n; i++ {
+			out <- in[i%len(in)]
+		}
+		close(out)
+	}({{$p.Name}}, {{$p.Name}}In, b.N)
+	{{- end}}
+	sent.Wait()
+	select {
+	case <-done:
+	case <-time.After(time.Duration({{$nb.Timeout.Nanoseconds}})):
+		b.Fatal("timed out waiting for the node to return")
+	}
+}
+{{end}}`
+
+var benchTemplate = template.Must(testTemplate.New("benchmarks").Parse(benchTemplateSrc))
+
+// nodeBench is a node, with the information needed to generate a benchmark.
+type nodeBench struct {
+	Node            *Node
+	Func            string // benchmark function name, without "Benchmark"
+	Inputs, Outputs []benchPin
+}
+
+// Timeout returns how long the benchmark waits for the node to return after
+// the last value is sent.
+func (nb *nodeBench) Timeout() time.Duration { return DefaultFixtureTimeout }
+
+// benchPin is a pin of a benchmarked node.
+type benchPin struct {
+	Name, Type string
+	Cap        int    // capacity of the connected channel, if any
+	Values     string // JSON array of values from a fixture, or ""
+}
+
+// HasBenchmarks reports whether any nodes would have a benchmark in the
+// output of WriteTestGoTo. Those are the enabled nodes with inputs.
+func (g *Graph) HasBenchmarks() bool {
+	for _, n := range g.Nodes {
+		if benchable(n) {
+			return true
+		}
+	}
+	return false
+}
+
+func benchable(n *Node) bool {
+	if !n.Enabled {
+		return false
+	}
+	for _, p := range n.Part.Pins() {
+		if p.Direction == pin.Input {
+			return true
+		}
+	}
+	return false
+}
+
+// nodeBenches works out the benchmarks for the nodes. Values for an input
+// pin come from the first fixture with values for the connected channel,
+// or else the zero value is sent.
+func (g *Graph) nodeBenches(fixtures []*Fixture) []*nodeBench {
+	values := func(c string) string {
+		for _, f := range fixtures {
+			if v, ok := f.Inputs[c]; ok {
+				return string(v)
+			}
+			if v, ok := f.Outputs[c]; ok {
+				return string(v)
+			}
+		}
+		return ""
+	}
+	var nbs []*nodeBench
+	for _, n := range g.Nodes {
+		if !benchable(n) {
+			continue
+		}
+		nb := &nodeBench{
+			Node: n,
+			Func: "Node_" + n.Identifier(),
+		}
+		for pn, p := range n.Part.Pins() {
+			bp := benchPin{Name: pn, Type: n.PinTypes[pn].String()}
+			c := n.Connections[pn]
+			if ch := g.Channels[c]; ch != nil {
+				bp.Cap = ch.Capacity
+			}
+			if p.Direction == pin.Input {
+				bp.Values = values(c)
+				nb.Inputs = append(nb.Inputs, bp)
+			} else {
+				nb.Outputs = append(nb.Outputs, bp)
+			}
+		}
+		sort.Slice(nb.Inputs, func(i, j int) bool { return nb.Inputs[i].Name < nb.Inputs[j].Name })
+		sort.Slice(nb.Outputs, func(i, j int) bool { return nb.Outputs[i].Name < nb.Outputs[j].Name })
+		nbs = append(nbs, nb)
+	}
+	sort.Slice(nbs, func(i, j int) bool { return nbs[i].Node.Name < nbs[j].Node.Name })
+	return nbs
+}
@@ -0,0 +1,370 @@
+// Copyright 2018 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package model
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+	"text/template"
+	"time"
+
+	"github.com/google/shenzhen-go/model/pin"
+	"github.com/google/shenzhen-go/source"
+)
+
+// DefaultFixtureTimeout is how long a fixture test waits for the output
+// channels to close and the nodes to finish, if the fixture doesn't say.
+const DefaultFixtureTimeout = 10 * time.Second
+
+// Fixture is a test case for a graph: values to send on some channels, and
+// the values expected on other channels as a result. The nodes upstream of
+// the input channels (see ReplaySkips) are not started.
+//
+// Fixtures are loaded from JSON files in the testdata directory next to the
+// graph file, named after the graph: the fixture "basic" for the graph in
+// foo.szgo is in testdata/foo_basic.json.
+type Fixture struct {
+	Name    string                     `json:"-"`
+	Inputs  map[string]json.RawMessage `json:"inputs"`            // channel -> JSON array of values to send
+	Outputs map[string]json.RawMessage `json:"outputs"`           // channel -> JSON array of values expected
+	Timeout string                     `json:"timeout,omitempty"` // e.g. "5s"; DefaultFixtureTimeout if empty
+}
+
+// FixturePaths returns the paths of the fixture files for the graph.
+func (g *Graph) FixturePaths() ([]string, error) {
+	base := strings.TrimSuffix(filepath.Base(g.FilePath), filepath.Ext(g.FilePath))
+	return filepath.Glob(filepath.Join(filepath.Dir(g.FilePath), "testdata", base+"_*.json"))
+}
+
+// LoadFixtures loads and checks all the fixtures for the graph.
+func (g *Graph) LoadFixtures() ([]*Fixture, error) {
+	paths, err := g.FixturePaths()
+	if err != nil {
+		return nil, err
+	}
+	base := strings.TrimSuffix(filepath.Base(g.FilePath), filepath.Ext(g.FilePath))
+	fs := make([]*Fixture, 0, len(paths))
+	for _, p := range paths {
+		f, err := loadFixture(p)
+		if err != nil {
+			return nil, fmt.Errorf("fixture %s: %v", p, err)
+		}
+		f.Name = strings.TrimSuffix(strings.TrimPrefix(filepath.Base(p), base+"_"), ".json")
+		if err := g.checkFixture(f); err != nil {
+			return nil, fmt.Errorf("fixture %s: %v", p, err)
+		}
+		fs = append(fs, f)
+	}
+	return fs, nil
+}
+
+func loadFixture(path string) (*Fixture, error) {
+	r, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer r.Close()
+	f := new(Fixture)
+	if err := json.NewDecoder(r).Decode(f); err != nil {
+		return nil, err
+	}
+	return f, nil
+}
+
+func (g *Graph) checkFixture(f *Fixture) error {
+	if len(f.Outputs) == 0 {
+		return fmt.Errorf("no outputs to check")
+	}
+	for c := range f.Inputs {
+		if g.Channels[c] == nil {
+			return fmt.Errorf("no such input channel %q", c)
+		}
+		if _, both := f.Outputs[c]; both {
+			return fmt.Errorf("channel %q is both an input and an output", c)
+		}
+	}
+	for c := range f.Outputs {
+		if g.Channels[c] == nil {
+			return fmt.Errorf("no such output channel %q", c)
+		}
+	}
+	if _, err := f.timeout(); err != nil {
+		return err
+	}
+	return nil
+}
+
+func (f *Fixture) timeout() (time.Duration, error) {
+	if f.Timeout == "" {
+		return DefaultFixtureTimeout, nil
+	}
+	return time.ParseDuration(f.Timeout)
+}
+
+const testTemplateSrc = `// Tests for {{.PackageName}}, automatically generated by Shenzhen Go from
+// the fixtures for the graph.
+
+{{if .IsCommand -}}
+package main
+{{else -}}
+package {{.PackageName}}
+{{end}}
+
+import (
+	{{range .Imports -}}
+	{{.}}
+	{{end -}}
+)
+
+{{range $f := .Fixtures}}
+// Test{{$f.Func}} checks the graph against the fixture {{printf "%q" $f.Name}}.
+func Test{{$f.Func}}(t *testing.T) {
+	deadline := time.Now().Add(time.Duration({{$f.Nanos}}))
+	{{range $n, $c := $.Channels}}
+	{{$n}} := make(chan {{$c.Type}}, {{$c.Capacity}})
+	{{- with $f.Input $n}}
+	var {{$n}}In []{{$c.Type}}
+	if err := json.Unmarshal([]byte({{printf "%q" .}}), &{{$n}}In); err != nil {
+		t.Fatalf("Couldn't decode the inputs for {{$n}}: %v", err)
+	}
+	go func(out chan<- {{$c.Type}}, in []{{$c.Type}}) {
+		for _, x := range in {
+			out <- x
+		}
+		close(out)
+	}({{$n}}, {{$n}}In)
+	{{- else}}{{with $f.Output $n}}
+	var {{$n}}Want []{{$c.Type}}
+	if err := json.Unmarshal([]byte({{printf "%q" .}}), &{{$n}}Want); err != nil {
+		t.Fatalf("Couldn't decode the expected outputs for {{$n}}: %v", err)
+	}
+	{{$n}}Sends := make(chan {{$c.Type}})
+	{{$n}}Got := make(chan []{{$c.Type}}, 1)
+	go func(in <-chan {{$c.Type}}, out chan<- {{$c.Type}}) {
+		var got []{{$c.Type}}
+		for x := range in {
+			got = append(got, x)
+			{{- if $f.Forwarded $n}}
+			out <- x
+			{{- end}}
+		}
+		{{- if $f.Forwarded $n}}
+		close(out)
+		{{- end}}
+		{{$n}}Got <- got
+	}({{$n}}Sends, {{$n}})
+	{{- end}}{{end}}
+	{{- if $f.Orphaned $n}}
+	close({{$f.SendTo $n}}) // only sent on by nodes that aren't started
+	{{- end}}
+	{{- end}}
+	var wg sync.WaitGroup
+	{{range $node := $.Nodes}}
+		{{- if and $node.Enabled (not ($f.Skipped $node))}}
+			{{- if $node.Wait}}
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		{{$node.Identifier}}({{range $pin := $node.Part.Pins}}{{$f.Arg $node $pin}},{{end}})
+	}()
+			{{- else}}
+	go {{$node.Identifier}}({{range $pin := $node.Part.Pins}}{{$f.Arg $node $pin}},{{end}})
+			{{- end}}
+		{{- end}}
+	{{- end}}
+	{{range $n, $c := $.Channels}}{{if $f.Output $n}}
+	select {
+	case got := <-{{$n}}Got:
+		if len(got) != len({{$n}}Want) || (len(got) > 0 && !reflect.DeepEqual(got, {{$n}}Want)) {
+			t.Errorf("channel {{$n}}: got %#v, want %#v", got, {{$n}}Want)
+		}
+	case <-time.After(time.Until(deadline)):
+		t.Errorf("channel {{$n}}: timed out waiting for it to close, want %#v", {{$n}}Want)
+	}
+	{{- end}}{{end}}
+
+	nodesDone := make(chan struct{})
+	go func() {
+		wg.Wait()
+		close(nodesDone)
+	}()
+	select {
+	case <-nodesDone:
+	case <-time.After(time.Until(deadline)):
+		t.Error("timed out waiting for the nodes to finish")
+	}
+}
+{{end}}
+{{- template "benchmarks" .}}`
+
+var testTemplate = template.Must(template.New("golang-test").Parse(testTemplateSrc))
+
+// testGenInput is the input to testTemplate.
+type testGenInput struct {
+	*Graph
+	Fixtures []*fixtureGen
+	Benches  []*nodeBench
+}
+
+// Imports returns the imports needed by the test: the graph's imports that
+// are used by channel and pin types, and those used by the test itself.
+func (i testGenInput) Imports() []string {
+	types := make([]string, 0, len(i.Channels))
+	for _, c := range i.Channels {
+		types = append(types, c.Type.String())
+	}
+	extra := []string{`"testing"`}
+	if len(i.Fixtures) > 0 {
+		extra = append(extra, `"encoding/json"`, `"reflect"`, `"sync"`, `"time"`)
+	}
+	if len(i.Benches) > 0 {
+		extra = append(extra, `"sync"`, `"time"`)
+	}
+	for _, nb := range i.Benches {
+		for _, p := range append(nb.Inputs, nb.Outputs...) {
+			types = append(types, p.Type)
+			if p.Values != "" {
+				extra = append(extra, `"encoding/json"`)
+			}
+		}
+	}
+	return i.importsFor(types, extra...)
+}
+
+// importsFor returns the graph's imports that are used by the types, together
+// with the extra imports.
+func (g *Graph) importsFor(types []string, extra ...string) []string {
+	m := source.NewStringSet(extra...)
+	for _, imp := range g.AllImports() {
+		used := regexp.MustCompile(`\b` + regexp.QuoteMeta(importName(imp)) + `\.`)
+		for _, t := range types {
+			if used.MatchString(t) {
+				m.Add(imp)
+				break
+			}
+		}
+	}
+	return m.Slice()
+}
+
+// importName returns the name a package is imported as, given an import
+// line like `"path/to/pkg"` or `name "path/to/pkg"`.
+func importName(imp string) string {
+	if i := strings.IndexAny(imp, " \t"); i > 0 && !strings.HasPrefix(imp, `"`) {
+		return imp[:i]
+	}
+	p := strings.Trim(imp, "\"` \t")
+	return p[strings.LastIndex(p, "/")+1:]
+}
+
+// fixtureGen is a fixture, with the information needed to generate a test.
+type fixtureGen struct {
+	*Fixture
+	Func    string          // test function name
+	Nanos   int64           // timeout
+	skip    map[string]bool // nodes not started
+	orphans map[string]bool // channels to close at the start
+	readers map[string]bool // channels read by a started node
+}
+
+// Input returns the JSON of the values to send on the channel, or "".
+func (f *fixtureGen) Input(c string) string { return string(f.Inputs[c]) }
+
+// Output returns the JSON of the values expected on the channel, or "".
+func (f *fixtureGen) Output(c string) string { return string(f.Outputs[c]) }
+
+// Skipped reports whether the node is not started.
+func (f *fixtureGen) Skipped(n *Node) bool { return f.skip[n.Name] }
+
+// Orphaned reports whether the channel should be closed at the start.
+func (f *fixtureGen) Orphaned(c string) bool { return f.orphans[c] }
+
+// Forwarded reports whether values on an output channel should be passed on
+// to the nodes that read it.
+func (f *fixtureGen) Forwarded(c string) bool { return f.readers[c] }
+
+// SendTo returns the channel that senders should send on.
+func (f *fixtureGen) SendTo(c string) string {
+	if _, out := f.Outputs[c]; out {
+		return c + "Sends"
+	}
+	return c
+}
+
+// Arg returns the argument passed to a node for one of its pins.
+func (f *fixtureGen) Arg(n *Node, p *pin.Definition) string {
+	c := n.Connections[p.Name]
+	if c == "nil" || p.Direction != pin.Output {
+		return c
+	}
+	return f.SendTo(c)
+}
+
+// WriteTestGoTo writes a Go test file for the package generated from the
+// graph, with one test per fixture. Each test starts the nodes directly,
+// sends the fixture's inputs, and compares everything sent on each output
+// channel until it is closed with the expected values. It then waits for
+// the nodes marked to be waited for to finish. The file also has
+// benchmarks; see HasBenchmarks.
+func (g *Graph) WriteTestGoTo(w io.Writer, fixtures []*Fixture) error {
+	if err := g.InferTypes(); err != nil {
+		return err
+	}
+	in := testGenInput{Graph: g}
+	for _, f := range fixtures {
+		d, err := f.timeout()
+		if err != nil {
+			return err
+		}
+		inputs := make([]string, 0, len(f.Inputs))
+		for c := range f.Inputs {
+			inputs = append(inputs, c)
+		}
+		sort.Strings(inputs)
+		skip, orphans := g.ReplaySkips(inputs)
+		fg := &fixtureGen{
+			Fixture: f,
+			Func:    "Fixture_" + Mangle(f.Name),
+			Nanos:   int64(d),
+			skip:    skip,
+			orphans: orphans,
+			readers: make(map[string]bool),
+		}
+		for _, n := range g.Nodes {
+			if !n.Enabled || skip[n.Name] {
+				continue
+			}
+			for pn, p := range n.Part.Pins() {
+				if p.Direction == pin.Input {
+					fg.readers[n.Connections[pn]] = true
+				}
+			}
+		}
+		in.Fixtures = append(in.Fixtures, fg)
+	}
+	in.Benches = g.nodeBenches(fixtures)
+	buf := new(bytes.Buffer)
+	if err := testTemplate.Execute(buf, in); err != nil {
+		return err
+	}
+	return source.GoFmt(w, buf)
+}
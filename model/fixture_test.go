@@ -0,0 +1,151 @@
+// Copyright 2018 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package model
+
+import (
+	"bytes"
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/google/shenzhen-go/model/pin"
+)
+
+func fixtureTestGraph(dir string) *Graph {
+	return &Graph{
+		FilePath:    filepath.Join(dir, "double.szgo"),
+		Name:        "double",
+		PackagePath: "example.com/double",
+		Nodes: map[string]*Node{
+			"src": {
+				Name:    "src",
+				Enabled: true,
+				Part: &FakePart{Pns: pin.NewMap(
+					&pin.Definition{Name: "out", Type: "int", Direction: pin.Output},
+				)},
+				Connections: map[string]string{"out": "nums"},
+			},
+			"dbl": {
+				Name:    "dbl",
+				Enabled: true,
+				Part: &FakePart{Pns: pin.NewMap(
+					&pin.Definition{Name: "in", Type: "int", Direction: pin.Input},
+					&pin.Definition{Name: "out", Type: "int", Direction: pin.Output},
+				)},
+				Connections: map[string]string{"in": "nums", "out": "doubled"},
+			},
+			"sink": {
+				Name:    "sink",
+				Enabled: true,
+				Wait:    true,
+				Part: &FakePart{Pns: pin.NewMap(
+					&pin.Definition{Name: "in", Type: "int", Direction: pin.Input},
+				)},
+				Connections: map[string]string{"in": "doubled"},
+			},
+		},
+		Channels: map[string]*Channel{
+			"nums":    {Name: "nums"},
+			"doubled": {Name: "doubled"},
+		},
+	}
+}
+
+func TestFixtures(t *testing.T) {
+	dir, err := ioutil.TempDir("", "fixture_test")
+	if err != nil {
+		t.Fatalf("ioutil.TempDir() = error %v", err)
+	}
+	defer os.RemoveAll(dir)
+	td := filepath.Join(dir, "testdata")
+	if err := os.Mkdir(td, 0755); err != nil {
+		t.Fatalf("os.Mkdir(testdata) = error %v", err)
+	}
+	files := map[string]string{
+		"double_small.json": `{"inputs": {"nums": [1, 2]}, "outputs": {"doubled": [2, 4]}, "timeout": "1s"}`,
+		"other_thing.json":  `{"outputs": {"nope": []}}`,
+	}
+	for name, contents := range files {
+		if err := ioutil.WriteFile(filepath.Join(td, name), []byte(contents), 0644); err != nil {
+			t.Fatalf("ioutil.WriteFile(%s) = error %v", name, err)
+		}
+	}
+
+	g := fixtureTestGraph(dir)
+	g.RefreshChannelsPins()
+	fs, err := g.LoadFixtures()
+	if err != nil {
+		t.Fatalf("g.LoadFixtures() = error %v", err)
+	}
+	if len(fs) != 1 || fs[0].Name != "small" {
+		t.Fatalf("g.LoadFixtures() = %v, want one fixture named small", fs)
+	}
+
+	var buf bytes.Buffer
+	if err := g.WriteTestGoTo(&buf, fs); err != nil {
+		t.Fatalf("g.WriteTestGoTo() = error %v", err)
+	}
+	src := buf.String()
+	for _, want := range []string{
+		"package double",
+		"func TestFixture_small(t *testing.T) {",
+		`json.Unmarshal([]byte("[1, 2]"), &numsIn)`,
+		"go dbl(nums, doubledSends)",
+		"sink(doubled)",
+		"wg.Add(1)",
+		"out <- x",
+		"close(out)",
+		"<-nodesDone",
+		"func BenchmarkFixture_small(b *testing.B) {",
+		"case <-doubledDone:",
+		"timeout := time.After(time.Duration(1000000000))",
+		"func BenchmarkNode_dbl(b *testing.B) {",
+		"case <-time.After(time.Duration(10000000000)):",
+		`json.Unmarshal([]byte("[1, 2]"), &inIn)`,
+		"func BenchmarkNode_sink(b *testing.B) {",
+	} {
+		if !strings.Contains(src, want) {
+			t.Errorf("generated test doesn't contain %q:\n%s", want, src)
+		}
+	}
+	if strings.Contains(src, "go src(") {
+		t.Errorf("generated test starts the node replaced by inputs:\n%s", src)
+	}
+	if strings.Contains(src, "BenchmarkNode_src") {
+		t.Errorf("generated test has a benchmark for a node without inputs:\n%s", src)
+	}
+}
+
+func TestCheckFixture(t *testing.T) {
+	g := fixtureTestGraph("")
+	tests := []struct {
+		name string
+		f    *Fixture
+	}{
+		{"no outputs", &Fixture{}},
+		{"no such input", &Fixture{Inputs: map[string]json.RawMessage{"x": nil}, Outputs: map[string]json.RawMessage{"doubled": nil}}},
+		{"no such output", &Fixture{Outputs: map[string]json.RawMessage{"x": nil}}},
+		{"input and output", &Fixture{Inputs: map[string]json.RawMessage{"nums": nil}, Outputs: map[string]json.RawMessage{"nums": nil}}},
+		{"bad timeout", &Fixture{Outputs: map[string]json.RawMessage{"doubled": nil}, Timeout: "soon"}},
+	}
+	for _, test := range tests {
+		if err := g.checkFixture(test.f); err == nil {
+			t.Errorf("g.checkFixture(%s) = nil error, want error", test.name)
+		}
+	}
+}
@@ -20,12 +20,17 @@ import (
 	"fmt"
 	"io"
 	"strings"
+	"time"
 
 	"github.com/google/shenzhen-go/source"
 )
 
 var typeEmptyInterface = source.MustNewType("", "interface{}")
 
+// DefaultShutdownTimeout is how long a command that handles signals waits
+// for the nodes to finish, if the graph doesn't say.
+const DefaultShutdownTimeout = 10 * time.Second
+
 // Graph represents a package / program / collection of nodes and channels.
 type Graph struct {
 	FilePath    string              `json:"-"` // path to the JSON source
@@ -36,9 +41,38 @@ type Graph struct {
 	Nodes       map[string]*Node    `json:"nodes"`    // name -> node
 	Channels    map[string]*Channel `json:"channels"` // name -> channel
 
+	// HandleSignals makes a command shut down gracefully on SIGINT or
+	// SIGTERM, waiting up to ShutdownTimeout (e.g. "5s") for the nodes to
+	// finish. See WriteGoTo for the details.
+	HandleSignals   bool   `json:"handle_signals,omitempty"`
+	ShutdownTimeout string `json:"shutdown_timeout,omitempty"`
+
+	RunConfigs map[string]*RunConfig `json:"run_configs,omitempty"` // name -> run config
+
 	types source.TypeInferenceMap
 }
 
+// ShutdownTimeoutDuration parses ShutdownTimeout; see ParseShutdownTimeout.
+func (g *Graph) ShutdownTimeoutDuration() (time.Duration, error) {
+	return ParseShutdownTimeout(g.ShutdownTimeout)
+}
+
+// ParseShutdownTimeout parses a shutdown timeout, which must be positive.
+// The empty string means DefaultShutdownTimeout.
+func ParseShutdownTimeout(s string) (time.Duration, error) {
+	if s == "" {
+		return DefaultShutdownTimeout, nil
+	}
+	d, err := time.ParseDuration(s)
+	if err != nil {
+		return 0, err
+	}
+	if d <= 0 {
+		return 0, fmt.Errorf("shutdown timeout %v is not positive", d)
+	}
+	return d, nil
+}
+
 // NewGraph returns a new empty graph associated with a file path.
 func NewGraph(filePath, urlPath, pkgPath string) *Graph {
 	return &Graph{
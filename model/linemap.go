@@ -0,0 +1,194 @@
+// Copyright 2018 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package model
+
+import (
+	"go/ast"
+	"go/parser"
+	"go/token"
+)
+
+// The sections of a node's implementation.
+const (
+	SectionHead = "head"
+	SectionBody = "body"
+	SectionTail = "tail"
+)
+
+// SourcePos is a line within one section of a node's implementation.
+type SourcePos struct {
+	Node    string
+	Section string
+	Line    int // 1-based, within the section
+}
+
+// LineMap maps line numbers in generated Go source to the node sections
+// they came from. Lines that didn't come from a node aren't in the map.
+type LineMap map[int]SourcePos
+
+// LineMap works out which lines of src came from which part of which node.
+// src should be the output of WriteGoTo, and the node implementations
+// shouldn't have changed since it was written.
+func (g *Graph) LineMap(src []byte) (LineMap, error) {
+	fset := token.NewFileSet()
+	f, err := parser.ParseFile(fset, "generated.go", src, 0)
+	if err != nil {
+		return nil, err
+	}
+	byIdent := make(map[string]*Node, len(g.Nodes))
+	for _, n := range g.Nodes {
+		byIdent[n.Identifier()] = n
+	}
+	lm := make(LineMap)
+	for _, d := range f.Decls {
+		fd, ok := d.(*ast.FuncDecl)
+		if !ok || fd.Body == nil {
+			continue
+		}
+		n := byIdent[fd.Name.Name]
+		if n == nil {
+			continue
+		}
+		lm.addNode(fset, n, fd.Body.List)
+	}
+	return lm, nil
+}
+
+// addNode maps the statements of a node function, which are laid out as in
+// goTemplate.
+func (lm LineMap) addNode(fset *token.FileSet, n *Node, stmts []ast.Stmt) {
+	if n.UsesMultiplicity() {
+		stmts = skip(stmts, 1) // multiplicity := ...
+	}
+
+	headFirst, headCount, ok := parseSection(n.Impl.Head)
+	if !ok || headCount > len(stmts) {
+		return
+	}
+	lm.addSection(fset, n.Name, SectionHead, headFirst, stmts[:headCount])
+	stmts = stmts[headCount:]
+
+	if n.Impl.Tail != "" && len(stmts) > 0 {
+		if lit := deferredFunc(stmts[0]); lit != nil {
+			if first, _, ok := parseSection(n.Impl.Tail); ok {
+				lm.addSection(fset, n.Name, SectionTail, first, lit.Body.List)
+			}
+		}
+		stmts = stmts[1:]
+	}
+
+	bodyFirst, _, ok := parseSection(n.Impl.Body)
+	if !ok {
+		return
+	}
+	if n.SingleInstance() {
+		if n.UsesInstanceNum() {
+			stmts = skip(stmts, 1) // const instanceNumber = 0
+		}
+		lm.addSection(fset, n.Name, SectionBody, bodyFirst, recoveredBody(n, stmts))
+		return
+	}
+
+	// var multWG ...; multWG.Add(...); defer multWG.Wait(); for ... { go func() { ... }() }
+	if len(stmts) < 4 {
+		return
+	}
+	loop, ok := stmts[3].(*ast.ForStmt)
+	if !ok {
+		return
+	}
+	for _, s := range loop.Body.List {
+		gs, ok := s.(*ast.GoStmt)
+		if !ok {
+			continue
+		}
+		lit, ok := gs.Call.Fun.(*ast.FuncLit)
+		if !ok {
+			continue
+		}
+		lm.addSection(fset, n.Name, SectionBody, bodyFirst, recoveredBody(n, skip(lit.Body.List, 1))) // defer multWG.Done()
+	}
+}
+
+// addSection maps the lines spanned by stmts, the first of which came from
+// line first of the section.
+func (lm LineMap) addSection(fset *token.FileSet, node, section string, first int, stmts []ast.Stmt) {
+	if len(stmts) == 0 {
+		return
+	}
+	start := fset.Position(stmts[0].Pos()).Line
+	end := fset.Position(stmts[len(stmts)-1].End()).Line
+	for l := start; l <= end; l++ {
+		lm[l] = SourcePos{
+			Node:    node,
+			Section: section,
+			Line:    l - start + first,
+		}
+	}
+}
+
+// parseSection parses a section of code on its own, returning the line of
+// the first statement and the number of statements.
+func parseSection(code string) (first, count int, ok bool) {
+	fset := token.NewFileSet()
+	// The section starts on line 2.
+	f, err := parser.ParseFile(fset, "", "package p; func _() {\n"+code+"\n}", 0)
+	if err != nil {
+		return 0, 0, false
+	}
+	stmts := f.Decls[0].(*ast.FuncDecl).Body.List
+	if len(stmts) == 0 {
+		return 0, 0, true
+	}
+	return fset.Position(stmts[0].Pos()).Line - 1, len(stmts), true
+}
+
+// deferredFunc returns the function literal in `defer func() { ... }()`.
+func deferredFunc(s ast.Stmt) *ast.FuncLit {
+	ds, ok := s.(*ast.DeferStmt)
+	if !ok {
+		return nil
+	}
+	lit, _ := ds.Call.Fun.(*ast.FuncLit)
+	return lit
+}
+
+// recoveredBody returns the statements of the body of the node, unwrapping
+// the recoverNode call if the node recovers from panics.
+func recoveredBody(n *Node, stmts []ast.Stmt) []ast.Stmt {
+	if !n.Recovers() || len(stmts) != 1 {
+		return stmts
+	}
+	es, ok := stmts[0].(*ast.ExprStmt)
+	if !ok {
+		return nil
+	}
+	call, ok := es.X.(*ast.CallExpr)
+	if !ok || len(call.Args) == 0 {
+		return nil
+	}
+	lit, ok := call.Args[len(call.Args)-1].(*ast.FuncLit)
+	if !ok {
+		return nil
+	}
+	return lit.Body.List
+}
+
+func skip(stmts []ast.Stmt, n int) []ast.Stmt {
+	if len(stmts) < n {
+		return nil
+	}
+	return stmts[n:]
+}
@@ -0,0 +1,86 @@
+// Copyright 2018 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package model
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestLineMap(t *testing.T) {
+	for _, test := range []struct{ mult, policy string }{
+		{"1", ""},
+		{"3", ""},
+		{"1", PanicRestart},
+		{"3", PanicStop},
+	} {
+		mult, policy := test.mult, test.policy
+		g := &Graph{
+			Name:        "linemap",
+			PackagePath: "linemap",
+			Nodes: map[string]*Node{
+				"foo": {
+					Name:         "foo",
+					Enabled:      true,
+					Multiplicity: mult,
+					PanicPolicy:  policy,
+					Part: &FakePart{
+						Head: "headVar := 1\n_ = headVar",
+						Body: "bodyVar := 2\n\n_ = bodyVar",
+						Tail: `println("tailCall")`,
+					},
+					Connections: map[string]string{},
+				},
+			},
+		}
+		src, err := g.Go()
+		if err != nil {
+			t.Fatalf("g.Go() = error %v", err)
+		}
+		lm, err := g.LineMap([]byte(src))
+		if err != nil {
+			t.Fatalf("g.LineMap() = error %v", err)
+		}
+		lines := strings.Split(src, "\n")
+		lineOf := func(s string) int {
+			for i, l := range lines {
+				if strings.Contains(l, s) {
+					return i + 1
+				}
+			}
+			t.Fatalf("generated source doesn't contain %q:\n%s", s, src)
+			return 0
+		}
+		tests := []struct {
+			code string
+			want SourcePos
+		}{
+			{"headVar := 1", SourcePos{"foo", SectionHead, 1}},
+			{"_ = headVar", SourcePos{"foo", SectionHead, 2}},
+			{"bodyVar := 2", SourcePos{"foo", SectionBody, 1}},
+			{"_ = bodyVar", SourcePos{"foo", SectionBody, 3}},
+			{"tailCall", SourcePos{"foo", SectionTail, 1}},
+		}
+		for _, test := range tests {
+			l := lineOf(test.code)
+			if got := lm[l]; got != test.want {
+				t.Errorf("multiplicity %s, policy %q: lm[%d] (%q) = %+v, want %+v", mult, policy, l, test.code, got, test.want)
+			}
+		}
+		if got, ok := lm[lineOf("wg.Wait()")]; ok {
+			t.Errorf("multiplicity %s, policy %q: lm[line of wg.Wait()] = %+v, want not present", mult, policy, got)
+		}
+	}
+}
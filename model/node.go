@@ -29,6 +29,28 @@ var (
 	instanceNumUsageRE  = regexp.MustCompile(`\binstanceNumber\b`)
 )
 
+// Panic policies for nodes: what happens when the node's body panics.
+const (
+	// PanicCrash lets the panic crash the program, as usual.
+	PanicCrash = "crash"
+
+	// PanicStop logs the panic and stops the node, as if the body returned.
+	PanicStop = "stop"
+
+	// PanicRestart logs the panic and runs the body again after a backoff,
+	// up to MaxRestarts times, and then stops the node.
+	PanicRestart = "restart"
+)
+
+// ValidPanicPolicy reports whether p is a panic policy ("" means PanicCrash).
+func ValidPanicPolicy(p string) bool {
+	switch p {
+	case "", PanicCrash, PanicStop, PanicRestart:
+		return true
+	}
+	return false
+}
+
 // Node models a goroutine. This is the "real" model type for nodes.
 // It can be marshalled and unmarshalled to JSON sensibly.
 type Node struct {
@@ -38,6 +60,9 @@ type Node struct {
 	Enabled      bool
 	Multiplicity string
 	Wait         bool
+	PanicPolicy  string   // one of the Panic constants; "" means PanicCrash
+	MaxRestarts  int      // with PanicRestart, how many times to restart the body
+	Configurable []string // names of settings to make configurable
 	X, Y         float64
 	Connections  map[string]string // Pin name -> channel name
 	Impl         PartImpl          // Final implementation after type inference
@@ -53,6 +78,9 @@ func (n *Node) Copy() *Node {
 		Enabled:      n.Enabled,
 		Multiplicity: n.Multiplicity,
 		Wait:         n.Wait,
+		PanicPolicy:  n.PanicPolicy,
+		MaxRestarts:  n.MaxRestarts,
+		Configurable: append([]string(nil), n.Configurable...),
 		Part:         n.Part.Clone(),
 		// TODO: find a better location
 		X: n.X + 8,
@@ -74,6 +102,7 @@ func (n *Node) ExpandedMult() string {
 func (n *Node) UsesMultiplicity() bool {
 	// Again, could do this more properly by parsing the code.
 	return n.Multiplicity != "1" ||
+		n.Configures(MultiplicitySetting) ||
 		multiplicityUsageRE.MatchString(n.Impl.Head) ||
 		multiplicityUsageRE.MatchString(n.Impl.Body) ||
 		multiplicityUsageRE.MatchString(n.Impl.Tail)
@@ -84,6 +113,19 @@ func (n *Node) UsesInstanceNum() bool {
 	return instanceNumUsageRE.MatchString(n.Impl.Body)
 }
 
+// Recovers reports whether panics in the body are recovered from.
+func (n *Node) Recovers() bool {
+	return n.PanicPolicy == PanicStop || n.PanicPolicy == PanicRestart
+}
+
+// Restarts returns how many times the body is restarted after panicking.
+func (n *Node) Restarts() int {
+	if n.PanicPolicy != PanicRestart || n.MaxRestarts < 0 {
+		return 0
+	}
+	return n.MaxRestarts
+}
+
 // PinFullTypes is a map from pin names to full resolved types:
 // pinName <-chan someType or pinName chan<- someType.
 // Requires InferTypes to have been called.
@@ -129,6 +171,9 @@ type jsonNode struct {
 	Enabled      bool              `json:"enabled"`
 	Wait         bool              `json:"wait"`
 	Multiplicity string            `json:"multiplicity,omitempty"`
+	PanicPolicy  string            `json:"panic_policy,omitempty"`
+	MaxRestarts  int               `json:"max_restarts,omitempty"`
+	Configurable []string          `json:"configurable,omitempty"`
 	X            float64           `json:"x"`
 	Y            float64           `json:"y"`
 	Connections  map[string]string `json:"connections"`
@@ -146,6 +191,9 @@ func (n *Node) MarshalJSON() ([]byte, error) {
 		Enabled:      n.Enabled,
 		Wait:         n.Wait,
 		Multiplicity: n.Multiplicity,
+		PanicPolicy:  n.PanicPolicy,
+		MaxRestarts:  n.MaxRestarts,
+		Configurable: n.Configurable,
 		X:            n.X,
 		Y:            n.Y,
 		Connections:  n.Connections,
@@ -169,6 +217,9 @@ func (n *Node) UnmarshalJSON(j []byte) error {
 	n.Enabled = mp.Enabled
 	n.Wait = mp.Wait
 	n.Multiplicity = mp.Multiplicity
+	n.PanicPolicy = mp.PanicPolicy
+	n.MaxRestarts = mp.MaxRestarts
+	n.Configurable = mp.Configurable
 	n.Part = p
 	n.X, n.Y = mp.X, mp.Y
 	n.Connections = mp.Connections
@@ -0,0 +1,113 @@
+// Copyright 2018 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package model
+
+import "github.com/google/shenzhen-go/model/pin"
+
+// ReplaySkips returns the names of the nodes that are replaced when values
+// are replayed onto the given channels. These are the nodes that send on the
+// replayed channels, and then any other nodes whose outputs all lead only
+// into replaced nodes. It also returns the orphans: the other channels that
+// only replaced nodes send on (or use at all), which should be closed so
+// their readers don't wait forever.
+func (g *Graph) ReplaySkips(replayed []string) (skip, orphans map[string]bool) {
+	skip = make(map[string]bool)
+	orphans = make(map[string]bool)
+	if len(replayed) == 0 {
+		return skip, orphans
+	}
+	isReplayed := make(map[string]bool, len(replayed))
+	for _, c := range replayed {
+		isReplayed[c] = true
+	}
+
+	outputs := make(map[string][]string) // node -> connected output channels
+	readers := make(map[string][]string) // channel -> nodes reading it
+	writers := make(map[string][]string) // channel -> nodes sending on it
+	for _, n := range g.Nodes {
+		for pn, p := range n.Part.Pins() {
+			c := n.Connections[pn]
+			if c == "" || c == "nil" {
+				continue
+			}
+			switch p.Direction {
+			case pin.Output:
+				outputs[n.Name] = append(outputs[n.Name], c)
+				writers[c] = append(writers[c], n.Name)
+				if isReplayed[c] {
+					skip[n.Name] = true
+				}
+			case pin.Input:
+				readers[c] = append(readers[c], n.Name)
+			}
+		}
+	}
+
+	// Keep skipping nodes that only feed skipped nodes, until there are no
+	// more.
+	for changed := true; changed; {
+		changed = false
+		for n, outs := range outputs {
+			if skip[n] || !allReadersSkipped(outs, readers, skip) {
+				continue
+			}
+			skip[n] = true
+			changed = true
+		}
+	}
+
+	chans := make(map[string]bool, len(g.Channels))
+	for c := range g.Channels {
+		chans[c] = true
+	}
+	for c := range readers {
+		chans[c] = true
+	}
+	for c := range writers {
+		chans[c] = true
+	}
+	for c := range chans {
+		if isReplayed[c] || !allSkipped(writers[c], skip) {
+			continue
+		}
+		// With no senders at all, leave it alone unless nothing that reads
+		// it is started either.
+		if len(writers[c]) > 0 || allSkipped(readers[c], skip) {
+			orphans[c] = true
+		}
+	}
+	return skip, orphans
+}
+
+// allReadersSkipped reports whether every channel has readers, all of which
+// are skipped.
+func allReadersSkipped(chans []string, readers map[string][]string, skip map[string]bool) bool {
+	for _, c := range chans {
+		if rs := readers[c]; len(rs) == 0 || !allSkipped(rs, skip) {
+			return false
+		}
+	}
+	return true
+}
+
+// allSkipped reports whether all the nodes are skipped.
+func allSkipped(nodes []string, skip map[string]bool) bool {
+	for _, n := range nodes {
+		if !skip[n] {
+			return false
+		}
+	}
+	return true
+}
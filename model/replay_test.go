@@ -0,0 +1,68 @@
+// Copyright 2018 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package model
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/google/shenzhen-go/model/pin"
+)
+
+func TestReplaySkips(t *testing.T) {
+	// a -> ab -> b -> bc -> c -> cd -> d
+	//             b -> be -> e
+	// f -> fc -> c
+	// g -> gx (nobody reads gx)
+	//      g -> gb -> b
+	node := func(name string, conns map[string]string) *Node {
+		pins := make(pin.Map)
+		for p := range conns {
+			dir := pin.Output
+			if p[0] == 'i' {
+				dir = pin.Input
+			}
+			pins[p] = &pin.Definition{Name: p, Type: "int", Direction: dir}
+		}
+		return &Node{Name: name, Part: &FakePart{Pns: pins}, Connections: conns}
+	}
+	g := &Graph{
+		Nodes: map[string]*Node{
+			"a": node("a", map[string]string{"out": "ab"}),
+			"b": node("b", map[string]string{"in": "ab", "in2": "gb", "out": "bc", "out2": "be"}),
+			"c": node("c", map[string]string{"in": "bc", "in2": "fc", "out": "cd"}),
+			"d": node("d", map[string]string{"in": "cd"}),
+			"e": node("e", map[string]string{"in": "be", "out": "nil"}),
+			"f": node("f", map[string]string{"out": "fc"}),
+			"g": node("g", map[string]string{"out": "gb", "out2": "gx"}),
+		},
+	}
+	tests := []struct {
+		replay      []string
+		wantSkip    map[string]bool
+		wantOrphans map[string]bool
+	}{
+		{nil, map[string]bool{}, map[string]bool{}},
+		{[]string{"cd"}, map[string]bool{"c": true, "f": true}, map[string]bool{"fc": true}},
+		{[]string{"bc"}, map[string]bool{"a": true, "b": true}, map[string]bool{"ab": true, "be": true}},
+		{[]string{"bc", "be"}, map[string]bool{"a": true, "b": true}, map[string]bool{"ab": true}},
+	}
+	for _, test := range tests {
+		skip, orphans := g.ReplaySkips(test.replay)
+		if !reflect.DeepEqual(skip, test.wantSkip) || !reflect.DeepEqual(orphans, test.wantOrphans) {
+			t.Errorf("g.ReplaySkips(%v) = (%v, %v), want (%v, %v)", test.replay, skip, orphans, test.wantSkip, test.wantOrphans)
+		}
+	}
+}
@@ -0,0 +1,30 @@
+// Copyright 2018 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package model
+
+// RunConfig describes how to run the program generated from a graph.
+type RunConfig struct {
+	Args       []string `json:"args,omitempty"`        // not including the program name
+	Env        []string `json:"env,omitempty"`         // added to the server's environment, each "KEY=value"
+	Dir        string   `json:"dir,omitempty"`         // relative to the graph file's directory, if not absolute
+	Race       bool     `json:"race,omitempty"`        // enables the race detector
+	Profile    bool     `json:"profile,omitempty"`     // collects CPU and heap profiles
+	Instrument bool     `json:"instrument,omitempty"`  // reports channel activity while running
+	Debug      bool     `json:"debug,omitempty"`       // holds values sent on channels with breakpoints
+	Record     []string `json:"record,omitempty"`      // channels whose values are recorded
+	RecordFile string   `json:"record_file,omitempty"` // relative to the graph file's directory, if not absolute
+	Replay     []string `json:"replay,omitempty"`      // channels whose values are replayed
+	ReplayFile string   `json:"replay_file,omitempty"` // relative to the graph file's directory, if not absolute
+}
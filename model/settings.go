@@ -0,0 +1,171 @@
+// Copyright 2018 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package model
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"unicode"
+)
+
+// MultiplicitySetting is the name of the setting every node has for its
+// multiplicity.
+const MultiplicitySetting = "multiplicity"
+
+// Setting is a value used by the implementation of a node that can be made
+// configurable (see Node.Configurable). Instead of being a constant in the
+// generated code, a configurable setting is held in a package variable, and
+// generated commands have a flag and an environment variable to override it.
+type Setting struct {
+	Name  string // e.g. "max_items"
+	Type  string // bool, int, int64, uint, uint64, float64, string, or time.Duration
+	Value string // Go expression for the default
+	Usage string // e.g. "Maximum number of items"
+}
+
+// SettingsPart is implemented by parts with settings. Parts should use
+// Node.SettingDecl or Node.SettingExpr in their implementation for each
+// setting.
+type SettingsPart interface {
+	Settings() []Setting
+}
+
+// flagFuncs maps the supported setting types to functions in package flag.
+var flagFuncs = map[string]string{
+	"bool":          "BoolVar",
+	"int":           "IntVar",
+	"int64":         "Int64Var",
+	"uint":          "UintVar",
+	"uint64":        "Uint64Var",
+	"float64":       "Float64Var",
+	"string":        "StringVar",
+	"time.Duration": "DurationVar",
+}
+
+// Settings returns the settings of the node: its multiplicity, followed by
+// those of the part.
+func (n *Node) Settings() []Setting {
+	ss := []Setting{{
+		Name:  MultiplicitySetting,
+		Type:  "int",
+		Value: n.ExpandedMult(),
+		Usage: "Number of instances of the body",
+	}}
+	if sp, ok := n.Part.(SettingsPart); ok {
+		ss = append(ss, sp.Settings()...)
+	}
+	return ss
+}
+
+// Configures reports whether the node's setting is configurable.
+func (n *Node) Configures(name string) bool {
+	return contains(n.Configurable, name)
+}
+
+// CheckConfigurable checks that the configurable settings exist.
+func (n *Node) CheckConfigurable() error {
+	ss := n.Settings()
+	for _, c := range n.Configurable {
+		found := false
+		for _, s := range ss {
+			if s.Name == c {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return fmt.Errorf("node %q has no setting %q", n.Name, c)
+		}
+	}
+	return nil
+}
+
+// SettingVar returns the name of the package variable holding the setting.
+func (n *Node) SettingVar(name string) string {
+	return "config_" + n.Identifier() + "_" + name
+}
+
+// SettingExpr returns an expression for the setting: the package variable if
+// the setting is configurable, or else the default.
+func (n *Node) SettingExpr(name string) string {
+	if n.Configures(name) {
+		return n.SettingVar(name)
+	}
+	for _, s := range n.Settings() {
+		if s.Name == name {
+			return s.Value
+		}
+	}
+	return ""
+}
+
+// SettingDecl returns a declaration of local, holding the setting: a
+// constant, or a variable if the setting is configurable. Untyped constants
+// are more forgiving than variables, so code using local should work with
+// the type of the setting either way.
+func (n *Node) SettingDecl(local, name string) string {
+	if n.Configures(name) {
+		return local + " := " + n.SettingVar(name)
+	}
+	return "const " + local + " = " + n.SettingExpr(name)
+}
+
+// SingleInstance reports whether the body is run once, without goroutines.
+func (n *Node) SingleInstance() bool {
+	return n.Multiplicity == "1" && !n.Configures(MultiplicitySetting)
+}
+
+// MultiplicityExpr returns an expression for the multiplicity.
+func (n *Node) MultiplicityExpr() string {
+	return n.SettingExpr(MultiplicitySetting)
+}
+
+// nodeConfig is a configurable setting of a node, with the information
+// needed to generate the variable and flag.
+type nodeConfig struct {
+	Setting
+	Var, Flag, Env, FlagFunc string
+}
+
+// configs returns the configurable settings of all the nodes, ordered by
+// flag name.
+func (g *Graph) configs() []*nodeConfig {
+	var cs []*nodeConfig
+	for _, n := range g.Nodes {
+		for _, s := range n.Settings() {
+			if !n.Configures(s.Name) {
+				continue
+			}
+			f := strings.ToLower(n.Identifier()) + "." + s.Name
+			env := strings.Map(func(r rune) rune {
+				if !unicode.IsLetter(r) && !unicode.IsDigit(r) {
+					return '_'
+				}
+				return unicode.ToUpper(r)
+			}, f)
+			s.Usage = fmt.Sprintf("%s for the node %q (or $%s)", s.Usage, n.Name, env)
+			cs = append(cs, &nodeConfig{
+				Setting:  s,
+				Var:      n.SettingVar(s.Name),
+				Flag:     f,
+				Env:      env,
+				FlagFunc: flagFuncs[s.Type],
+			})
+		}
+	}
+	sort.Slice(cs, func(i, j int) bool { return cs[i].Flag < cs[j].Flag })
+	return cs
+}
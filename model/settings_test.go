@@ -0,0 +1,77 @@
+// Copyright 2018 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package model
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestSettings(t *testing.T) {
+	g := fixtureTestGraph("")
+	g.RefreshChannelsPins()
+	g.IsCommand = true
+	dbl := g.Nodes["dbl"]
+	dbl.Multiplicity = "2*n"
+	dbl.Configurable = []string{MultiplicitySetting}
+	if err := dbl.CheckConfigurable(); err != nil {
+		t.Errorf("dbl.CheckConfigurable() = error %v", err)
+	}
+	if dbl.SingleInstance() {
+		t.Error("dbl.SingleInstance() = true, want false")
+	}
+	if got, want := dbl.SettingDecl("m", MultiplicitySetting), "m := config_dbl_multiplicity"; got != want {
+		t.Errorf("dbl.SettingDecl(m, multiplicity) = %q, want %q", got, want)
+	}
+	src := g.Nodes["src"]
+	src.Multiplicity = "1"
+	if got, want := src.SettingDecl("m", MultiplicitySetting), "const m = 1"; got != want {
+		t.Errorf("src.SettingDecl(m, multiplicity) = %q, want %q", got, want)
+	}
+	src.Configurable = []string{"nope"}
+	if err := src.CheckConfigurable(); err == nil {
+		t.Error("src.CheckConfigurable() with an unknown setting = nil error, want error")
+	}
+	src.Configurable = nil
+
+	var buf bytes.Buffer
+	if err := g.WriteRawGoTo(&buf); err != nil {
+		t.Fatalf("WriteRawGoTo() = error %v", err)
+	}
+	s := buf.String()
+	for _, want := range []string{
+		`"flag"`,
+		"config_dbl_multiplicity int = 2*runtime.NumCPU()",
+		`flag.IntVar(&config_dbl_multiplicity, "dbl.multiplicity", config_dbl_multiplicity, `,
+		`"dbl.multiplicity": "DBL_MULTIPLICITY",`,
+		"flag.Parse()",
+		"multiplicity := config_dbl_multiplicity",
+		"if multiplicity < 1 {",
+	} {
+		if !strings.Contains(s, want) {
+			t.Errorf("generated source doesn't contain %q:\n%s", want, s)
+		}
+	}
+
+	g.IsCommand = false
+	buf.Reset()
+	if err := g.WriteRawGoTo(&buf); err != nil {
+		t.Fatalf("WriteRawGoTo() = error %v", err)
+	}
+	if s := buf.String(); strings.Contains(s, "flag.") {
+		t.Errorf("generated package has flags:\n%s", s)
+	}
+}
@@ -0,0 +1,143 @@
+// Copyright 2018 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package model
+
+import (
+	"bytes"
+	"io"
+	"sort"
+	"text/template"
+
+	"github.com/google/shenzhen-go/model/pin"
+	"github.com/google/shenzhen-go/source"
+)
+
+const nodeTestTemplateSrc = `// Tests for the node {{printf "%q" .Node.Name}}. This file was generated by
+// Shenzhen Go as a starting point, and won't be overwritten.
+
+{{if .IsCommand -}}
+package main
+{{else -}}
+package {{.PackageName}}
+{{end}}
+
+import (
+	{{range .Imports -}}
+	{{.}}
+	{{end -}}
+)
+
+func Test_{{.Node.Identifier}}(szT *testing.T) {
+	// The locals are prefixed so they don't collide with pin names.
+	szTests := []struct {
+		szName string
+		{{range .Inputs -}}
+		{{.Name}} []{{.Type}} // sent on {{.Name}}, which is then closed
+		{{end -}}
+		{{range .Outputs -}}
+		{{.Name}} []{{.Type}} // expected on {{.Name}}
+		{{end -}}
+	}{
+		// TODO: Add test cases.
+	}
+	for _, szTest := range szTests {
+		szT.Run(szTest.szName, func(szT *testing.T) {
+			{{- range .Inputs}}
+			{{.Name}} := make(chan {{.Type}}, len(szTest.{{.Name}}))
+			for _, szX := range szTest.{{.Name}} {
+				{{.Name}} <- szX
+			}
+			close({{.Name}})
+			{{- end}}
+			{{- range .Outputs}}
+			{{.Name}} := make(chan {{.Type}}, len(szTest.{{.Name}})+1)
+			{{- end}}
+
+			szDone := make(chan struct{})
+			go func() {
+				{{.Node.Identifier}}({{range $name, $type := .Node.PinFullTypes}}{{$name}},{{end}})
+				close(szDone)
+			}()
+			select {
+			case <-szDone:
+			case <-time.After(5 * time.Second):
+				szT.Fatal("{{.Node.Identifier}} didn't return; it might be waiting to send more values than expected")
+			}
+			{{- range .Outputs}}
+
+			var {{.Name}}Got []{{.Type}}
+			for len({{.Name}}) > 0 {
+				{{.Name}}Got = append({{.Name}}Got, <-{{.Name}})
+			}
+			if len({{.Name}}Got) != len(szTest.{{.Name}}) || (len({{.Name}}Got) > 0 && !reflect.DeepEqual({{.Name}}Got, szTest.{{.Name}})) {
+				szT.Errorf("{{.Name}}: got %#v, want %#v", {{.Name}}Got, szTest.{{.Name}})
+			}
+			{{- end}}
+		})
+	}
+}
+`
+
+var nodeTestTemplate = template.Must(template.New("golang-node-test").Parse(nodeTestTemplateSrc))
+
+// nodeTestInput is the input to nodeTestTemplate.
+type nodeTestInput struct {
+	*Graph
+	Node            *Node
+	Inputs, Outputs []nodeTestPin
+	Imports         []string
+}
+
+// nodeTestPin is a pin, with the type of the values it carries.
+type nodeTestPin struct{ Name, Type string }
+
+// TestStubFilename returns the name of the file WriteNodeTestGoTo output
+// should be written to, in the directory of the generated package.
+func (n *Node) TestStubFilename() string {
+	return "node_" + n.Identifier() + "_test.go"
+}
+
+// WriteNodeTestGoTo writes a Go test file for one node of the graph. The
+// test is a table-driven stub that calls the node's function directly with
+// buffered channels; the table starts empty.
+func (g *Graph) WriteNodeTestGoTo(w io.Writer, n *Node) error {
+	if err := g.InferTypes(); err != nil {
+		return err
+	}
+	in := nodeTestInput{Graph: g, Node: n}
+	var types []string
+	for pn, p := range n.Part.Pins() {
+		tp := nodeTestPin{Name: pn, Type: n.PinTypes[pn].String()}
+		types = append(types, tp.Type)
+		if p.Direction == pin.Input {
+			in.Inputs = append(in.Inputs, tp)
+		} else {
+			in.Outputs = append(in.Outputs, tp)
+		}
+	}
+	sort.Slice(in.Inputs, func(i, j int) bool { return in.Inputs[i].Name < in.Inputs[j].Name })
+	sort.Slice(in.Outputs, func(i, j int) bool { return in.Outputs[i].Name < in.Outputs[j].Name })
+	extra := []string{`"testing"`, `"time"`}
+	if len(in.Outputs) > 0 {
+		extra = append(extra, `"reflect"`)
+	}
+	in.Imports = g.importsFor(types, extra...)
+
+	buf := new(bytes.Buffer)
+	if err := nodeTestTemplate.Execute(buf, in); err != nil {
+		return err
+	}
+	return source.GoFmt(w, buf)
+}
@@ -0,0 +1,101 @@
+// Copyright 2018 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package model
+
+import (
+	"bytes"
+	"go/ast"
+	"go/importer"
+	"go/parser"
+	"go/token"
+	"go/types"
+	"strings"
+	"testing"
+
+	"github.com/google/shenzhen-go/model/pin"
+)
+
+func TestWriteNodeTestGoTo(t *testing.T) {
+	g := fixtureTestGraph("")
+	g.RefreshChannelsPins()
+	n := g.Nodes["dbl"]
+	if got, want := n.TestStubFilename(), "node_dbl_test.go"; got != want {
+		t.Errorf("n.TestStubFilename() = %q, want %q", got, want)
+	}
+	var buf bytes.Buffer
+	if err := g.WriteNodeTestGoTo(&buf, n); err != nil {
+		t.Fatalf("g.WriteNodeTestGoTo() = error %v", err)
+	}
+	src := buf.String()
+	for _, want := range []string{
+		`"reflect"`,
+		"func Test_dbl(szT *testing.T) {",
+		"[]int // sent on in, which is then closed",
+		"[]int // expected on out",
+		"in := make(chan int, len(szTest.in))",
+		"out := make(chan int, len(szTest.out)+1)",
+		"dbl(in, out)",
+	} {
+		if !strings.Contains(src, want) {
+			t.Errorf("generated test doesn't contain %q:\n%s", want, src)
+		}
+	}
+
+	buf.Reset()
+	if err := g.WriteNodeTestGoTo(&buf, g.Nodes["sink"]); err != nil {
+		t.Fatalf("g.WriteNodeTestGoTo() = error %v", err)
+	}
+	if src := buf.String(); strings.Contains(src, `"reflect"`) {
+		t.Errorf("generated test for a node without outputs imports reflect:\n%s", src)
+	}
+}
+
+func TestWriteNodeTestGoToPinNames(t *testing.T) {
+	// Pins named like the locals in the stub.
+	n := &Node{
+		Name:    "clash",
+		Enabled: true,
+		Part: &FakePart{Pns: pin.NewMap(
+			&pin.Definition{Name: "name", Type: "int", Direction: pin.Input},
+			&pin.Definition{Name: "done", Type: "int", Direction: pin.Input},
+			&pin.Definition{Name: "t", Type: "int", Direction: pin.Output},
+			&pin.Definition{Name: "test", Type: "string", Direction: pin.Output},
+		)},
+		Connections: map[string]string{"name": "nil", "done": "nil", "t": "nil", "test": "nil"},
+	}
+	g := &Graph{
+		Name:        "clash",
+		PackagePath: "example.com/clash",
+		IsCommand:   true,
+		Nodes:       map[string]*Node{"clash": n},
+		Channels:    map[string]*Channel{},
+	}
+	var buf bytes.Buffer
+	if err := g.WriteNodeTestGoTo(&buf, n); err != nil {
+		t.Fatalf("g.WriteNodeTestGoTo() = error %v", err)
+	}
+	// Stand in for the generated node function.
+	buf.WriteString("\nfunc clash(done <-chan int, name <-chan int, t chan<- int, test chan<- string) {}\n")
+
+	fset := token.NewFileSet()
+	f, err := parser.ParseFile(fset, "node_clash_test.go", buf.Bytes(), 0)
+	if err != nil {
+		t.Fatalf("Couldn't parse generated test: %v\n%s", err, buf.Bytes())
+	}
+	conf := types.Config{Importer: importer.ForCompiler(fset, "source", nil)}
+	if _, err := conf.Check("main", fset, []*ast.File{f}, nil); err != nil {
+		t.Errorf("Generated test doesn't type-check: %v\n%s", err, buf.Bytes())
+	}
+}
@@ -19,8 +19,11 @@ import (
 	"encoding/json"
 	"go/format"
 	"io"
+	"strings"
 	"text/template"
+	"time"
 
+	"github.com/google/shenzhen-go/model/pin"
 	"github.com/google/shenzhen-go/source"
 )
 
@@ -46,6 +49,77 @@ var _ = runtime.Compiler
 {{.}}
 {{end -}}
 
+{{if .Graceful -}}
+var (
+	// rootCtx is cancelled when the command receives SIGINT or SIGTERM.
+	rootCtx, rootCancel = context.WithCancel(context.Background())
+
+	// shutdownTimeout is how long to wait for the nodes to finish after that.
+	shutdownTimeout = time.Duration({{.ShutdownNanos}})
+)
+{{end}}
+
+{{with .Configs -}}
+// Configurable settings of nodes.
+var (
+	{{range . -}}
+	{{.Var}} {{.Type}} = {{.Value}} // {{.Usage}}
+	{{end -}}
+)
+{{end}}
+
+{{if .Recovers -}}
+{{if .Prometheus -}}
+var nodePanics = prometheus.NewCounterVec(
+	prometheus.CounterOpts{
+		Namespace: "shenzhen_go",
+		Subsystem: "node",
+		Name:      "panics",
+		Help:      "Panics recovered from in nodes",
+	},
+	[]string{"node_name"},
+)
+
+func init() {
+	prometheus.MustRegister(nodePanics)
+}
+{{end}}
+// recoverNode runs the body of a node, recovering from panics. After a
+// panic, the body is run again after a backoff, up to restarts times.
+func recoverNode(name string, restarts int, body func()) {
+	backoff := 100 * time.Millisecond
+	for i := 0; ; i++ {
+		r, panicked := runRecovered(body)
+		if !panicked {
+			return
+		}
+		{{if .Prometheus -}}
+		nodePanics.With(prometheus.Labels{"node_name": name}).Inc()
+		{{end -}}
+		if i >= restarts {
+			log.Printf("Node %q panicked, stopping it: %v", name, r)
+			return
+		}
+		log.Printf("Node %q panicked, restarting it in %v: %v", name, backoff, r)
+		time.Sleep(backoff)
+		if backoff *= 2; backoff > 10*time.Second {
+			backoff = 10 * time.Second
+		}
+	}
+}
+
+func runRecovered(body func()) (r interface{}, panicked bool) {
+	panicked = true
+	defer func() {
+		if panicked {
+			r = recover()
+		}
+	}()
+	body()
+	return nil, false
+}
+{{end}}
+
 {{range .Nodes}}
 {{if .Comment -}}
 /* {{.Comment}} */
@@ -53,7 +127,12 @@ var _ = runtime.Compiler
 func {{.Identifier}}({{range $name, $type := .PinFullTypes}}{{$name}} {{$type}},{{end}}) {
 	// {{ .Name }}
 	{{if .UsesMultiplicity -}}
-	multiplicity := {{.ExpandedMult}}
+	multiplicity := {{.MultiplicityExpr}}
+	{{if .Configures "multiplicity" -}}
+	if multiplicity < 1 {
+		log.Fatalf("Node %q: multiplicity %d must be at least 1", {{printf "%q" .Name}}, multiplicity)
+	}
+	{{end -}}
 	{{end -}}
 	{{.Impl.Head}}
 	{{if .Impl.Tail -}}
@@ -61,11 +140,11 @@ func {{.Identifier}}({{range $name, $type := .PinFullTypes}}{{$name}} {{$type}},
 		{{.Impl.Tail}}
 	}()
 	{{end -}}
-	{{if eq .Multiplicity "1" -}}
+	{{if .SingleInstance -}}
 	{{if .UsesInstanceNum -}}
 	const instanceNumber = 0
 	{{end -}}
-	{{.Impl.Body}}
+	{{template "body" .}}
 	{{else -}}
 	var multWG sync.WaitGroup
 	multWG.Add(multiplicity)
@@ -76,7 +155,7 @@ func {{.Identifier}}({{range $name, $type := .PinFullTypes}}{{$name}} {{$type}},
 		{{end -}}
 		go func() {
 			defer multWG.Done()
-			{{.Impl.Body}}
+			{{template "body" .}}
 		}()
 	}
 	{{end -}}
@@ -91,28 +170,187 @@ func main() {
 // finish" to finish before returning.
 func Run() {
 {{end}}
+	{{- if and .IsCommand .Configs}}
+	{{range .Configs -}}
+	flag.{{.FlagFunc}}(&{{.Var}}, {{printf "%q" .Flag}}, {{.Var}}, {{printf "%q" .Usage}})
+	{{end -}}
+	for name, env := range map[string]string{
+		{{range .Configs -}}
+		{{printf "%q" .Flag}}: {{printf "%q" .Env}},
+		{{end -}}
+	} {
+		if v, ok := os.LookupEnv(env); ok {
+			if err := flag.Set(name, v); err != nil {
+				log.Fatalf("Invalid value %q in $%s: %v", v, env, err)
+			}
+		}
+	}
+	flag.Parse()
+	{{end}}
+	{{- if .Opts.ProfileDir}}
+	stopProfiling := probe.StartProfiling({{printf "%q" .Opts.ProfileDir}})
+	defer stopProfiling()
+	{{- if not .Graceful}}
+	profileSignals := make(chan os.Signal, 1)
+	signal.Notify(profileSignals, os.Interrupt)
+	go func() {
+		<-profileSignals
+		stopProfiling()
+		os.Exit(1)
+	}()
+	{{- end}}
+	{{end}}
+	{{- if .Opts.ProbeAddr}}
+	probeConn := probe.Dial({{printf "%q" .Opts.ProbeAddr}}{{range .Opts.Breakpoints}}, {{printf "%q" .}}{{end}})
+	defer probeConn.Close()
+	{{end}}
+	{{- if .Opts.Record}}
+	probeRecorder := probe.StartRecording({{printf "%q" .Opts.RecordFile}})
+	defer probeRecorder.Close()
+	{{end}}
 	{{- range $n, $c := .Channels}}
 	{{$n}} := make(chan {{$c.Type}}, {{$c.Capacity}})
+	{{- if $.Relayed $n}}
+	{{$n}}Sends := make(chan {{$c.Type}})
+	go func(in <-chan {{$c.Type}}, out chan<- {{$c.Type}}, pc *probe.Channel) {
+		for x := range in {
+			pc.Sent()
+			if pc.Tapping() {
+				pc.Tap(x)
+			}
+			{{- if $.Recorded $n}}
+			probeRecorder.Record({{printf "%q" $n}}, x)
+			{{- end}}
+			{{- if $.Opts.Debug}}
+			if pc.Breaking() && !pc.Hold(x) {
+				continue
+			}
+			{{- end}}
+			out <- x
+		}
+		close(out)
+	}({{$n}}Sends, {{$n}}, {{if $.Opts.ProbeAddr}}probeConn.Channel({{printf "%q" $n}}, cap({{$n}}), func() int { return len({{$n}}) }){{else}}nil{{end}})
+	{{- end}}
+	{{- if $.Replayed $n}}
+	go func(out chan<- {{$c.Type}}) {
+		defer close(out)
+		probe.Replay({{printf "%q" $.Opts.ReplayFile}}, {{printf "%q" $n}}, func(decode func(interface{}) error) error {
+			var x {{$c.Type}}
+			if err := decode(&x); err != nil {
+				return err
+			}
+			out <- x
+			return nil
+		})
+	}({{$.SendTo $n}})
+	{{- else if $.Orphaned $n}}
+	close({{$.SendTo $n}}) // only sent on by nodes replaced by replays
+	{{- end}}
+	{{- if $.Cutoff $n}}
+	{{$n}}Writes := make(chan {{$c.Type}})
+	go func(in <-chan {{$c.Type}}, out chan<- {{$c.Type}}) {
+		defer close(out)
+		{{- if $.Manages $n}}
+		var managers []{{$c.Type}}
+		{{- end}}
+		for {
+			select {
+			case x, open := <-in:
+				if !open {
+					return
+				}
+				select {
+				case out <- x:
+					{{- if $.Manages $n}}
+					managers = append(managers, x)
+					{{- end}}
+					continue
+				case <-rootCtx.Done():
+				}
+			case <-rootCtx.Done():
+			}
+			// Shutting down: stop passing values on, without blocking the senders.
+			go func() {
+				for range in {
+				}
+			}()
+			{{- if $.Manages $n}}
+			go func() {
+				ctx, cancel := context.WithTimeout(context.Background(), shutdownTimeout)
+				defer cancel()
+				var shutdowns sync.WaitGroup
+				for _, m := range managers {
+					shutdowns.Add(1)
+					go func(m {{$c.Type}}) {
+						defer shutdowns.Done()
+						m.Shutdown(ctx)
+					}(m)
+				}
+				done := make(chan struct{})
+				go func() {
+					shutdowns.Wait()
+					close(done)
+				}()
+				select {
+				case <-done:
+				case <-ctx.Done():
+				}
+			}()
+			{{- end}}
+			return
+		}
+	}({{$n}}Writes, {{$.SendTo $n}})
+	{{- end}}
+	{{- end}}
+	{{- if .Graceful}}
+
+	shutdownSignals := make(chan os.Signal, 1)
+	signal.Notify(shutdownSignals, os.Interrupt, syscall.SIGTERM)
+	go func() {
+		s := <-shutdownSignals
+		log.Printf("Received %v, shutting down", s)
+		rootCancel()
+		select {
+		case s := <-shutdownSignals:
+			log.Printf("Received %v again, exiting", s)
+		case <-time.After(shutdownTimeout):
+			log.Printf("Timed out after %v waiting for nodes to finish, exiting", shutdownTimeout)
+		}
+		{{- if .Opts.ProfileDir}}
+		stopProfiling()
+		{{- end}}
+		os.Exit(1)
+	}()
 	{{- end}}
 
 	var wg sync.WaitGroup
 	{{range $node := .Nodes}}
-		{{if $node.Enabled -}}
+		{{if and $node.Enabled (not ($.Skipped $node)) -}}
 			{{if $node.Wait -}}
 	wg.Add(1)
 	go func() {
-			{{$node.Identifier}}({{range $pin := $node.Part.Pins}}{{index $node.Connections $pin.Name}},{{end}})
+			{{$node.Identifier}}({{range $pin := $node.Part.Pins}}{{$.Arg $node $pin}},{{end}})
 		wg.Done()
 	}()
 			{{else}}
-	go {{$node.Identifier}}({{range $pin := $node.Part.Pins}}{{index $node.Connections $pin.Name}},{{end}})
+	go {{$node.Identifier}}({{range $pin := $node.Part.Pins}}{{$.Arg $node $pin}},{{end}})
 			{{- end}}
 		{{- end}}
 	{{- end}}
 
 	// Wait for the various goroutines to finish.
 	wg.Wait()
-}`
+}
+
+{{define "body" -}}
+{{if .Recovers -}}
+recoverNode({{printf "%q" .Name}}, {{.Restarts}}, func() {
+	{{.Impl.Body}}
+})
+{{- else -}}
+{{.Impl.Body}}
+{{- end}}
+{{- end}}`
 
 	nodeTemplateSrc = `{{if .Graph.IsCommand -}}
 	package main
@@ -137,7 +375,7 @@ func Run() {
 	func {{.Identifier}}({{range $name, $type := .PinFullTypes}}{{$name}} {{$type}},{{end}}) {
 		// {{ .Name }}
 		{{if .UsesMultiplicity -}}
-		multiplicity := {{.ExpandedMult}}
+		multiplicity := {{.MultiplicityExpr}}
 		{{end -}}
 		{{.Impl.Head}}
 		{{if .Impl.Tail -}}
@@ -145,7 +383,7 @@ func Run() {
 			{{.Impl.Tail}}
 		}()
 		{{end -}}
-		{{if eq .Multiplicity "1" -}}
+		{{if .SingleInstance -}}
 		{{if .UsesInstanceNum -}}
 		const instanceNumber = 0
 		{{end -}}
@@ -222,15 +460,251 @@ var (
 	mainTemplate = template.Must(template.New("golang-main").Parse(mainTemplateSrc))
 )
 
+// GenOptions enables optional extras in the generated Go source.
+// The zero value generates the plain program.
+type GenOptions struct {
+	// ProfileDir, if not empty, makes the program write CPU and heap
+	// profiles into that directory when it finishes.
+	ProfileDir string
+
+	// ProbeAddr, if not empty, makes the program report channel activity
+	// to the server listening at that address. Values sent on each channel
+	// go via a goroutine that counts them, which adds one value of
+	// buffering to every channel.
+	ProbeAddr string
+
+	// Debug, if set along with ProbeAddr, lets the server hold values sent
+	// on channels with breakpoints.
+	Debug bool
+
+	// Breakpoints are the channels to break on from the start, if Debug is
+	// set.
+	Breakpoints []string
+
+	// Record are channels whose values are written to RecordFile, which is
+	// created when the program starts.
+	Record     []string
+	RecordFile string
+
+	// Replay are channels whose values are read from ReplayFile, a
+	// recording made using Record. The nodes upstream of these channels
+	// (see ReplaySkips) are not started.
+	Replay     []string
+	ReplayFile string
+}
+
+// genInput is the input to goTemplate.
+type genInput struct {
+	*Graph
+	Opts     *GenOptions
+	skip     map[string]bool // nodes not started
+	orphans  map[string]bool // channels to close at the start
+	cutoff   map[string]bool // channels cut off when shutting down
+	shutdown time.Duration   // shutdown timeout
+	configs  []*nodeConfig   // configurable settings
+}
+
+// AllImports adds the imports needed for the options to the graph's imports.
+func (i genInput) AllImports() []string {
+	m := source.NewStringSet(i.Graph.AllImports()...)
+	o := i.Opts
+	if o.ProfileDir != "" || o.ProbeAddr != "" || len(o.Record) > 0 || len(o.Replay) > 0 {
+		m.Add(`"github.com/google/shenzhen-go/probe"`)
+	}
+	if o.ProfileDir != "" && !i.Graceful() {
+		m.Add(`"os"`)
+		m.Add(`"os/signal"`)
+	}
+	if i.Recovers() {
+		m.Add(`"log"`)
+		m.Add(`"time"`)
+		if i.Prometheus() {
+			m.Add(prometheusImport)
+		}
+	}
+	for _, c := range i.configs {
+		if c.Type == "time.Duration" {
+			m.Add(`"time"`)
+		}
+		if c.Name == MultiplicitySetting {
+			m.Add(`"log"`)
+		}
+		if i.IsCommand {
+			m.Add(`"flag"`)
+			m.Add(`"log"`)
+			m.Add(`"os"`)
+		}
+	}
+	if i.Graceful() {
+		for _, imp := range []string{`"context"`, `"log"`, `"os"`, `"os/signal"`, `"syscall"`, `"time"`} {
+			m.Add(imp)
+		}
+	}
+	return m.Slice()
+}
+
+// Configs returns the configurable settings of the nodes.
+func (i genInput) Configs() []*nodeConfig { return i.configs }
+
+// Graceful reports whether the command handles signals.
+func (i genInput) Graceful() bool { return i.IsCommand && i.HandleSignals }
+
+// ShutdownNanos returns the shutdown timeout.
+func (i genInput) ShutdownNanos() int64 { return int64(i.shutdown) }
+
+// Cutoff reports whether the channel stops passing on values when the
+// command is shutting down, after which it is closed.
+func (i genInput) Cutoff(c string) bool { return i.cutoff[c] }
+
+// Manages reports whether the channel carries HTTPServerManagers, which are
+// shut down when the command is shutting down.
+func (i genInput) Manages(c string) bool {
+	return i.Channels[c].Type.String() == "parts.HTTPServerManager"
+}
+
+const prometheusImport = `"github.com/prometheus/client_golang/prometheus"`
+
+// Recovers reports whether any node recovers from panics.
+func (i genInput) Recovers() bool {
+	for _, n := range i.Nodes {
+		if n.Recovers() {
+			return true
+		}
+	}
+	return false
+}
+
+// Prometheus reports whether the graph uses Prometheus, in which case panics
+// recovered from are counted.
+func (i genInput) Prometheus() bool {
+	for _, imp := range i.Graph.AllImports() {
+		if strings.Contains(imp, `"github.com/prometheus/client_golang/`) {
+			return true
+		}
+	}
+	return false
+}
+
+// Recorded reports whether values sent on the channel are recorded.
+func (i genInput) Recorded(c string) bool { return contains(i.Opts.Record, c) }
+
+// Replayed reports whether values for the channel come from a recording.
+func (i genInput) Replayed(c string) bool { return contains(i.Opts.Replay, c) }
+
+// Orphaned reports whether the channel should be closed at the start.
+func (i genInput) Orphaned(c string) bool { return i.orphans[c] }
+
+// Skipped reports whether the node is not started.
+func (i genInput) Skipped(n *Node) bool { return i.skip[n.Name] }
+
+// Relayed reports whether values sent on the channel go via a goroutine.
+func (i genInput) Relayed(c string) bool {
+	return i.Opts.ProbeAddr != "" || i.Recorded(c)
+}
+
+// SendTo returns the channel that values should be sent on, after any
+// cutoff.
+func (i genInput) SendTo(c string) string {
+	if !i.Relayed(c) {
+		return c
+	}
+	return c + "Sends"
+}
+
+// Arg returns the argument passed to a node for one of its pins.
+func (i genInput) Arg(n *Node, p *pin.Definition) string {
+	c := n.Connections[p.Name]
+	if c == "nil" || p.Direction != pin.Output {
+		return c
+	}
+	if i.Cutoff(c) {
+		return c + "Writes"
+	}
+	return i.SendTo(c)
+}
+
+// cutoffs returns the channels cut off when a command is shutting down:
+// those carrying HTTPServerManagers, and those only written by started
+// nodes without inputs (source nodes). Channels replaced by replays, or not
+// written by any started node, are left alone.
+func (g *Graph) cutoffs(skip, orphans map[string]bool, replayed []string) map[string]bool {
+	writers := make(map[string]int)
+	sources := make(map[string]int)
+	for _, n := range g.Nodes {
+		if !n.Enabled || skip[n.Name] {
+			continue
+		}
+		source := true
+		for _, p := range n.Part.Pins() {
+			if p.Direction == pin.Input {
+				source = false
+			}
+		}
+		for pn, p := range n.Part.Pins() {
+			if p.Direction != pin.Output {
+				continue
+			}
+			c := n.Connections[pn]
+			writers[c]++
+			if source {
+				sources[c]++
+			}
+		}
+	}
+	m := make(map[string]bool)
+	for c, ch := range g.Channels {
+		if orphans[c] || contains(replayed, c) || writers[c] == 0 {
+			continue
+		}
+		if sources[c] == writers[c] || ch.Type.String() == "parts.HTTPServerManager" {
+			m[c] = true
+		}
+	}
+	return m
+}
+
+func contains(ss []string, s string) bool {
+	for _, t := range ss {
+		if t == s {
+			return true
+		}
+	}
+	return false
+}
+
 // WriteRawGoTo writes the Go language view of the graph to the io.Writer, without gofmt-ing.
 func (g *Graph) WriteRawGoTo(w io.Writer) error {
+	return g.WriteRawGoToWith(w, nil)
+}
+
+// WriteRawGoToWith is like WriteRawGoTo, but with options. opts may be nil.
+func (g *Graph) WriteRawGoToWith(w io.Writer, opts *GenOptions) error {
+	if opts == nil {
+		opts = new(GenOptions)
+	}
 	if err := g.InferTypes(); err != nil {
 		return err
 	}
 	for _, n := range g.Nodes {
 		n.RefreshImpl()
 	}
-	return goTemplate.Execute(w, g)
+	skip, orphans := g.ReplaySkips(opts.Replay)
+	in := genInput{
+		Graph:   g,
+		Opts:    opts,
+		skip:    skip,
+		orphans: orphans,
+		configs: g.configs(),
+	}
+	if in.Graceful() {
+		d, err := g.ShutdownTimeoutDuration()
+		if err != nil {
+			return err
+		}
+		in.shutdown = d
+		in.cutoff = g.cutoffs(skip, orphans, opts.Replay)
+	}
+	return goTemplate.Execute(w, in)
 }
 
 // RawGo outputs the unformatted Go language view of the graph.
@@ -243,9 +717,21 @@ func (g *Graph) RawGo() (string, error) {
 }
 
 // WriteGoTo writes the Go language view of the graph to the io.Writer.
+//
+// If the graph is a command with HandleSignals set, the command shuts down
+// on SIGINT or SIGTERM: the package variable rootCtx, which nodes may use,
+// is cancelled; the outputs of source nodes are closed, as are channels of
+// HTTPServerManagers after the managers seen on them are shut down; and the
+// command waits up to the shutdown timeout for the nodes it would wait for
+// anyway to finish, before exiting.
 func (g *Graph) WriteGoTo(w io.Writer) error {
+	return g.WriteGoToWith(w, nil)
+}
+
+// WriteGoToWith is like WriteGoTo, but with options. opts may be nil.
+func (g *Graph) WriteGoToWith(w io.Writer, opts *GenOptions) error {
 	buf := &bytes.Buffer{}
-	if err := g.WriteRawGoTo(buf); err != nil {
+	if err := g.WriteRawGoToWith(buf, opts); err != nil {
 		return err
 	}
 	return source.GoFmt(w, buf)
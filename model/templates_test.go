@@ -14,7 +14,13 @@
 
 package model
 
-import "testing"
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/google/shenzhen-go/model/pin"
+)
 
 type nopWriter struct{}
 
@@ -26,8 +32,185 @@ func TestGoTemplate(t *testing.T) {
 		if err := g.InferTypes(); err != nil {
 			t.Fatalf("InferTypes() = error %v", err)
 		}
-		if err := goTemplate.Execute(nopWriter{}, g); err != nil {
-			t.Errorf("goTemplate.Execute(%v) = error %v", name, err)
+		for _, opts := range []*GenOptions{
+			{},
+			{ProfileDir: "/tmp/profiles"},
+			{ProbeAddr: "localhost:1234"},
+			{ProbeAddr: "localhost:1234", Debug: true, Breakpoints: []string{"bar"}},
+		} {
+			if err := goTemplate.Execute(nopWriter{}, genInput{Graph: g, Opts: opts}); err != nil {
+				t.Errorf("goTemplate.Execute(%v, %+v) = error %v", name, opts, err)
+			}
+		}
+	}
+}
+
+func TestGoTemplateProbe(t *testing.T) {
+	g := TestGraphs["has a node and a channel"]
+	tests := []struct {
+		opts       *GenOptions
+		want, nope []string
+	}{
+		{
+			opts: &GenOptions{ProbeAddr: "localhost:1234"},
+			want: []string{
+				`probeConn := probe.Dial("localhost:1234")`,
+				`barSends := make(chan `,
+				`probeConn.Channel("bar", cap(bar), func() int { return len(bar) })`,
+				`foo(barSends,)`,
+			},
+			nope: []string{`pc.Hold(x)`},
+		},
+		{
+			opts: &GenOptions{ProbeAddr: "localhost:1234", Debug: true, Breakpoints: []string{"bar"}},
+			want: []string{
+				`probeConn := probe.Dial("localhost:1234", "bar")`,
+				`if pc.Breaking() && !pc.Hold(x) {`,
+			},
+		},
+		{
+			opts: &GenOptions{ProfileDir: "/tmp/profiles"},
+			want: []string{
+				`stopProfiling := probe.StartProfiling("/tmp/profiles")`,
+				`signal.Notify(profileSignals, os.Interrupt)`,
+			},
+		},
+		{
+			opts: &GenOptions{Record: []string{"bar"}, RecordFile: "bar.rec"},
+			want: []string{
+				`probeRecorder := probe.StartRecording("bar.rec")`,
+				`probeRecorder.Record("bar", x)`,
+				`}(barSends, bar, nil)`,
+				`foo(barSends,)`,
+			},
+			nope: []string{`probeConn`},
+		},
+		{
+			opts: &GenOptions{Replay: []string{"bar"}, ReplayFile: "bar.rec"},
+			want: []string{
+				`probe.Replay("bar.rec", "bar", func(decode func(interface{}) error) error {`,
+				`}(bar)`,
+			},
+			nope: []string{`foo(bar,)`, `probeRecorder`},
+		},
+	}
+	for _, test := range tests {
+		var buf bytes.Buffer
+		if err := g.WriteRawGoToWith(&buf, test.opts); err != nil {
+			t.Fatalf("WriteRawGoToWith(%+v) = error %v", test.opts, err)
+		}
+		src := buf.String()
+		for _, want := range test.want {
+			if !strings.Contains(src, want) {
+				t.Errorf("generated source with %+v doesn't contain %q:\n%s", test.opts, want, src)
+			}
+		}
+		for _, nope := range test.nope {
+			if strings.Contains(src, nope) {
+				t.Errorf("generated source with %+v contains %q:\n%s", test.opts, nope, src)
+			}
 		}
 	}
 }
+
+func TestGoTemplatePanicPolicy(t *testing.T) {
+	g := fixtureTestGraph("")
+	g.RefreshChannelsPins()
+	g.Nodes["dbl"].Multiplicity = "1"
+	g.Nodes["dbl"].PanicPolicy = PanicRestart
+	g.Nodes["dbl"].MaxRestarts = 3
+	g.Nodes["sink"].Multiplicity = "1"
+	g.Nodes["sink"].PanicPolicy = PanicStop
+	g.Nodes["sink"].MaxRestarts = 3 // ignored
+	var buf bytes.Buffer
+	if err := g.WriteRawGoTo(&buf); err != nil {
+		t.Fatalf("WriteRawGoTo() = error %v", err)
+	}
+	src := buf.String()
+	for _, want := range []string{
+		`"log"`,
+		`"time"`,
+		"func recoverNode(name string, restarts int, body func()) {",
+		`recoverNode("dbl", 3, func() {`,
+		`recoverNode("sink", 0, func() {`,
+	} {
+		if !strings.Contains(src, want) {
+			t.Errorf("generated source doesn't contain %q:\n%s", want, src)
+		}
+	}
+	for _, nope := range []string{`recoverNode("src"`, "nodePanics"} {
+		if strings.Contains(src, nope) {
+			t.Errorf("generated source contains %q:\n%s", nope, src)
+		}
+	}
+}
+
+func TestGoTemplateSignals(t *testing.T) {
+	g := fixtureTestGraph("")
+	g.RefreshChannelsPins()
+	g.IsCommand = true
+	g.HandleSignals = true
+	g.ShutdownTimeout = "2s"
+	var buf bytes.Buffer
+	if err := g.WriteRawGoTo(&buf); err != nil {
+		t.Fatalf("WriteRawGoTo() = error %v", err)
+	}
+	src := buf.String()
+	for _, want := range []string{
+		`"os/signal"`,
+		"rootCtx, rootCancel = context.WithCancel(context.Background())",
+		"shutdownTimeout = time.Duration(2000000000)",
+		"signal.Notify(shutdownSignals, os.Interrupt, syscall.SIGTERM)",
+		"}(numsWrites, nums)",
+		"src(numsWrites,)",
+		"dbl(nums,doubled,)",
+	} {
+		if !strings.Contains(src, want) {
+			t.Errorf("generated source doesn't contain %q:\n%s", want, src)
+		}
+	}
+	if strings.Contains(src, "doubledWrites") {
+		t.Errorf("generated source cuts off a channel not written by a source node:\n%s", src)
+	}
+
+	buf.Reset()
+	if err := g.WriteRawGoToWith(&buf, &GenOptions{ProfileDir: "/tmp/profiles"}); err != nil {
+		t.Fatalf("WriteRawGoToWith() = error %v", err)
+	}
+	src = buf.String()
+	if want := "stopProfiling()\n\t\tos.Exit(1)"; !strings.Contains(src, want) {
+		t.Errorf("generated source doesn't write profiles before exiting:\n%s", src)
+	}
+	if strings.Contains(src, "profileSignals") {
+		t.Errorf("generated source handles signals twice:\n%s", src)
+	}
+
+	// Channels of HTTP server managers have them shut down, all at once.
+	g.Nodes["src"].Part = &FakePart{Pns: pin.NewMap(
+		&pin.Definition{Name: "out", Type: "parts.HTTPServerManager", Direction: pin.Output},
+	)}
+	g.Nodes["dbl"].Part = &FakePart{Pns: pin.NewMap(
+		&pin.Definition{Name: "in", Type: "parts.HTTPServerManager", Direction: pin.Input},
+		&pin.Definition{Name: "out", Type: "int", Direction: pin.Output},
+	)}
+	g.RefreshChannelsPins()
+	buf.Reset()
+	if err := g.WriteRawGoTo(&buf); err != nil {
+		t.Fatalf("WriteRawGoTo() = error %v", err)
+	}
+	src = buf.String()
+	for _, want := range []string{
+		"managers = append(managers, x)",
+		"go func(m parts.HTTPServerManager) {",
+		"shutdowns.Wait()",
+	} {
+		if !strings.Contains(src, want) {
+			t.Errorf("generated source doesn't contain %q:\n%s", want, src)
+		}
+	}
+
+	g.ShutdownTimeout = "-1s"
+	if err := g.WriteRawGoTo(&buf); err == nil {
+		t.Error("WriteRawGoTo() with a negative shutdown timeout = nil error, want error")
+	}
+}
@@ -0,0 +1,127 @@
+// Copyright 2018 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package parts
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/google/shenzhen-go/model"
+	"github.com/google/shenzhen-go/model/pin"
+)
+
+var afterPins = pin.NewMap(
+	&pin.Definition{
+		Name:      "stop",
+		Direction: pin.Input,
+		Type:      "struct{}",
+	},
+	&pin.Definition{
+		Name:      "output",
+		Direction: pin.Output,
+		Type:      "time.Time",
+	},
+)
+
+func init() {
+	model.RegisterPartType("After", "Time", &model.PartType{
+		New: func() model.Part {
+			return &After{Delay: time.Second}
+		},
+		Panels: []model.PartPanel{
+			{
+				Name: "After",
+				Editor: `
+			<div class="form">
+				<div class="formfield">
+					<label for="after-delay">Delay</label>
+					<input id="after-delay" name="after-delay" type="text" required title="Must be a parseable time.Duration." value="1s"></input>
+				</div>
+				<div class="formfield">
+					<label for="after-jitter">Jitter</label>
+					<input id="after-jitter" name="after-jitter" type="text" required title="Must be a parseable time.Duration. 0s means no jitter." value="0s"></input>
+				</div>
+			</div>`,
+			},
+			{
+				Name: "Help",
+				Editor: `<div>
+			<p>
+				An After part waits for the delay, sends the current time on the 
+				output once, and closes the output. If the stop input receives a
+				value or is closed first, the output is closed without sending.
+			</p><p>
+				With jitter, the delay is lengthened by a random duration, up to
+				the jitter.
+			</p>
+			</div>`,
+			},
+		},
+	})
+}
+
+// After is a part which sends the time once, after a delay.
+type After struct {
+	Delay  time.Duration `json:"delay"`
+	Jitter time.Duration `json:"jitter,omitempty"`
+}
+
+// Clone returns a clone of this After.
+func (a *After) Clone() model.Part {
+	a0 := *a
+	return &a0
+}
+
+// Settings returns the settings that can be made configurable.
+func (a *After) Settings() []model.Setting {
+	return []model.Setting{
+		{Name: "delay", Type: "time.Duration", Value: fmt.Sprintf("time.Duration(%d)", a.Delay), Usage: "Time to wait before sending"},
+		{Name: "jitter", Type: "time.Duration", Value: fmt.Sprintf("time.Duration(%d)", a.Jitter), Usage: "Maximum random time added to the delay"},
+	}
+}
+
+// Impl returns the After implementation.
+func (a *After) Impl(n *model.Node) model.PartImpl {
+	tail := ""
+	if n.Connections["output"] != "nil" {
+		// We know at design time whether a pin is nil.
+		tail = "close(output)"
+	}
+	return model.PartImpl{
+		Imports: []string{`"math/rand"`, `"time"`},
+		Head:    n.SettingDecl("delay", "delay") + "\n" + n.SettingDecl("jitter", "jitter"),
+		Body: `wait := delay
+		if jitter > 0 {
+			wait += time.Duration(rand.Int63n(int64(jitter)))
+		}
+		timer := time.NewTimer(wait)
+		select {
+		case <-stop:
+			timer.Stop()
+		case t := <-timer.C:
+			select {
+			case <-stop:
+			case output <- t:
+			}
+		}`,
+		Tail: tail,
+	}
+}
+
+// Pins returns a map declaring a stop input and an output of time.Time.
+func (a *After) Pins() pin.Map { return afterPins }
+
+// TypeKey returns "After".
+func (a *After) TypeKey() string { return "After" }
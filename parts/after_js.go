@@ -0,0 +1,41 @@
+// Copyright 2018 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//+build js
+
+package parts
+
+import "time"
+
+var (
+	inputAfterDelay  = doc.ElementByID("after-delay")
+	inputAfterJitter = doc.ElementByID("after-jitter")
+
+	focusedAfter *After
+)
+
+func init() {
+	inputAfterDelay.AddEventListener("change", durationChange(func(t time.Duration) {
+		focusedAfter.Delay = t
+	}))
+	inputAfterJitter.AddEventListener("change", durationChange(func(t time.Duration) {
+		focusedAfter.Jitter = t
+	}))
+}
+
+func (a *After) GainFocus() {
+	focusedAfter = a
+	inputAfterDelay.Set("value", a.Delay.String())
+	inputAfterJitter.Set("value", a.Jitter.String())
+}
@@ -0,0 +1,185 @@
+// Copyright 2018 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package parts
+
+import (
+	"bytes"
+	"fmt"
+	"text/template"
+	"time"
+
+	"github.com/google/shenzhen-go/model"
+	"github.com/google/shenzhen-go/model/pin"
+)
+
+var (
+	batchPins = pin.NewMap(
+		&pin.Definition{
+			Name:      "input",
+			Direction: pin.Input,
+			Type:      "$Any",
+		},
+		&pin.Definition{
+			Name:      "output",
+			Direction: pin.Output,
+			Type:      "[]$Any",
+		})
+
+	batchBodyTmpl = template.Must(template.New("batch-body").Parse(`
+	batch := make([]{{.Type}}, 0, maxSize)
+	{{- if .Reuse}}
+	// The batch being collected and the last batch sent take turns, so
+	// each backing array is reused once the receiver has taken the next.
+	spare := make([]{{.Type}}, 0, maxSize)
+	{{- end}}
+	var timeout <-chan time.Time // fires maxDelay after the first value of the batch
+	flush := func() {
+		output <- batch
+		{{- if .Reuse}}
+		batch, spare = spare[:0], batch
+		{{- else}}
+		batch = make([]{{.Type}}, 0, maxSize)
+		{{- end}}
+		timeout = nil
+	}
+batchLoop:
+	for {
+		select {
+		case in, open := <-input:
+			if !open {
+				break batchLoop
+			}
+			batch = append(batch, in)
+			if len(batch) >= maxSize {
+				flush()
+				break // select
+			}
+			if len(batch) == 1 && maxDelay > 0 {
+				timeout = time.After(maxDelay)
+			}
+		case <-timeout:
+			flush()
+		}
+	}
+	if len(batch) > 0 {
+		output <- batch
+	}`))
+)
+
+func init() {
+	model.RegisterPartType("Batch", "Flow", &model.PartType{
+		New: func() model.Part {
+			return &Batch{
+				MaxSize:  100,
+				MaxDelay: time.Second,
+			}
+		},
+		Panels: []model.PartPanel{
+			{
+				Name: "Batch",
+				Editor: `
+			<div class="form">
+				<div class="formfield">
+					<label for="batch-maxsize">Maximum size</label>
+					<input id="batch-maxsize" name="batch-maxsize" type="number" required title="Must be a whole number, at least 1." value="100"></input>
+				</div>
+				<div class="formfield">
+					<label for="batch-maxdelay">Maximum delay</label>
+					<input id="batch-maxdelay" name="batch-maxdelay" type="text" required title="Must be a parseable time.Duration. 0s means no limit." value="1s"></input>
+				</div>
+				<div class="formfield">
+					<input id="batch-reuseslices" name="batch-reuseslices" type="checkbox"></input>
+					<label for="batch-reuseslices">Reuse slices</label>
+				</div>
+			</div>`,
+			},
+			{
+				Name: "Help",
+				Editor: `<div>
+			<p>
+				A Batch part collects values from the input into slices, and sends
+				each slice on the output once it has the maximum size, or the
+				maximum delay has passed since the first value in it was received.
+				A maximum delay of 0s means batches are only sent when full. When
+				the input is closed, any partial batch is sent, and then the
+				output is closed. It is the inverse of Unbatch.
+			</p><p>
+				With reuse slices, only two backing arrays are used, taking turns.
+				The receiver must be done with each batch before receiving the
+				next one, because the one before is then overwritten. This is only
+				safe with a single receiver that doesn't keep batches.
+			</p>
+			</div>`,
+			},
+		},
+	})
+}
+
+// Batch is a part which collects values into slices, sending each slice
+// when it is full or has waited long enough.
+type Batch struct {
+	MaxSize  int           `json:"max_size"`
+	MaxDelay time.Duration `json:"max_delay,omitempty"` // 0 means no limit
+
+	// ReuseSlices alternates between two backing arrays instead of making
+	// one per batch. The receiver must be done with each batch before
+	// receiving the next.
+	ReuseSlices bool `json:"reuse_slices,omitempty"`
+}
+
+// Clone returns a clone of this Batch.
+func (b *Batch) Clone() model.Part {
+	b0 := *b
+	return &b0
+}
+
+// Settings returns the settings that can be made configurable.
+func (b *Batch) Settings() []model.Setting {
+	return []model.Setting{
+		{Name: "max_size", Type: "int", Value: fmt.Sprint(b.MaxSize), Usage: "Maximum number of values in a batch"},
+		{Name: "max_delay", Type: "time.Duration", Value: fmt.Sprintf("time.Duration(%d)", b.MaxDelay), Usage: "Maximum time to wait before sending a partial batch, or 0 for no limit"},
+	}
+}
+
+// Impl returns the Batch implementation.
+func (b *Batch) Impl(n *model.Node) model.PartImpl {
+	params := struct {
+		Type  string
+		Reuse bool
+	}{
+		Type:  n.TypeParams["$Any"].String(),
+		Reuse: b.ReuseSlices,
+	}
+	body := bytes.NewBuffer(nil)
+	if err := batchBodyTmpl.Execute(body, params); err != nil {
+		panic("couldn't execute batch-body template: " + err.Error())
+	}
+	// make panics on a negative size, and a size of 0 would send every
+	// value on its own.
+	check, imps := settingCheck(n, "max_size", "maxSize", b.MaxSize >= 1, "maxSize < 1", "at least 1")
+	return model.PartImpl{
+		Imports: append(imps, `"time"`),
+		Head:    n.SettingDecl("maxSize", "max_size") + "\n" + n.SettingDecl("maxDelay", "max_delay") + check,
+		Body:    body.String(),
+		Tail:    "close(output)",
+	}
+}
+
+// Pins returns a map declaring a single input of any type and a single
+// output of slices of that type.
+func (b *Batch) Pins() pin.Map { return batchPins }
+
+// TypeKey returns "Batch".
+func (b *Batch) TypeKey() string { return "Batch" }
@@ -0,0 +1,56 @@
+// Copyright 2018 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//+build js
+
+package parts
+
+import (
+	"log"
+	"time"
+
+	"github.com/google/shenzhen-go/dom"
+)
+
+var (
+	inputBatchMaxSize     = doc.ElementByID("batch-maxsize")
+	inputBatchMaxDelay    = doc.ElementByID("batch-maxdelay")
+	inputBatchReuseSlices = doc.ElementByID("batch-reuseslices")
+
+	focusedBatch *Batch
+)
+
+func init() {
+	inputBatchMaxSize.AddEventListener("change", func(dom.Object) {
+		size := inputBatchMaxSize.Get("value").Int()
+		if size < 1 {
+			log.Printf("maximum size %d is less than 1", size)
+			return
+		}
+		focusedBatch.MaxSize = size
+	})
+	inputBatchMaxDelay.AddEventListener("change", durationChange(func(t time.Duration) {
+		focusedBatch.MaxDelay = t
+	}))
+	inputBatchReuseSlices.AddEventListener("change", func(dom.Object) {
+		focusedBatch.ReuseSlices = inputBatchReuseSlices.Get("checked").Bool()
+	})
+}
+
+func (b *Batch) GainFocus() {
+	focusedBatch = b
+	inputBatchMaxSize.Set("value", b.MaxSize)
+	inputBatchMaxDelay.Set("value", b.MaxDelay.String())
+	inputBatchReuseSlices.Set("checked", b.ReuseSlices)
+}
@@ -0,0 +1,106 @@
+// Copyright 2018 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package parts
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestBatch(t *testing.T) {
+	for _, b := range []*Batch{
+		{MaxSize: 3},
+		{MaxSize: 3, MaxDelay: time.Millisecond},
+		{MaxSize: 3, ReuseSlices: true},
+	} {
+		for _, mult := range []string{"1", "2"} {
+			typeCheckPipeline(t, b, "string", mult, "input", "output")
+		}
+	}
+}
+
+func TestBatchRun(t *testing.T) {
+	tests := []struct {
+		name   string
+		batch  *Batch
+		script string
+		want   []string
+	}{
+		{
+			name:   "max size",
+			batch:  &Batch{MaxSize: 3},
+			script: `for i := 0; i < 10; i++ { output <- i }`,
+			want:   []string{"[0 1 2]", "[3 4 5]", "[6 7 8]", "[9]"},
+		},
+		{
+			name:   "max delay",
+			batch:  &Batch{MaxSize: 100, MaxDelay: 50 * time.Millisecond},
+			script: `output <- 0; output <- 1; time.Sleep(250 * time.Millisecond); output <- 2; output <- 3`,
+			want:   []string{"[0 1]", "[2 3]"},
+		},
+		{
+			// Safe, because each batch is printed before the next is received.
+			name:   "reuse slices",
+			batch:  &Batch{MaxSize: 3, ReuseSlices: true},
+			script: `for i := 0; i < 10; i++ { output <- i }`,
+			want:   []string{"[0 1 2]", "[3 4 5]", "[6 7 8]", "[9]"},
+		},
+		{
+			name:   "empty",
+			batch:  &Batch{MaxSize: 3, MaxDelay: time.Millisecond},
+			script: ``,
+			want:   nil,
+		},
+	}
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+			got := runPart(t, test.batch, "int", map[string]string{"input": test.script}, "output")
+			if !reflect.DeepEqual(got["output"], test.want) {
+				t.Errorf("output = %q, want %q", got["output"], test.want)
+			}
+		})
+	}
+}
+
+func TestBatchReuseSlicesHazard(t *testing.T) {
+	// Keeping batches after receiving the next breaks the rule for reusing
+	// slices: the batch before last is overwritten.
+	for _, test := range []struct {
+		reuse bool
+		want  string
+	}{
+		{false, "[[0 1 2] [3 4 5] [6 7 8]]"},
+		{true, "[[6 7 8] [3 4 5] [6 7 8]]"},
+	} {
+		g := partGraph(&Batch{MaxSize: 3, ReuseSlices: test.reuse}, "int", map[string]string{
+			"input": `for i := 0; i < 9; i++ { output <- i }`,
+		}, "output")
+		sink := g.Nodes["sink_output"].Part.(*Code)
+		sink.Body = []string{
+			"var kept [][]int",
+			"for x := range input {",
+			"\tkept = append(kept, x)",
+			"}",
+			`fmt.Printf("output\t%v\n", kept)`,
+		}
+		got := runPartGraph(t, g)["output"]
+		if want := []string{test.want}; !reflect.DeepEqual(got, want) {
+			t.Errorf("with ReuseSlices = %t, kept batches = %q, want %q", test.reuse, got, want)
+		}
+	}
+}
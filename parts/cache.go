@@ -18,6 +18,7 @@ import (
 	"bytes"
 	"fmt"
 	"text/template"
+	"time"
 
 	"github.com/google/shenzhen-go/model"
 	"github.com/google/shenzhen-go/model/pin"
@@ -65,17 +66,171 @@ var (
 	)
 
 	cacheHeadTmpl = template.Must(template.New("cache-head").Parse(`
-	const bytesLimit = {{.BytesLimit}}
+	{{.BytesLimitDecl}}
+	{{.MaxEntriesDecl}}
+	{{.TTLDecl}}
 	type cacheEntry struct {
-		data []byte
-		last time.Time
-		{{if .Mult}}sync.Mutex{{end}}
+		key     {{.KeyType}}
+		data    []byte
+		expires time.Time
+		{{- if eq .Policy "lfu"}}
+		elem    *list.Element // in the entries of its bucket
+		bucket  *list.Element // in buckets
+		{{- else if eq .Policy "arc"}}
+		elem    *list.Element // in recent or frequent
+		in      *list.List
+		{{- else}}
+		elem    *list.Element // in recency
+		{{- end}}
+		age     *list.Element // in ages, if there is a TTL
 	}
-	{{if .Mult}}var mu sync.RWMutex{{end}}
+	{{if .Mult}}var mu sync.Mutex{{end}}
 	totalBytes := uint64(0)
 	cache := make(map[{{.KeyType}}]*cacheEntry)
+	ages := list.New() // oldest at the front
+	{{if eq .Policy "lfu" -}}
+	// Entries are kept in buckets by how many times they have been used,
+	// least used first. Within each bucket, the most recently used entry
+	// is at the front, so ties are broken by evicting the least recent.
+	type cacheBucket struct {
+		uses    int
+		entries *list.List
+	}
+	buckets := list.New()
+	unlink := func(e *cacheEntry) (prev *list.Element) {
+		b := e.bucket.Value.(*cacheBucket)
+		b.entries.Remove(e.elem)
+		if b.entries.Len() > 0 {
+			return e.bucket
+		}
+		prev = e.bucket.Prev()
+		buckets.Remove(e.bucket)
+		return prev
+	}
+	// place adds e to the bucket for uses, which belongs after prev, or
+	// at the front if prev is nil.
+	place := func(e *cacheEntry, uses int, prev *list.Element) {
+		next := buckets.Front()
+		if prev != nil {
+			next = prev.Next()
+		}
+		if next == nil || next.Value.(*cacheBucket).uses != uses {
+			b := &cacheBucket{uses: uses, entries: list.New()}
+			if prev == nil {
+				next = buckets.PushFront(b)
+			} else {
+				next = buckets.InsertAfter(b, prev)
+			}
+		}
+		e.bucket = next
+		e.elem = next.Value.(*cacheBucket).entries.PushFront(e)
+	}
+	touch := func(e *cacheEntry) {
+		uses := e.bucket.Value.(*cacheBucket).uses
+		place(e, uses+1, unlink(e))
+	}
+	insert := func(e *cacheEntry) {
+		place(e, 1, nil)
+	}
+	victim := func() *cacheEntry {
+		return buckets.Front().Value.(*cacheBucket).entries.Back().Value.(*cacheEntry)
+	}
+	{{- else if eq .Policy "arc" -}}
+	// Adaptive replacement: recent has the entries used only when they
+	// were put, and frequent those used since. The keys of entries evicted
+	// from each are remembered for a while as ghosts. Putting the key of a
+	// ghost again means it was evicted too soon, so the target number of
+	// entries in recent adapts in favour of the list it came from. A scan
+	// of keys used once only passes through recent.
+	type cacheGhost struct {
+		key {{.KeyType}}
+		in  *list.List
+	}
+	recent, frequent := list.New(), list.New()
+	recentGhosts, frequentGhosts := list.New(), list.New()
+	ghosts := make(map[{{.KeyType}}]*list.Element)
+	target := 0
+	unlink := func(e *cacheEntry) {
+		e.in.Remove(e.elem)
+	}
+	touch := func(e *cacheEntry) {
+		e.in.Remove(e.elem)
+		e.in, e.elem = frequent, frequent.PushFront(e)
+	}
+	insert := func(e *cacheEntry) {
+		ge, ok := ghosts[e.key]
+		if !ok {
+			e.in, e.elem = recent, recent.PushFront(e)
+			return
+		}
+		g := ge.Value.(*cacheGhost)
+		if g.in == recentGhosts {
+			delta := 1
+			if n := frequentGhosts.Len() / recentGhosts.Len(); n > delta {
+				delta = n
+			}
+			if target += delta; target > len(cache)+1 {
+				target = len(cache) + 1
+			}
+		} else {
+			delta := 1
+			if n := recentGhosts.Len() / frequentGhosts.Len(); n > delta {
+				delta = n
+			}
+			if target -= delta; target < 0 {
+				target = 0
+			}
+		}
+		g.in.Remove(ge)
+		delete(ghosts, e.key)
+		e.in, e.elem = frequent, frequent.PushFront(e)
+	}
+	forget := func(l *list.List) {
+		delete(ghosts, l.Remove(l.Back()).(*cacheGhost).key)
+	}
+	victim := func() *cacheEntry {
+		from, to := frequent, frequentGhosts
+		if recent.Len() > 0 && (recent.Len() > target || frequent.Len() == 0) {
+			from, to = recent, recentGhosts
+		}
+		e := from.Back().Value.(*cacheEntry)
+		ghosts[e.key] = to.PushFront(&cacheGhost{key: e.key, in: to})
+		// Keep about as many ghosts as entries.
+		for recentGhosts.Len() > 0 && recent.Len()+recentGhosts.Len() > len(cache) {
+			forget(recentGhosts)
+		}
+		for frequentGhosts.Len() > 0 && recentGhosts.Len()+frequentGhosts.Len() > len(cache) {
+			forget(frequentGhosts)
+		}
+		return e
+	}
+	{{- else -}}
+	recency := list.New() // most recently used at the front
+	unlink := func(e *cacheEntry) {
+		recency.Remove(e.elem)
+	}
+	touch := func(e *cacheEntry) {
+		recency.MoveToFront(e.elem)
+	}
+	insert := func(e *cacheEntry) {
+		e.elem = recency.PushFront(e)
+	}
+	victim := func() *cacheEntry {
+		return recency.{{if eq .Policy "mru"}}Front{{else}}Back{{end}}().Value.(*cacheEntry)
+	}
+	{{- end}}
+	remove := func(e *cacheEntry) (size uint64) {
+		delete(cache, e.key)
+		unlink(e)
+		if e.age != nil {
+			ages.Remove(e.age)
+		}
+		size = uint64(len(e.data))
+		totalBytes -= size
+		return size
+	}
 	{{if .Prometheus -}}
-	cacheLimit.With(prometheus.Labels{"node_name":"{{.NodeName}}"}).Set(bytesLimit)
+	cacheLimit.With(prometheus.Labels{"node_name":"{{.NodeName}}"}).Set(float64(bytesLimit))
 	cacheSize := cacheSize.With(prometheus.Labels{"node_name":"{{.NodeName}}"})
 	cacheSize.Set(0)
 	{{end -}}`))
@@ -94,6 +249,27 @@ var (
 	cachePutsSize := cachePutsSize.With(labels)
 	cacheEvictionsSize := cacheEvictionsSize.With(labels)
 	{{end -}}
+	evict := func(e *cacheEntry) {
+		{{if .Prometheus -}}
+		size := remove(e)
+		cacheEvictions.Inc()
+		cacheEvictionsSize.Add(float64(size))
+		cacheSize.Set(float64(totalBytes))
+		{{- else -}}
+		remove(e)
+		{{- end}}
+	}
+	// expire evicts the entries that have outlived the TTL. They are
+	// oldest first in ages, so this stops at the first one that hasn't.
+	expire := func(now time.Time) {
+		for ttl > 0 && ages.Len() > 0 {
+			e := ages.Front().Value.(*cacheEntry)
+			if now.Before(e.expires) {
+				return
+			}
+			evict(e)
+		}
+	}
 handleLoop:
 	for {
 		select {
@@ -101,82 +277,65 @@ handleLoop:
 			if !open {
 				break handleLoop
 			}
-			{{if .Mult}}mu.RLock(){{end}}
+			{{if .Mult}}mu.Lock(){{end}}
+			expire(time.Now())
 			e, ok := cache[g.Key]
-			{{if .Mult}}mu.RUnlock(){{end}}
+			var data []byte
+			if ok {
+				touch(e)
+				data = e.data
+			}
+			{{if .Mult}}mu.Unlock(){{end}}
 			if !ok {
 				miss <- g
 				{{if .Prometheus}}cacheMisses.Inc(){{end}}
 				continue
 			}
-			{{if .Mult}}e.Lock(){{end}}
 			{{if .Prometheus -}}
 			cacheHits.Inc()
-			cacheHitsSize.Add(float64(len(e.data)))
+			cacheHitsSize.Add(float64(len(data)))
 			{{end -}}
 			hit <- {{.HitType}}{
 				Key: g.Key,
 				Ctx: g.Ctx,
-				Data: e.data,
+				Data: data,
 			}
-			e.last = time.Now()
-			{{if .Mult}}e.Unlock(){{end}}
 			
 		case p, open := <-put:
 			if !open {
 				put = nil
 				continue
 			}
-			if len(p.Data) > bytesLimit {
+			size := uint64(len(p.Data))
+			if size > bytesLimit {
 				continue
 			}
 			
-			// TODO: Can improve eviction algorithm - this is simplistic but O(n^2)
 			{{if .Mult}}mu.Lock(){{end}}
-			for {
-				// Find something to evict if needed.
-				var ek {{.KeyType}}
-				var ee *cacheEntry
-				et := {{.InitTime}}
-				for k, e := range cache {
-					{{if .Mult}}e.Lock(){{end}}
-					if e.last.{{.TimeComp}}(et) {
-						ee, et, ek = e, e.last, k
-					}
-					{{if .Mult}}e.Unlock(){{end}}
-				}
-				// Necessary to evict?
-				if totalBytes + uint64(len(p.Data)) > bytesLimit {
-					// Evict ek.
-					if ee == nil {
-						break
-					}
-					{{if .Mult}}ee.Lock(){{end}}
-					size := uint64(len(ee.data))
-					{{if .Mult}}ee.Unlock(){{end}}
-					totalBytes -= size
-					delete(cache, ek)
-					{{if .Prometheus -}}
-					cacheEvictions.Inc()
-					cacheEvictionsSize.Add(float64(size))
-					{{end -}}
-					continue
-				}
-
-				// No - insert now.
-				size := uint64(len(p.Data))
-				cache[p.Key] = &cacheEntry{
-					data: p.Data,
-					last: time.Now(),
-				}
-				totalBytes += size
-				{{if .Prometheus -}}
-				cachePuts.Inc()
-				cachePutsSize.Add(float64(size))
-				cacheSize.Set(float64(totalBytes))
-				{{end -}}
-				break
+			now := time.Now()
+			expire(now)
+			if e, ok := cache[p.Key]; ok {
+				remove(e) // Replaced, not evicted.
+			}
+			for totalBytes+size > bytesLimit || (maxEntries > 0 && len(cache) >= maxEntries) {
+				evict(victim())
+			}
+			e := &cacheEntry{
+				key:  p.Key,
+				data: p.Data,
 			}
+			insert(e)
+			if ttl > 0 {
+				e.expires = now.Add(ttl)
+				e.age = ages.PushBack(e)
+			}
+			cache[p.Key] = e
+			totalBytes += size
+			{{if .Prometheus -}}
+			cachePuts.Inc()
+			cachePutsSize.Add(float64(size))
+			cacheSize.Set(float64(totalBytes))
+			{{end -}}
 			{{if .Mult}}mu.Unlock(){{end}}
 		}
 	}`))
@@ -187,7 +346,7 @@ func init() {
 		New: func() model.Part {
 			return &Cache{
 				ContentBytesLimit: 1 << 30,
-				EvictionMode:      EvictLRU,
+				EvictionPolicy:    EvictLRU,
 			}
 		},
 		Init: `
@@ -302,10 +461,20 @@ func init() {
 						<input id="cache-contentbyteslimit" name="cache-contentbyteslimit" type="number" required title="Must be a whole number, at least 1." value="1073741824"></input>
 					</div>
 					<div class="formfield">
-						<label for="cache-evictionmode">Eviction mode</label>
-						<select id="cache-evictionmode" name="cache-evictionmode">
+						<label for="cache-maxentries">Maximum entries</label>
+						<input id="cache-maxentries" name="cache-maxentries" type="number" required title="Must be a whole number. 0 means no limit." value="0"></input>
+					</div>
+					<div class="formfield">
+						<label for="cache-ttl">Time to live</label>
+						<input id="cache-ttl" name="cache-ttl" type="text" required title="Must be a parseable time.Duration. Entries are evicted this long after being put; 0s means never." value="0s"></input>
+					</div>
+					<div class="formfield">
+						<label for="cache-evictionpolicy">Eviction policy</label>
+						<select id="cache-evictionpolicy" name="cache-evictionpolicy">
 							<option value="lru" selected>LRU (least recently used)</option>
 							<option value="mru">MRU (most recently used)</option>
+							<option value="lfu">LFU (least frequently used)</option>
+							<option value="arc">ARC (adaptive replacement, scan-resistant)</option>
 						</select>
 					</div>
 				</div>`,
@@ -315,7 +484,22 @@ func init() {
 				Editor: `<div><p>
 				A Cache part caches content in memory. It supports concurrently inserting and retrieving items.
 			</p><p>
-				TODO: Implement Cache part.
+				Values received on put are stored under their key, and requests received
+				on get are sent to hit, with the stored content, or else to miss.
+			</p><p>
+				When storing a value would take the cache over the maximum bytes or the
+				maximum entries, entries are evicted first, chosen by the eviction policy:
+			</p><ul>
+				<li>LRU evicts the least recently used entry.</li>
+				<li>MRU evicts the most recently used entry.</li>
+				<li>LFU evicts the least frequently used entry, and the least recently
+				used of those if there is a tie.</li>
+				<li>ARC balances recency and frequency, adapting to which evictions turn out
+				to be mistakes. Entries used more than once aren't pushed out by a scan
+				of many entries used only once.</li>
+			</ul><p>
+				Values bigger than the maximum bytes are not stored. With a time to live,
+				entries are also evicted once they are that old.
 			</p></div>`,
 			},
 		},
@@ -324,29 +508,42 @@ func init() {
 
 // Cache is a part which caches content in memory.
 type Cache struct {
-	ContentBytesLimit uint64            `json:"content_bytes_limit"`
-	EnablePrometheus  bool              `json:"enable_prometheus"`
-	EvictionMode      CacheEvictionMode `json:"eviction_mode"`
+	ContentBytesLimit uint64              `json:"content_bytes_limit"`
+	MaxEntries        int                 `json:"max_entries,omitempty"` // 0 means no limit
+	TTL               time.Duration       `json:"ttl,omitempty"`         // 0 means entries don't expire
+	EnablePrometheus  bool                `json:"enable_prometheus"`
+	EvictionPolicy    CacheEvictionPolicy `json:"eviction_policy,omitempty"`
+
+	// EvictionMode is the eviction policy of graphs saved before
+	// EvictionPolicy, which only offered LRU and MRU.
+	EvictionMode CacheEvictionPolicy `json:"eviction_mode,omitempty"`
 }
 
-// CacheEvictionMode is how the cache decides which content to evict
-// to stay under the memory limit.
-type CacheEvictionMode string
+// CacheEvictionPolicy is how the cache decides which content to evict
+// to stay under the limits.
+type CacheEvictionPolicy string
 
-// Cache eviction modes.
+// Cache eviction policies.
 const (
-	EvictLRU CacheEvictionMode = "lru" // Least recently used
-	EvictMRU CacheEvictionMode = "mru" // Most recently used
+	EvictLRU CacheEvictionPolicy = "lru" // Least recently used
+	EvictMRU CacheEvictionPolicy = "mru" // Most recently used
+	EvictLFU CacheEvictionPolicy = "lfu" // Least frequently used
+	EvictARC CacheEvictionPolicy = "arc" // Adaptive replacement cache
 )
 
-func (m CacheEvictionMode) searchParams() (init, comp string) {
-	switch m {
-	case EvictLRU:
-		return "time.Now()", "Before"
-	case EvictMRU:
-		return "time.Time{}", "After"
+// policy returns the eviction policy, falling back to EvictionMode.
+func (c *Cache) policy() CacheEvictionPolicy {
+	p := c.EvictionPolicy
+	if p == "" {
+		p = c.EvictionMode
+	}
+	switch p {
+	case EvictLRU, EvictMRU, EvictLFU, EvictARC:
+		return p
+	case "":
+		return EvictLRU
 	default:
-		panic("unrecognised EvictionMode " + m)
+		panic("unrecognised EvictionPolicy " + p)
 	}
 }
 
@@ -356,22 +553,34 @@ func (c *Cache) Clone() model.Part {
 	return &c0
 }
 
+// Settings returns the settings that can be made configurable.
+func (c *Cache) Settings() []model.Setting {
+	return []model.Setting{
+		{Name: "content_bytes_limit", Type: "uint64", Value: fmt.Sprint(c.ContentBytesLimit), Usage: "Maximum size of the cached content in bytes"},
+		{Name: "max_entries", Type: "int", Value: fmt.Sprint(c.MaxEntries), Usage: "Maximum number of entries, or 0 for no limit"},
+		{Name: "ttl", Type: "time.Duration", Value: fmt.Sprintf("time.Duration(%d)", c.TTL), Usage: "How long entries are kept after being put, or 0 for ever"},
+	}
+}
+
 // Impl returns a cache implementation.
 func (c *Cache) Impl(n *model.Node) model.PartImpl {
 	params := struct {
-		BytesLimit                           uint64
-		KeyType, HitType, InitTime, TimeComp string
-		Mult, Prometheus                     bool
-		NodeName                             string
+		BytesLimitDecl, MaxEntriesDecl, TTLDecl string
+		KeyType, HitType                        string
+		Policy                                  CacheEvictionPolicy
+		Mult, Prometheus                        bool
+		NodeName                                string
 	}{
-		BytesLimit: c.ContentBytesLimit,
-		KeyType:    n.TypeParams[cacheKeyTypeParam].String(),
-		Mult:       n.Multiplicity != "1",
-		NodeName:   n.Name,
-		Prometheus: c.EnablePrometheus,
+		BytesLimitDecl: n.SettingDecl("bytesLimit", "content_bytes_limit"),
+		MaxEntriesDecl: n.SettingDecl("maxEntries", "max_entries"),
+		TTLDecl:        n.SettingDecl("ttl", "ttl"),
+		KeyType:        n.TypeParams[cacheKeyTypeParam].String(),
+		Policy:         c.policy(),
+		Mult:           !n.SingleInstance(),
+		NodeName:       n.Name,
+		Prometheus:     c.EnablePrometheus,
 	}
 	params.HitType = cacheHitType(params.KeyType, n.TypeParams[cacheCtxTypeParam].String())
-	params.InitTime, params.TimeComp = c.EvictionMode.searchParams()
 	h, b := bytes.NewBuffer(nil), bytes.NewBuffer(nil)
 	if err := cacheHeadTmpl.Execute(h, params); err != nil {
 		panic("couldn't execute cache-head template: " + err.Error())
@@ -379,7 +588,7 @@ func (c *Cache) Impl(n *model.Node) model.PartImpl {
 	if err := cacheBodyTmpl.Execute(b, params); err != nil {
 		panic("couldn't execute cache-body template: " + err.Error())
 	}
-	imps := []string{`"time"`}
+	imps := []string{`"container/list"`, `"time"`}
 	if params.Mult {
 		imps = append(imps, `"sync"`)
 	}
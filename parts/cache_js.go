@@ -16,12 +16,18 @@
 
 package parts
 
-import "github.com/google/shenzhen-go/dom"
+import (
+	"time"
+
+	"github.com/google/shenzhen-go/dom"
+)
 
 var (
 	inputCacheContentBytesLimit = doc.ElementByID("cache-contentbyteslimit")
+	inputCacheMaxEntries        = doc.ElementByID("cache-maxentries")
+	inputCacheTTL               = doc.ElementByID("cache-ttl")
 	inputCacheEnablePrometheus  = doc.ElementByID("cache-enableprometheus")
-	selectCacheEvictionMode     = doc.ElementByID("cache-evictionmode")
+	selectCacheEvictionPolicy   = doc.ElementByID("cache-evictionpolicy")
 
 	focusedCache *Cache
 )
@@ -30,17 +36,26 @@ func init() {
 	inputCacheContentBytesLimit.AddEventListener("change", func(dom.Object) {
 		focusedCache.ContentBytesLimit = inputCacheContentBytesLimit.Get("value").Uint64()
 	})
+	inputCacheMaxEntries.AddEventListener("change", func(dom.Object) {
+		focusedCache.MaxEntries = inputCacheMaxEntries.Get("value").Int()
+	})
+	inputCacheTTL.AddEventListener("change", durationChange(func(t time.Duration) {
+		focusedCache.TTL = t
+	}))
 	inputCacheEnablePrometheus.AddEventListener("change", func(dom.Object) {
 		focusedCache.EnablePrometheus = inputCacheEnablePrometheus.Get("checked").Bool()
 	})
-	selectCacheEvictionMode.AddEventListener("change", func(dom.Object) {
-		focusedCache.EvictionMode = CacheEvictionMode(selectCacheEvictionMode.Get("value").String())
+	selectCacheEvictionPolicy.AddEventListener("change", func(dom.Object) {
+		focusedCache.EvictionPolicy = CacheEvictionPolicy(selectCacheEvictionPolicy.Get("value").String())
+		focusedCache.EvictionMode = ""
 	})
 }
 
 func (c *Cache) GainFocus() {
 	focusedCache = c
 	inputCacheContentBytesLimit.Set("value", c.ContentBytesLimit)
+	inputCacheMaxEntries.Set("value", c.MaxEntries)
+	inputCacheTTL.Set("value", c.TTL.String())
 	inputCacheEnablePrometheus.Set("checked", c.EnablePrometheus)
-	selectCacheEvictionMode.Set("value", c.EvictionMode)
+	selectCacheEvictionPolicy.Set("value", c.policy())
 }
@@ -0,0 +1,196 @@
+// Copyright 2018 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package parts
+
+import (
+	"bytes"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/google/shenzhen-go/model"
+	"github.com/google/shenzhen-go/model/pin"
+)
+
+// cacheGraph returns a command graph where a Code node runs the script
+// against a Cache node. The script can call put(key, data), and get(key),
+// which prints "hit key data" or "miss key". Each call is handled before
+// the next, because the cache has one instance.
+func cacheGraph(c *Cache, script string) *model.Graph {
+	const (
+		getType  = "struct{ Key int; Ctx struct{} }"
+		putType  = "struct{ Key int; Data []byte }"
+		hitType  = "struct{ Key int; Ctx struct{}; Data []byte }"
+		missType = getType
+	)
+	g := model.NewGraph("cache.szgo", "", "example.com/cache")
+	g.IsCommand = true
+	g.Nodes["cache"] = &model.Node{
+		Name:         "cache",
+		Enabled:      true,
+		Wait:         true,
+		Multiplicity: "1",
+		Part:         c,
+		Connections:  map[string]string{"get": "get", "put": "put", "hit": "hit", "miss": "miss"},
+	}
+	g.Nodes["driver"] = &model.Node{
+		Name:         "driver",
+		Enabled:      true,
+		Wait:         true,
+		Multiplicity: "1",
+		Part: NewCode([]string{`"fmt"`, `"time"`}, `
+			var _ = time.Sleep
+			put := func(key int, data string) {
+				puts <- `+putType+`{Key: key, Data: []byte(data)}
+			}
+			get := func(key int) {
+				gets <- `+getType+`{Key: key}
+				select {
+				case h := <-hits:
+					fmt.Println("hit", h.Key, string(h.Data))
+				case m := <-misses:
+					fmt.Println("miss", m.Key)
+				}
+			}`,
+			script,
+			"close(gets)\nclose(puts)",
+			pin.NewMap(
+				&pin.Definition{Name: "gets", Direction: pin.Output, Type: getType},
+				&pin.Definition{Name: "puts", Direction: pin.Output, Type: putType},
+				&pin.Definition{Name: "hits", Direction: pin.Input, Type: hitType},
+				&pin.Definition{Name: "misses", Direction: pin.Input, Type: missType},
+			)),
+		Connections: map[string]string{"gets": "get", "puts": "put", "hits": "hit", "misses": "miss"},
+	}
+	for _, c := range []string{"get", "put", "hit", "miss"} {
+		g.Channels[c] = &model.Channel{Name: c}
+	}
+	g.RefreshChannelsPins()
+	return g
+}
+
+func TestCache(t *testing.T) {
+	tests := []struct {
+		name   string
+		cache  *Cache
+		script string
+		want   string
+	}{
+		{
+			name:   "LRU",
+			cache:  &Cache{ContentBytesLimit: 1 << 20, MaxEntries: 2, EvictionPolicy: EvictLRU},
+			script: `put(1, "a"); put(2, "b"); get(1); put(3, "c"); get(2); get(1); get(3)`,
+			want:   "hit 1 a\nmiss 2\nhit 1 a\nhit 3 c\n",
+		},
+		{
+			name:   "MRU",
+			cache:  &Cache{ContentBytesLimit: 1 << 20, MaxEntries: 2, EvictionPolicy: EvictMRU},
+			script: `put(1, "a"); put(2, "b"); get(1); put(3, "c"); get(2); get(1); get(3)`,
+			want:   "hit 1 a\nhit 2 b\nmiss 1\nhit 3 c\n",
+		},
+		{
+			name:   "LFU",
+			cache:  &Cache{ContentBytesLimit: 1 << 20, MaxEntries: 2, EvictionPolicy: EvictLFU},
+			script: `put(1, "a"); put(2, "b"); get(1); get(1); get(2); put(3, "c"); get(2); get(1); get(3)`,
+			want:   "hit 1 a\nhit 1 a\nhit 2 b\nmiss 2\nhit 1 a\nhit 3 c\n",
+		},
+		{
+			// A key used again survives a scan of more keys than fit.
+			name:   "ARC scan",
+			cache:  &Cache{ContentBytesLimit: 1 << 20, MaxEntries: 3, EvictionPolicy: EvictARC},
+			script: `put(1, "a"); get(1); for k := 10; k < 15; k++ { put(k, "s") }; get(1); get(14); get(10)`,
+			want:   "hit 1 a\nhit 1 a\nhit 14 s\nmiss 10\n",
+		},
+		{
+			// Whereas LRU is flushed by the scan.
+			name:   "LRU scan",
+			cache:  &Cache{ContentBytesLimit: 1 << 20, MaxEntries: 3, EvictionPolicy: EvictLRU},
+			script: `put(1, "a"); get(1); for k := 10; k < 15; k++ { put(k, "s") }; get(1); get(14); get(10)`,
+			want:   "hit 1 a\nmiss 1\nhit 14 s\nmiss 10\n",
+		},
+		{
+			name:   "max entries",
+			cache:  &Cache{ContentBytesLimit: 1 << 20, MaxEntries: 3, EvictionPolicy: EvictLRU},
+			script: `for k := 1; k <= 5; k++ { put(k, "x") }; for k := 1; k <= 5; k++ { get(k) }`,
+			want:   "miss 1\nmiss 2\nhit 3 x\nhit 4 x\nhit 5 x\n",
+		},
+		{
+			name:   "bytes limit",
+			cache:  &Cache{ContentBytesLimit: 4, EvictionPolicy: EvictLRU},
+			script: `put(1, "aa"); put(2, "bb"); put(3, "cc"); put(4, "toobig"); get(1); get(2); get(3); get(4)`,
+			want:   "miss 1\nhit 2 bb\nhit 3 cc\nmiss 4\n",
+		},
+		{
+			name:   "TTL",
+			cache:  &Cache{ContentBytesLimit: 1 << 20, TTL: 100 * time.Millisecond, EvictionPolicy: EvictLRU},
+			script: `put(1, "a"); get(1); time.Sleep(200 * time.Millisecond); put(2, "b"); get(1); get(2)`,
+			want:   "hit 1 a\nmiss 1\nhit 2 b\n",
+		},
+	}
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+			if got := runGraph(t, cacheGraph(test.cache, test.script)); got != test.want {
+				t.Errorf("output = %q, want %q", got, test.want)
+			}
+		})
+	}
+}
+
+func TestCacheEvictionPolicies(t *testing.T) {
+	for _, policy := range []CacheEvictionPolicy{EvictLRU, EvictMRU, EvictLFU, EvictARC} {
+		for _, mult := range []string{"1", "4"} {
+			t.Run(string(policy)+"/"+mult, func(t *testing.T) {
+				r, err := os.Open("../examples/cache.szgo")
+				if err != nil {
+					t.Fatalf("Couldn't open example: %v", err)
+				}
+				defer r.Close()
+				g, err := model.LoadJSON(r, "cache.szgo", "")
+				if err != nil {
+					t.Fatalf("Couldn't load example: %v", err)
+				}
+				n := g.Nodes["Cache"]
+				n.Multiplicity = mult
+				c := n.Part.(*Cache)
+				c.EvictionPolicy = policy
+				c.MaxEntries = 3
+
+				var buf bytes.Buffer
+				if err := g.WriteGoTo(&buf); err != nil {
+					t.Fatalf("g.WriteGoTo() = error %v", err)
+				}
+				typeCheck(t, buf.Bytes())
+			})
+		}
+	}
+}
+
+func TestCacheEvictionModeFallback(t *testing.T) {
+	tests := []struct {
+		c    Cache
+		want CacheEvictionPolicy
+	}{
+		{Cache{}, EvictLRU},
+		{Cache{EvictionMode: EvictMRU}, EvictMRU},
+		{Cache{EvictionPolicy: EvictARC, EvictionMode: EvictMRU}, EvictARC},
+	}
+	for _, test := range tests {
+		if got := test.c.policy(); got != test.want {
+			t.Errorf("%+v.policy() = %q, want %q", test.c, got, test.want)
+		}
+	}
+}
@@ -0,0 +1,131 @@
+// Copyright 2018 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package parts
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/google/shenzhen-go/model"
+	"github.com/google/shenzhen-go/model/pin"
+)
+
+var debouncePins = pin.NewMap(
+	&pin.Definition{
+		Name:      "input",
+		Direction: pin.Input,
+		Type:      "$Any",
+	},
+	&pin.Definition{
+		Name:      "output",
+		Direction: pin.Output,
+		Type:      "$Any",
+	},
+)
+
+func init() {
+	model.RegisterPartType("Debounce", "Time", &model.PartType{
+		New: func() model.Part {
+			return &Debounce{Period: 100 * time.Millisecond}
+		},
+		Panels: []model.PartPanel{
+			{
+				Name: "Debounce",
+				Editor: `
+			<div class="form">
+				<div class="formfield">
+					<label for="debounce-period">Quiet period</label>
+					<input id="debounce-period" name="debounce-period" type="text" required title="Must be a parseable time.Duration." value="100ms"></input>
+				</div>
+			</div>`,
+			},
+			{
+				Name: "Help",
+				Editor: `<div>
+			<p>
+				A Debounce part waits for the input to be quiet: once no value has 
+				been received for the quiet period, the last value received is sent 
+				on the output. Earlier values are discarded.
+			</p><p>
+				When the input is closed, the last value is sent if it hasn't been
+				already, and then the output is closed.
+			</p>
+			</div>`,
+			},
+		},
+	})
+}
+
+// Debounce is a part which sends the last value received, once the input
+// has been quiet for a while.
+type Debounce struct {
+	Period time.Duration `json:"period"`
+}
+
+// Clone returns a clone of this Debounce.
+func (d *Debounce) Clone() model.Part {
+	d0 := *d
+	return &d0
+}
+
+// Settings returns the settings that can be made configurable.
+func (d *Debounce) Settings() []model.Setting {
+	return []model.Setting{
+		{Name: "period", Type: "time.Duration", Value: fmt.Sprintf("time.Duration(%d)", d.Period), Usage: "How long the input must be quiet before sending"},
+	}
+}
+
+// Impl returns the Debounce implementation.
+func (d *Debounce) Impl(n *model.Node) model.PartImpl {
+	return model.PartImpl{
+		Imports: []string{`"time"`},
+		Head:    n.SettingDecl("period", "period"),
+		Body: fmt.Sprintf(`var last %s
+		pending := false
+		timer := time.NewTimer(period)
+		timer.Stop()
+		var quiet <-chan time.Time // non-nil while the timer is running
+	debounceLoop:
+		for {
+			select {
+			case in, open := <-input:
+				if !open {
+					break debounceLoop
+				}
+				last, pending = in, true
+				if quiet != nil && !timer.Stop() {
+					<-timer.C
+				}
+				timer.Reset(period)
+				quiet = timer.C
+			case <-quiet:
+				quiet = nil
+				pending = false
+				output <- last
+			}
+		}
+		timer.Stop()
+		if pending {
+			output <- last
+		}`, n.TypeParams["$Any"]),
+		Tail: "close(output)",
+	}
+}
+
+// Pins returns a map declaring an input and an output of the same arbitrary type.
+func (d *Debounce) Pins() pin.Map { return debouncePins }
+
+// TypeKey returns "Debounce".
+func (d *Debounce) TypeKey() string { return "Debounce" }
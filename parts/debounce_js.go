@@ -0,0 +1,36 @@
+// Copyright 2018 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//+build js
+
+package parts
+
+import "time"
+
+var (
+	inputDebouncePeriod = doc.ElementByID("debounce-period")
+
+	focusedDebounce *Debounce
+)
+
+func init() {
+	inputDebouncePeriod.AddEventListener("change", durationChange(func(t time.Duration) {
+		focusedDebounce.Period = t
+	}))
+}
+
+func (d *Debounce) GainFocus() {
+	focusedDebounce = d
+	inputDebouncePeriod.Set("value", d.Period.String())
+}
@@ -0,0 +1,39 @@
+// Copyright 2018 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package parts
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestDebounce(t *testing.T) {
+	for _, mult := range []string{"1", "2"} {
+		typeCheckPipeline(t, &Debounce{Period: time.Millisecond}, "string", mult, "input", "output")
+	}
+}
+
+func TestDebounceRun(t *testing.T) {
+	script := `for i := 0; i < 5; i++ { output <- i }
+	time.Sleep(150 * time.Millisecond)
+	for i := 5; i < 10; i++ { output <- i }
+	time.Sleep(150 * time.Millisecond)
+	output <- 10`
+	got := runPart(t, &Debounce{Period: 50 * time.Millisecond}, "int", map[string]string{"input": script}, "output")
+	if want := []string{"4", "9", "10"}; !reflect.DeepEqual(got["output"], want) {
+		t.Errorf("output = %q, want %q", got["output"], want)
+	}
+}
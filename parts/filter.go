@@ -0,0 +1,123 @@
+// Copyright 2018 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package parts
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/google/shenzhen-go/model"
+	"github.com/google/shenzhen-go/model/pin"
+)
+
+var filterPins = pin.NewMap(
+	&pin.Definition{
+		Name:      "inputs",
+		Direction: pin.Input,
+		Type:      "$T",
+	},
+	&pin.Definition{
+		Name:      "pass",
+		Direction: pin.Output,
+		Type:      "$T",
+	},
+	&pin.Definition{
+		Name:      "reject",
+		Direction: pin.Output,
+		Type:      "$T",
+	},
+)
+
+func init() {
+	model.RegisterPartType("Filter", "General", &model.PartType{
+		New: func() model.Part {
+			return &Filter{
+				Predicate: []string{"true"},
+			}
+		},
+		Panels: []model.PartPanel{
+			{
+				Name:   "Imports",
+				Editor: `<div class="codeedit" id="filter-imports"></div>`,
+			},
+			{
+				Name: "Predicate",
+				Editor: `<div class="formfield">
+					<span class="link" id="filter-format-link">Format</span>
+				</div>
+				<div class="codeedit formfield" id="filter-predicate"></div>`,
+			},
+			{
+				Name: "Help",
+				Editor: `<div>
+			<p>
+				A Filter part sends the inputs that match a condition to pass, and 
+				the others to reject. The condition is BYO code.
+			</p><p>
+				The predicate must be a boolean expression, which can use the 
+				input value (available as a value called <code>input</code>). 
+				The reject output is optional: if it isn't connected, inputs that 
+				don't match are discarded.
+			</p>
+			</div>`,
+			},
+		},
+	})
+}
+
+// Filter is a part which forwards the inputs for which a predicate is true.
+type Filter struct {
+	Imports   []string `json:"imports"`
+	Predicate []string `json:"predicate"`
+}
+
+// Clone returns a clone of this Filter.
+func (f *Filter) Clone() model.Part {
+	return &Filter{
+		Imports:   append([]string(nil), f.Imports...),
+		Predicate: append([]string(nil), f.Predicate...),
+	}
+}
+
+// Impl returns the Filter implementation.
+func (f *Filter) Impl(n *model.Node) model.PartImpl {
+	// We know at design time whether a pin is nil.
+	pass, reject := "", ""
+	var tail []string
+	if n.Connections["pass"] != "nil" {
+		pass = "pass <- input"
+		tail = append(tail, "close(pass)")
+	}
+	if n.Connections["reject"] != "nil" {
+		reject = " else {\n\treject <- input\n}"
+		tail = append(tail, "close(reject)")
+	}
+	return model.PartImpl{
+		Imports: f.Imports,
+		Body: fmt.Sprintf(`for input := range inputs {
+			if (%s) {
+				%s
+			}%s
+		}`, strings.Join(f.Predicate, "\n"), pass, reject),
+		Tail: strings.Join(tail, "\n"),
+	}
+}
+
+// Pins returns a map declaring a single input and two outputs of the same
+// arbitrary type.
+func (f *Filter) Pins() pin.Map { return filterPins }
+
+// TypeKey returns "Filter".
+func (f *Filter) TypeKey() string { return "Filter" }
@@ -0,0 +1,53 @@
+// Copyright 2018 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//+build js
+
+package parts
+
+import (
+	"strings"
+
+	"github.com/google/shenzhen-go/dom"
+)
+
+var (
+	filterImportsSession, filterPredicateSession *dom.AceSession
+
+	linkFilterFormat = doc.ElementByID("filter-format-link")
+
+	focusedFilter *Filter
+)
+
+// Needed to resolve initialization cycle. handleFoo uses the value loaded here.
+func init() {
+	filterImportsSession = setupAce("filter-imports", dom.AceGoMode, filterImportsChange)
+	filterPredicateSession = setupAce("filter-predicate", dom.AceGoMode, filterPredicateChange)
+
+	linkFilterFormat.AddEventListener("click", formatHandler(filterPredicateSession))
+}
+
+func filterImportsChange(dom.Object) {
+	focusedFilter.Imports = stripCR(strings.Split(filterImportsSession.Value(), "\n"))
+}
+
+func filterPredicateChange(dom.Object) {
+	focusedFilter.Predicate = stripCR(strings.Split(filterPredicateSession.Value(), "\n"))
+}
+
+func (f *Filter) GainFocus() {
+	focusedFilter = f
+	filterImportsSession.SetValue(strings.Join(f.Imports, "\n"))
+	filterPredicateSession.SetValue(strings.Join(f.Predicate, "\n"))
+}
@@ -0,0 +1,64 @@
+// Copyright 2018 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package parts
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+)
+
+func TestFilter(t *testing.T) {
+	f := &Filter{
+		Imports:   []string{`"strings"`},
+		Predicate: []string{`strings.HasPrefix(input, "a") ||`, `input == ""`},
+	}
+	for _, mult := range []string{"1", "2"} {
+		typeCheckPipeline(t, f, "string", mult, "inputs", "pass")
+		typeCheckPipeline(t, f, "string", mult, "inputs", "pass", "reject")
+		typeCheckPipeline(t, f, "string", mult, "inputs", "reject")
+	}
+}
+
+func TestFilterRun(t *testing.T) {
+	f := &Filter{Predicate: []string{"input%2 == 0"}}
+	sources := map[string]string{"inputs": `for i := 0; i < 6; i++ { output <- i }`}
+	tests := []struct {
+		outputs []string
+		want    map[string][]string
+	}{
+		{
+			outputs: []string{"pass", "reject"},
+			want:    map[string][]string{"pass": {"0", "2", "4"}, "reject": {"1", "3", "5"}},
+		},
+		{
+			outputs: []string{"pass"},
+			want:    map[string][]string{"pass": {"0", "2", "4"}},
+		},
+		{
+			outputs: []string{"reject"},
+			want:    map[string][]string{"reject": {"1", "3", "5"}},
+		},
+	}
+	for _, test := range tests {
+		test := test
+		t.Run(fmt.Sprint(test.outputs), func(t *testing.T) {
+			t.Parallel()
+			if got := runPart(t, f, "int", sources, test.outputs...); !reflect.DeepEqual(got, test.want) {
+				t.Errorf("outputs = %q, want %q", got, test.want)
+			}
+		})
+	}
+}
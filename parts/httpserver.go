@@ -110,8 +110,20 @@ type HTTPServer struct {
 // Clone returns a clone of this HTTPServer.
 func (s *HTTPServer) Clone() model.Part { s0 := *s; return &s0 }
 
+// Settings returns the settings that can be made configurable.
+func (s *HTTPServer) Settings() []model.Setting {
+	dur := func(d time.Duration) string { return fmt.Sprintf("time.Duration(%d)", d) }
+	return []model.Setting{
+		{Name: "read_timeout", Type: "time.Duration", Value: dur(s.ReadTimeout), Usage: "Read timeout"},
+		{Name: "read_header_timeout", Type: "time.Duration", Value: dur(s.ReadHeaderTimeout), Usage: "Read header timeout"},
+		{Name: "write_timeout", Type: "time.Duration", Value: dur(s.WriteTimeout), Usage: "Write timeout"},
+		{Name: "idle_timeout", Type: "time.Duration", Value: dur(s.IdleTimeout), Usage: "Idle timeout"},
+		{Name: "max_header_bytes", Type: "int", Value: fmt.Sprint(s.MaxHeaderBytes), Usage: "Maximum size of request headers in bytes"},
+	}
+}
+
 // Impl returns the HTTPServer implementation.
-func (s *HTTPServer) Impl(*model.Node) model.PartImpl {
+func (s *HTTPServer) Impl(n *model.Node) model.PartImpl {
 	b := bytes.NewBuffer(nil)
 	b.WriteString(`
 	for mgr := range manager {
@@ -119,19 +131,26 @@ func (s *HTTPServer) Impl(*model.Node) model.PartImpl {
 			Handler: parts.HTTPHandler(requests),
 			Addr:    mgr.Addr(),
 		`)
-	if s.ReadTimeout != 0 {
-		fmt.Fprintf(b, "ReadTimeout: %d, // %v\n", s.ReadTimeout, s.ReadTimeout)
-	}
-	if s.ReadHeaderTimeout != 0 {
-		fmt.Fprintf(b, "ReadHeaderTimeout: %d, // %v\n", s.ReadHeaderTimeout, s.ReadHeaderTimeout)
-	}
-	if s.WriteTimeout != 0 {
-		fmt.Fprintf(b, "WriteTimeout: %d, // %v\n", s.WriteTimeout, s.WriteTimeout)
-	}
-	if s.IdleTimeout != 0 {
-		fmt.Fprintf(b, "IdleTimeout: %d, // %v\n", s.IdleTimeout, s.IdleTimeout)
+	for _, f := range []struct {
+		field, setting string
+		d              time.Duration
+	}{
+		{"ReadTimeout", "read_timeout", s.ReadTimeout},
+		{"ReadHeaderTimeout", "read_header_timeout", s.ReadHeaderTimeout},
+		{"WriteTimeout", "write_timeout", s.WriteTimeout},
+		{"IdleTimeout", "idle_timeout", s.IdleTimeout},
+	} {
+		switch {
+		case n.Configures(f.setting):
+			fmt.Fprintf(b, "%s: %s,\n", f.field, n.SettingVar(f.setting))
+		case f.d != 0:
+			fmt.Fprintf(b, "%s: %d, // %v\n", f.field, f.d, f.d)
+		}
 	}
-	if s.MaxHeaderBytes != 0 {
+	switch {
+	case n.Configures("max_header_bytes"):
+		fmt.Fprintf(b, "MaxHeaderBytes: %s,\n", n.SettingVar("max_header_bytes"))
+	case s.MaxHeaderBytes != 0:
 		fmt.Fprintf(b, "MaxHeaderBytes: %d,\n", s.MaxHeaderBytes)
 	}
 	b.WriteString(`}
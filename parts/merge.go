@@ -0,0 +1,218 @@
+// Copyright 2018 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package parts
+
+import (
+	"bytes"
+	"fmt"
+	"text/template"
+
+	"github.com/google/shenzhen-go/model"
+	"github.com/google/shenzhen-go/model/pin"
+)
+
+var mergeBodyTmpl = template.Must(template.New("merge-body").Parse(`
+	{{- define "recv"}}
+		if !open {
+			{{.Name}} = nil
+			break
+		}
+		output <- in
+		{{- if .RoundRobin}}
+		next = {{.Next}}
+		{{- end}}
+	{{- end}}
+	{{- if eq .Mode "fair"}}
+	for {{range $i, $in := .Inputs}}{{if $i}} || {{end}}{{$in.Name}} != nil{{end}} {
+		select {
+		{{- range .Inputs}}
+		case in, open := <-{{.Name}}:
+			{{- template "recv" .}}
+		{{- end}}
+		}
+	}
+	{{- else}}
+	{{if eq .Mode "round_robin"}}next := 0{{else}}const next = 0 // input0 has the highest priority{{end}}
+	for {{range $i, $in := .Inputs}}{{if $i}} || {{end}}{{$in.Name}} != nil{{end}} {
+		// Receive from the first input that is ready, trying them in turn
+		// starting with next.
+		ready := false
+		for k := 0; k < {{len .Inputs}} && !ready; k++ {
+			switch (next + k) % {{len .Inputs}} {
+			{{- range $i, $in := .Inputs}}
+			case {{$i}}:
+				select {
+				case in, open := <-{{.Name}}:
+					ready = true
+					{{- template "recv" .}}
+				default:
+				}
+			{{- end}}
+			}
+		}
+		if ready {
+			continue
+		}
+		// None are ready, so wait for any of them.
+		select {
+		{{- range .Inputs}}
+		case in, open := <-{{.Name}}:
+			{{- template "recv" .}}
+		{{- end}}
+		}
+	}
+	{{- end}}`))
+
+func init() {
+	model.RegisterPartType("Merge", "Flow", &model.PartType{
+		New: func() model.Part {
+			return &Merge{
+				InputNum: 2,
+				Mode:     MergeModePriority,
+			}
+		},
+		Panels: []model.PartPanel{
+			{
+				Name: "Merge",
+				Editor: `<div class="form">
+				<div class="formfield">
+					<label for="merge-inputnum">Number of inputs</label>
+					<input id="merge-inputnum" name="merge-inputnum" type="number" required title="Must be a whole number." value="2"></input>
+				</div>
+				<div class="formfield">
+					<label for="merge-mode">Mode</label>
+					<select id="merge-mode" name="merge-mode">
+						<option value="fair">Fair</option>
+						<option value="priority" selected>Priority</option>
+						<option value="round_robin">Round-robin</option>
+					</select>
+				</div>
+			</div>`,
+			},
+			{
+				Name: "Help",
+				Editor: `<div>
+			<p>
+				A Merge part sends every value received from every input to the output.
+				When all the inputs are closed, the output is closed. Inputs that are 
+				not connected are ignored. The number of inputs is configurable.
+			</p><p>
+				The mode decides which input to receive from when more than one has 
+				a value ready:
+			</p><ul>
+				<li>Fair chooses at random, like Gather.</li>
+				<li>Priority always chooses the lowest-numbered input, so input0 is 
+				drained before input1, and so on.</li>
+				<li>Round-robin takes turns: after receiving from an input, the next 
+				input has the highest priority.</li>
+			</ul>
+			</div>`,
+			},
+		},
+	})
+}
+
+// MergeMode describes how to choose between inputs that are ready.
+type MergeMode string
+
+// Valid values of MergeMode.
+const (
+	MergeModeFair       MergeMode = "fair"
+	MergeModePriority   MergeMode = "priority"
+	MergeModeRoundRobin MergeMode = "round_robin"
+)
+
+// Merge is a part type which reads a configurable number of inputs and sends
+// values to a single output, choosing between inputs according to the mode.
+type Merge struct {
+	InputNum uint      `json:"input_num"`
+	Mode     MergeMode `json:"mode"`
+}
+
+// Clone returns a clone of this Merge.
+func (m *Merge) Clone() model.Part {
+	m0 := *m
+	return &m0
+}
+
+// mergeInput is a connected input of a Merge node.
+type mergeInput struct {
+	Name       string
+	Next       int // index of the connected input after this one
+	RoundRobin bool
+}
+
+// Impl returns the Merge implementation.
+func (m *Merge) Impl(n *model.Node) model.PartImpl {
+	switch m.Mode {
+	case MergeModeFair, MergeModePriority, MergeModeRoundRobin:
+	default:
+		panic("unknown mode " + m.Mode)
+	}
+	var ins []*mergeInput
+	for i := uint(0); i < m.InputNum; i++ {
+		name := fmt.Sprintf("input%d", i)
+		if n.Connections[name] == "nil" {
+			// We know at design time whether a pin is nil.
+			continue
+		}
+		ins = append(ins, &mergeInput{
+			Name:       name,
+			RoundRobin: m.Mode == MergeModeRoundRobin,
+		})
+	}
+	if len(ins) == 0 {
+		return model.PartImpl{Tail: "close(output)"}
+	}
+	for i, in := range ins {
+		in.Next = (i + 1) % len(ins)
+	}
+	params := struct {
+		Mode   MergeMode
+		Inputs []*mergeInput
+	}{
+		Mode:   m.Mode,
+		Inputs: ins,
+	}
+	b := bytes.NewBuffer(nil)
+	if err := mergeBodyTmpl.Execute(b, params); err != nil {
+		panic("couldn't execute merge-body template: " + err.Error())
+	}
+	return model.PartImpl{
+		Body: b.String(),
+		Tail: "close(output)",
+	}
+}
+
+// Pins returns a map with N inputs and 1 output.
+func (m *Merge) Pins() pin.Map {
+	p := pin.NewMap(&pin.Definition{
+		Name:      "output",
+		Direction: pin.Output,
+		Type:      "$Any",
+	})
+	for i := uint(0); i < m.InputNum; i++ {
+		n := fmt.Sprintf("input%d", i)
+		p[n] = &pin.Definition{
+			Name:      n,
+			Direction: pin.Input,
+			Type:      "$Any",
+		}
+	}
+	return p
+}
+
+// TypeKey returns "Merge".
+func (m *Merge) TypeKey() string { return "Merge" }
@@ -0,0 +1,41 @@
+// Copyright 2018 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//+build js
+
+package parts
+
+import "github.com/google/shenzhen-go/dom"
+
+var (
+	inputMergeInputNum = doc.ElementByID("merge-inputnum")
+	selectMergeMode    = doc.ElementByID("merge-mode")
+
+	focusedMerge *Merge
+)
+
+func init() {
+	inputMergeInputNum.AddEventListener("change", func(dom.Object) {
+		focusedMerge.InputNum = uint(inputMergeInputNum.Get("value").Int())
+	})
+	selectMergeMode.AddEventListener("change", func(dom.Object) {
+		focusedMerge.Mode = MergeMode(selectMergeMode.Get("value").String())
+	})
+}
+
+func (m *Merge) GainFocus() {
+	focusedMerge = m
+	inputMergeInputNum.Set("value", m.InputNum)
+	selectMergeMode.Set("value", m.Mode)
+}
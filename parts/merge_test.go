@@ -0,0 +1,89 @@
+// Copyright 2018 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package parts
+
+import (
+	"bytes"
+	"reflect"
+	"testing"
+)
+
+func TestMerge(t *testing.T) {
+	for _, mode := range []MergeMode{MergeModeFair, MergeModePriority, MergeModeRoundRobin} {
+		t.Run(string(mode), func(t *testing.T) {
+			// With input1 unconnected.
+			typeCheckPipeline(t, &Merge{InputNum: 3, Mode: mode}, "string", "1", "input0", "output")
+
+			// With input0 and input2 connected to the same channel.
+			g := pipeline(&Merge{InputNum: 3, Mode: mode}, "string", "2", "input0", "output")
+			g.Nodes["part"].Connections["input2"] = "input0"
+			g.RefreshChannelsPins()
+			var buf bytes.Buffer
+			if err := g.WriteGoTo(&buf); err != nil {
+				t.Fatalf("g.WriteGoTo() = error %v", err)
+			}
+			typeCheck(t, buf.Bytes())
+		})
+	}
+}
+
+func TestMergeRun(t *testing.T) {
+	tests := []struct {
+		mode MergeMode
+		// The first value received is whichever source got going first, which
+		// decides the order of the rest.
+		want [][]string
+	}{
+		{
+			mode: MergeModePriority,
+			want: [][]string{
+				{"0", "1", "2", "3", "10", "11", "12", "13"},
+				{"10", "0", "1", "2", "3", "11", "12", "13"},
+			},
+		},
+		{
+			mode: MergeModeRoundRobin,
+			want: [][]string{
+				{"0", "10", "1", "11", "2", "12", "3", "13"},
+				{"10", "0", "11", "1", "12", "2", "13", "3"},
+			},
+		},
+	}
+	for _, test := range tests {
+		test := test
+		t.Run(string(test.mode), func(t *testing.T) {
+			t.Parallel()
+			g := partGraph(&Merge{InputNum: 2, Mode: test.mode}, "int", map[string]string{
+				"input0": `for i := 0; i < 4; i++ { output <- i }`,
+				"input1": `for i := 10; i < 14; i++ { output <- i }`,
+			}, "output")
+			// Buffer the inputs, and start the sink late, so that both inputs
+			// are ready whenever the part chooses after the first value.
+			g.Channels["input0"].Capacity = 4
+			g.Channels["input1"].Capacity = 4
+			sink := g.Nodes["sink_output"].Part.(*Code)
+			sink.Imports = append(sink.Imports, `"time"`)
+			sink.Head = []string{"time.Sleep(100 * time.Millisecond)"}
+
+			got := runPartGraph(t, g)["output"]
+			for _, want := range test.want {
+				if reflect.DeepEqual(got, want) {
+					return
+				}
+			}
+			t.Errorf("output = %q, want one of %q", got, test.want)
+		})
+	}
+}
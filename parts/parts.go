@@ -15,7 +15,12 @@
 // Package parts contains various pre-made bits and pieces to combine into the graph.
 package parts
 
-import "strings"
+import (
+	"fmt"
+	"strings"
+
+	"github.com/google/shenzhen-go/model"
+)
 
 func stripCR(in []string) []string {
 	for i := range in {
@@ -24,6 +29,23 @@ func stripCR(in []string) []string {
 	return in
 }
 
+// settingCheck returns code for the head of the node, after the setting is
+// declared as local, that stops the program if bad (a condition on local)
+// holds. want describes the values that are allowed. The editor rejects bad
+// values, but flags and hand edits don't, so the check is only left out when
+// the setting isn't configurable and its value is ok. The code needs the
+// returned imports.
+func settingCheck(n *model.Node, setting, local string, ok bool, bad, want string) (code string, imports []string) {
+	if ok && !n.Configures(setting) {
+		return "", nil
+	}
+	format := n.Part.TypeKey() + " %q: " + setting + " %v must be " + want
+	return fmt.Sprintf(`
+	if %s {
+		log.Fatalf(%q, %q, %s)
+	}`, bad, format, n.Name, local), []string{`"log"`}
+}
+
 /*
 Clone() model.Part
 Impl(n *model.Node) model.PartImpl
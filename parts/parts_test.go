@@ -0,0 +1,341 @@
+// Copyright 2018 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package parts
+
+import (
+	"bytes"
+	"fmt"
+	"go/ast"
+	"go/importer"
+	"go/parser"
+	"go/token"
+	"go/types"
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/shenzhen-go/model"
+	"github.com/google/shenzhen-go/model/pin"
+)
+
+// runGraph generates the command graph, runs it, and returns what it wrote
+// to stdout. The generated code must only use the standard library. Running
+// needs the go tool, so it is skipped in short mode.
+func runGraph(t *testing.T, g *model.Graph) string {
+	t.Helper()
+	stdout, stderr, err := runGraphArgs(t, g)
+	if err != nil {
+		t.Fatalf("Generated code failed: %v\n%s", err, stderr)
+	}
+	return stdout
+}
+
+// runGraphArgs builds the command graph like runGraph, and runs it with the
+// args. It returns what the command wrote to stdout and stderr, and the error
+// from running it.
+func runGraphArgs(t *testing.T, g *model.Graph, args ...string) (stdout, stderr string, err error) {
+	t.Helper()
+	if testing.Short() {
+		t.Skip("Skipping running generated code in short mode")
+	}
+	var src bytes.Buffer
+	if err := g.WriteGoTo(&src); err != nil {
+		t.Fatalf("g.WriteGoTo() = error %v", err)
+	}
+	dir, err := ioutil.TempDir("", "parts_test")
+	if err != nil {
+		t.Fatalf("ioutil.TempDir() = error %v", err)
+	}
+	defer os.RemoveAll(dir)
+	files := map[string][]byte{
+		"go.mod":  []byte("module example.com/generated\n"),
+		"main.go": src.Bytes(),
+	}
+	for name, contents := range files {
+		if err := ioutil.WriteFile(filepath.Join(dir, name), contents, 0644); err != nil {
+			t.Fatalf("ioutil.WriteFile(%s) = error %v", name, err)
+		}
+	}
+	build := exec.Command("go", "build", "-o", "generated")
+	build.Dir = dir
+	// The generated command is a module of its own, so flags meant for
+	// this module don't apply.
+	build.Env = append(os.Environ(), "GOFLAGS=", "GO111MODULE=on")
+	if out, err := build.CombinedOutput(); err != nil {
+		t.Fatalf("Couldn't build generated code: %v\n%s\n%s", err, out, src.Bytes())
+	}
+	var outBuf, errBuf bytes.Buffer
+	cmd := exec.Command(filepath.Join(dir, "generated"), args...)
+	cmd.Stdout, cmd.Stderr = &outBuf, &errBuf
+	err = cmd.Run()
+	return outBuf.String(), errBuf.String(), err
+}
+
+// The source importer caches packages, so it is shared between tests.
+var (
+	typeCheckFset = token.NewFileSet()
+	typeCheckConf = types.Config{Importer: importer.ForCompiler(typeCheckFset, "source", nil)}
+)
+
+// typeCheck parses and type-checks generated source.
+func typeCheck(t *testing.T, src []byte) {
+	t.Helper()
+	f, err := parser.ParseFile(typeCheckFset, "generated.go", src, 0)
+	if err != nil {
+		t.Fatalf("Couldn't parse generated code: %v\n%s", err, src)
+	}
+	if _, err := typeCheckConf.Check("main", typeCheckFset, []*ast.File{f}, nil); err != nil {
+		t.Fatalf("Generated code doesn't type-check: %v\n%s", err, src)
+	}
+}
+
+// pipeline returns a command graph where a Code node named "source" sends
+// values of type in to the input pin of the node "part", and each of the
+// output pins is received from by a Code node named "sink_" followed by the
+// pin name. Other pins of the part are left unconnected.
+func pipeline(p model.Part, in, mult, input string, outputs ...string) *model.Graph {
+	g := model.NewGraph("pipeline.szgo", "", "example.com/pipeline")
+	g.IsCommand = true
+	g.Nodes["source"] = &model.Node{
+		Name:         "source",
+		Enabled:      true,
+		Wait:         true,
+		Multiplicity: "1",
+		Part: NewCode(nil, "", "var x "+in+"\nfor i := 0; i < 10; i++ {\n\toutput <- x\n}", "close(output)", pin.NewMap(
+			&pin.Definition{Name: "output", Direction: pin.Output, Type: in},
+		)),
+		Connections: map[string]string{"output": input},
+	}
+	g.Channels[input] = &model.Channel{Name: input}
+	conns := make(map[string]string)
+	for pn := range p.Pins() {
+		conns[pn] = "nil"
+	}
+	conns[input] = input
+	for _, out := range outputs {
+		conns[out] = out
+		g.Channels[out] = &model.Channel{Name: out}
+		g.Nodes["sink_"+out] = &model.Node{
+			Name:         "sink_" + out,
+			Enabled:      true,
+			Wait:         true,
+			Multiplicity: "1",
+			Part: NewCode(nil, "", "for range input {\n}", "", pin.NewMap(
+				&pin.Definition{Name: "input", Direction: pin.Input, Type: "$T"},
+			)),
+			Connections: map[string]string{"input": out},
+		}
+	}
+	g.Nodes["part"] = &model.Node{
+		Name:         "part",
+		Enabled:      true,
+		Wait:         true,
+		Multiplicity: mult,
+		Part:         p,
+		Connections:  conns,
+	}
+	g.RefreshChannelsPins()
+	return g
+}
+
+// typeCheckPipeline generates the pipeline graph for the part and
+// type-checks it.
+func typeCheckPipeline(t *testing.T, p model.Part, in, mult, input string, outputs ...string) {
+	t.Helper()
+	g := pipeline(p, in, mult, input, outputs...)
+	var buf bytes.Buffer
+	if err := g.WriteGoTo(&buf); err != nil {
+		t.Fatalf("g.WriteGoTo() = error %v", err)
+	}
+	typeCheck(t, buf.Bytes())
+}
+
+// runPart runs partGraph(p, in, sources, outputs...) with runPartGraph.
+func runPart(t *testing.T, p model.Part, in string, sources map[string]string, outputs ...string) map[string][]string {
+	t.Helper()
+	return runPartGraph(t, partGraph(p, in, sources, outputs...))
+}
+
+// partGraph makes a command graph around the part. Each input pin in sources
+// is connected to a Code node that runs the script, which sends values of
+// type in on the channel output (and may use time); output is closed after.
+// Each of the outputs is connected to a node that prints the values sent.
+// Other pins of the part are left unconnected.
+func partGraph(p model.Part, in string, sources map[string]string, outputs ...string) *model.Graph {
+	g := model.NewGraph("part.szgo", "", "example.com/part")
+	g.IsCommand = true
+	conns := make(map[string]string)
+	for pn := range p.Pins() {
+		conns[pn] = "nil"
+	}
+	for pn, script := range sources {
+		conns[pn] = pn
+		g.Channels[pn] = &model.Channel{Name: pn}
+		g.Nodes["source_"+pn] = &model.Node{
+			Name:         "source_" + pn,
+			Enabled:      true,
+			Wait:         true,
+			Multiplicity: "1",
+			Part: NewCode([]string{`"time"`}, "var _ = time.Sleep", script, "close(output)", pin.NewMap(
+				&pin.Definition{Name: "output", Direction: pin.Output, Type: in},
+			)),
+			Connections: map[string]string{"output": pn},
+		}
+	}
+	for _, pn := range outputs {
+		conns[pn] = pn
+		g.Channels[pn] = &model.Channel{Name: pn}
+		g.Nodes["sink_"+pn] = &model.Node{
+			Name:         "sink_" + pn,
+			Enabled:      true,
+			Wait:         true,
+			Multiplicity: "1",
+			Part: NewCode([]string{`"fmt"`}, "", fmt.Sprintf("for x := range input {\n\tfmt.Printf(\"%%s\\t%%v\\n\", %q, x)\n}", pn), "", pin.NewMap(
+				&pin.Definition{Name: "input", Direction: pin.Input, Type: "$T"},
+			)),
+			Connections: map[string]string{"input": pn},
+		}
+	}
+	g.Nodes["part"] = &model.Node{
+		Name:         "part",
+		Enabled:      true,
+		Wait:         true,
+		Multiplicity: "1",
+		Part:         p,
+		Connections:  conns,
+	}
+	g.RefreshChannelsPins()
+	return g
+}
+
+// runPartGraph runs a graph made by partGraph, and returns the values sent
+// on each of the outputs, formatted with fmt.Print.
+func runPartGraph(t *testing.T, g *model.Graph) map[string][]string {
+	t.Helper()
+	got := make(map[string][]string)
+	for _, line := range strings.Split(strings.TrimSuffix(runGraph(t, g), "\n"), "\n") {
+		if line == "" {
+			continue
+		}
+		f := strings.SplitN(line, "\t", 2)
+		if len(f) != 2 {
+			t.Fatalf("Unexpected output line %q", line)
+		}
+		got[f[0]] = append(got[f[0]], f[1])
+	}
+	return got
+}
+
+func TestConfigurableMultiplicity(t *testing.T) {
+	g := model.NewGraph("mult.szgo", "", "example.com/mult")
+	g.IsCommand = true
+	g.Nodes["part"] = &model.Node{
+		Name:         "part",
+		Enabled:      true,
+		Wait:         true,
+		Multiplicity: "2",
+		Configurable: []string{model.MultiplicitySetting},
+		Part:         NewCode([]string{`"fmt"`}, "", `fmt.Println("hi")`, "", nil),
+	}
+	for _, test := range []struct {
+		flag, stdout, stderr string
+		fails                bool
+	}{
+		{flag: "-part.multiplicity=3", stdout: "hi\nhi\nhi\n"},
+		{flag: "-part.multiplicity=0", stderr: `Node "part": multiplicity 0 must be at least 1`, fails: true},
+		{flag: "-part.multiplicity=-1", stderr: `Node "part": multiplicity -1 must be at least 1`, fails: true},
+	} {
+		stdout, stderr, err := runGraphArgs(t, g, test.flag)
+		if fails := err != nil; fails != test.fails {
+			t.Errorf("running with %s: error = %v, want error %t\n%s", test.flag, err, test.fails, stderr)
+		}
+		if stdout != test.stdout {
+			t.Errorf("running with %s: stdout = %q, want %q", test.flag, stdout, test.stdout)
+		}
+		if !strings.Contains(stderr, test.stderr) {
+			t.Errorf("running with %s: stderr = %q, want it to contain %q", test.flag, stderr, test.stderr)
+		}
+	}
+}
+
+func TestSettingChecks(t *testing.T) {
+	tests := []struct {
+		part       model.Part
+		in, source string
+		setting    string
+		flag       string
+		want       string
+	}{
+		{
+			part:    &Batch{MaxSize: 3},
+			in:      "int",
+			source:  "input",
+			setting: "max_size",
+			flag:    "-part.max_size=0",
+			want:    `Batch "part": max_size 0 must be at least 1`,
+		},
+		{
+			part:    &RateLimit{Rate: 10, Burst: 1},
+			in:      "int",
+			source:  "input",
+			setting: "rate",
+			flag:    "-part.rate=-1",
+			want:    `RateLimit "part": rate -1 must be greater than 0`,
+		},
+		{
+			part:    &RateLimit{Rate: 10, Burst: 1},
+			in:      "int",
+			source:  "input",
+			setting: "burst",
+			flag:    "-part.burst=0",
+			want:    `RateLimit "part": burst 0 must be at least 1`,
+		},
+		{
+			part:    &Ticker{Interval: time.Second},
+			in:      "struct{}",
+			source:  "stop",
+			setting: "interval",
+			flag:    "-part.interval=0s",
+			want:    `Ticker "part": interval 0s must be greater than 0`,
+		},
+		{
+			part:    &Sample{Interval: time.Second},
+			in:      "int",
+			source:  "input",
+			setting: "interval",
+			flag:    "-part.interval=-1s",
+			want:    `Sample "part": interval -1s must be greater than 0`,
+		},
+	}
+	for _, test := range tests {
+		test := test
+		t.Run(test.part.TypeKey()+test.flag, func(t *testing.T) {
+			t.Parallel()
+			g := partGraph(test.part, test.in, map[string]string{test.source: ""})
+			g.Nodes["part"].Configurable = []string{test.setting}
+			_, stderr, err := runGraphArgs(t, g, test.flag)
+			if err == nil {
+				t.Errorf("running with %s succeeded, want it to fail", test.flag)
+			}
+			if !strings.Contains(stderr, test.want) {
+				t.Errorf("running with %s: stderr = %q, want it to contain %q", test.flag, stderr, test.want)
+			}
+		})
+	}
+}
@@ -49,6 +49,24 @@ func init() {
 				MaxItems: 1000,
 			}
 		},
+		Init: `
+		// queueHeap adapts the queue of a Queue node in priority mode to
+		// heap.Interface. Items are added by appending them to the queue and
+		// calling heap.Fix, and are read from the queue before heap.Pop or
+		// heap.Remove, so Push is never used and Pop only shortens the queue.
+		type queueHeap struct {
+			len      func() int
+			less     func(i, j int) bool
+			swap     func(i, j int)
+			truncate func()
+		}
+
+		func (h queueHeap) Len() int           { return h.len() }
+		func (h queueHeap) Less(i, j int) bool { return h.less(i, j) }
+		func (h queueHeap) Swap(i, j int)      { h.swap(i, j) }
+		func (h queueHeap) Push(interface{})   { panic("queueHeap.Push is not supported") }
+		func (h queueHeap) Pop() interface{}   { h.truncate(); return nil }
+		`,
 		Panels: []model.PartPanel{
 			{
 				Name: "Queue",
@@ -63,8 +81,13 @@ func init() {
 					<select id="queue-mode" name="queue-mode">
 						<option value="lifo" selected>LIFO (stack)</option>
 						<option value="fifo">FIFO (queue)</option>
+						<option value="priority">Priority</option>
 					</select>
 				</div>
+				<div class="formfield">
+					<label for="queue-less">Lower priority</label>
+					<input id="queue-less" name="queue-less" type="text" title="In priority mode, a Go expression that is true if a has a lower priority than b." placeholder="a.Priority &lt; b.Priority"></input>
+				</div>
 			</div>`,
 			},
 			{
@@ -80,10 +103,17 @@ func init() {
 			</p><p>
 				Using a LIFO
 				queue can have higher goodput than a FIFO queue.
+			</p><p>
+				In priority mode, the highest-priority item is sent first. Priority is 
+				given by the lower priority expression, which should be true if the
+				item a has a lower priority than the item b. If it is empty, the items
+				must be structs with a Priority field, and items with a greater
+				Priority have a higher priority.
 			</p><p>
 				Queues have a required maximum number of items. If reading an item 
 				puts the queue over	the limit, the least recently read item is dropped
-				from the queue, rather than waiting for the queue to lower. 
+				from the queue, rather than waiting for the queue to lower. In priority
+				mode, the lowest-priority item is dropped instead.
 				Dropped items are sent to the drop output, but unlike the main output,
 				the queue will not block on sending to drop.
 				A queue may temporarily use more memory than the limit.
@@ -99,14 +129,23 @@ type QueueMode string
 
 // Valid values of QueueMode.
 const (
-	QueueModeFIFO QueueMode = "fifo"
-	QueueModeLIFO QueueMode = "lifo"
+	QueueModeFIFO     QueueMode = "fifo"
+	QueueModeLIFO     QueueMode = "lifo"
+	QueueModePriority QueueMode = "priority"
 )
 
+// DefaultQueueLess is the lower priority expression used if Queue.Less is
+// empty.
+const DefaultQueueLess = "a.Priority < b.Priority"
+
 // Queue is a basic queue part.
 type Queue struct {
 	Mode     QueueMode `json:"mode"`
 	MaxItems int       `json:"max_items"`
+
+	// Less is used in priority mode. It is a boolean expression that is
+	// true if the item a has a lower priority than the item b.
+	Less string `json:"less,omitempty"`
 }
 
 // Clone returns a clone of this Queue.
@@ -126,11 +165,21 @@ func (m QueueMode) params() (index, trim string) {
 	}
 }
 
+// Settings returns the settings that can be made configurable.
+func (q *Queue) Settings() []model.Setting {
+	return []model.Setting{
+		{Name: "max_items", Type: "int", Value: fmt.Sprint(q.MaxItems), Usage: "Maximum number of items in the queue"},
+	}
+}
+
 // Impl returns the Queue implementation.
 func (q *Queue) Impl(n *model.Node) model.PartImpl {
+	if q.Mode == QueueModePriority {
+		return q.priorityImpl(n)
+	}
 	index, trim := q.Mode.params()
 	return model.PartImpl{
-		Head: fmt.Sprintf("const maxItems = %d", q.MaxItems),
+		Head: n.SettingDecl("maxItems", "max_items"),
 		Body: fmt.Sprintf(`
 		queue := make([]%s, 0, maxItems)
 		for {
@@ -173,6 +222,73 @@ func (q *Queue) Impl(n *model.Node) model.PartImpl {
 	}
 }
 
+// priorityImpl returns the Queue implementation for priority mode. The
+// queue is a heap with the highest-priority item first. The lowest-priority
+// item is one of the leaves, which are searched when an item must be dropped.
+func (q *Queue) priorityImpl(n *model.Node) model.PartImpl {
+	less := q.Less
+	if less == "" {
+		less = DefaultQueueLess
+	}
+	return model.PartImpl{
+		Imports: []string{`"container/heap"`},
+		Head:    n.SettingDecl("maxItems", "max_items"),
+		Body: fmt.Sprintf(`
+		queue := make([]%[1]s, 0, maxItems+1)
+		lower := func(a, b %[1]s) bool { return %[2]s }
+		pq := queueHeap{
+			len:      func() int { return len(queue) },
+			less:     func(i, j int) bool { return lower(queue[j], queue[i]) },
+			swap:     func(i, j int) { queue[i], queue[j] = queue[j], queue[i] },
+			truncate: func() { queue = queue[:len(queue)-1] },
+		}
+		for {
+			if len(queue) == 0 {
+				if input == nil {
+					break
+				}
+				in, open := <-input
+				if !open {
+					break
+				}
+				queue = append(queue, in)
+			}
+			out := queue[0]
+			select {
+			case in, open := <-input:
+				if !open {
+					input = nil
+					break // select
+				}
+				queue = append(queue, in)
+				heap.Fix(pq, len(queue)-1)
+				if len(queue) <= maxItems {
+					break // select
+				}
+				// Drop the lowest-priority item, but don't block.
+				low := len(queue) / 2
+				for i := low + 1; i < len(queue); i++ {
+					if lower(queue[i], queue[low]) {
+						low = i
+					}
+				}
+				select {
+				case drop <- queue[low]:
+				default:
+				}
+				heap.Remove(pq, low)
+			case output <- out:
+				heap.Pop(pq)
+			}
+		}`, n.TypeParams[queueTypeParam], less),
+		Tail: `close(output)
+		if drop != nil {
+			close(drop)
+		}`,
+		NeedsInit: true,
+	}
+}
+
 // Pins returns a map declaring an input and two outputs of the same arbitrary type.
 func (q *Queue) Pins() pin.Map { return queuePins }
 
@@ -0,0 +1,103 @@
+// Copyright 2018 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package parts
+
+import (
+	"bytes"
+	"os"
+	"reflect"
+	"testing"
+
+	"github.com/google/shenzhen-go/model"
+)
+
+func TestQueueModes(t *testing.T) {
+	for _, mode := range []QueueMode{QueueModeFIFO, QueueModeLIFO, QueueModePriority} {
+		t.Run(string(mode), func(t *testing.T) {
+			r, err := os.Open("../examples/queue.szgo")
+			if err != nil {
+				t.Fatalf("Couldn't open example: %v", err)
+			}
+			defer r.Close()
+			g, err := model.LoadJSON(r, "queue.szgo", "")
+			if err != nil {
+				t.Fatalf("Couldn't load example: %v", err)
+			}
+			q := g.Nodes["Queue"].Part.(*Queue)
+			q.Mode = mode
+			if mode == QueueModePriority {
+				q.Less = "a > b"
+			}
+
+			var buf bytes.Buffer
+			if err := g.WriteGoTo(&buf); err != nil {
+				t.Fatalf("g.WriteGoTo() = error %v", err)
+			}
+			typeCheck(t, buf.Bytes())
+		})
+	}
+}
+
+func TestQueuePriorityRun(t *testing.T) {
+	tests := []struct {
+		name   string
+		queue  *Queue
+		in     string
+		script string
+		want   map[string][]string
+	}{
+		{
+			name:   "less",
+			queue:  &Queue{Mode: QueueModePriority, MaxItems: 10, Less: "a < b"},
+			in:     "int",
+			script: `for _, p := range []int{5, 1, 4, 2, 3} { output <- p }`,
+			want:   map[string][]string{"output": {"5", "4", "3", "2", "1"}},
+		},
+		{
+			name:   "default less",
+			queue:  &Queue{Mode: QueueModePriority, MaxItems: 10},
+			in:     "struct{ Priority int }",
+			script: `for _, p := range []int{5, 1, 4, 2, 3} { output <- struct{ Priority int }{p} }`,
+			want:   map[string][]string{"output": {"{5}", "{4}", "{3}", "{2}", "{1}"}},
+		},
+		{
+			name:   "drop",
+			queue:  &Queue{Mode: QueueModePriority, MaxItems: 3, Less: "a < b"},
+			in:     "int",
+			script: `for _, p := range []int{5, 1, 4, 2, 3} { output <- p }`,
+			want: map[string][]string{
+				"output": {"5", "4", "3"},
+				"drop":   {"1", "2"},
+			},
+		},
+	}
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+			g := partGraph(test.queue, test.in, map[string]string{"input": test.script}, "output", "drop")
+			// Start the output sink late, so the whole input is queued
+			// before anything is sent. Dropping doesn't block, so buffer it.
+			sink := g.Nodes["sink_output"].Part.(*Code)
+			sink.Imports = append(sink.Imports, `"time"`)
+			sink.Head = []string{"time.Sleep(200 * time.Millisecond)"}
+			g.Channels["drop"].Capacity = 5
+
+			if got := runPartGraph(t, g); !reflect.DeepEqual(got, test.want) {
+				t.Errorf("outputs = %q, want %q", got, test.want)
+			}
+		})
+	}
+}
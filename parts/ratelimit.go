@@ -0,0 +1,263 @@
+// Copyright 2018 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package parts
+
+import (
+	"bytes"
+	"fmt"
+	"text/template"
+
+	"github.com/google/shenzhen-go/model"
+	"github.com/google/shenzhen-go/model/pin"
+)
+
+var (
+	rateLimitPins = pin.NewMap(
+		&pin.Definition{
+			Name:      "input",
+			Direction: pin.Input,
+			Type:      "$Any",
+		},
+		&pin.Definition{
+			Name:      "output",
+			Direction: pin.Output,
+			Type:      "$Any",
+		},
+		&pin.Definition{
+			Name:      "drop",
+			Direction: pin.Output,
+			Type:      "$Any",
+		},
+	)
+
+	rateLimitHeadTmpl = template.Must(template.New("ratelimit-head").Parse(`
+	{{.RateDecl}}
+	{{.BurstDecl}}
+	{{.Checks}}
+	// The token bucket is shared by all instances. tokens goes below zero
+	// when values are waiting for tokens that haven't been added yet.
+	tokens := float64(burst)
+	last := time.Now()
+	{{if .Mult}}var mu sync.Mutex{{end}}
+	{{if .Prometheus -}}
+	labels := prometheus.Labels{"node_name": "{{.NodeName}}"}
+	rateLimitTokens := rateLimitTokens.With(labels)
+	{{if .Drop -}}
+	rateLimitDropped := rateLimitDropped.With(labels)
+	{{- else -}}
+	rateLimitDelay := rateLimitDelay.With(labels)
+	{{- end}}
+	rateLimitTokens.Set(tokens)
+	{{end -}}`))
+
+	rateLimitBodyTmpl = template.Must(template.New("ratelimit-body").Parse(`
+	for in := range input {
+		{{if .Mult}}mu.Lock(){{end}}
+		now := time.Now()
+		if tokens += now.Sub(last).Seconds() * rate; tokens > float64(burst) {
+			tokens = float64(burst)
+		}
+		last = now
+		{{- if .Drop}}
+		shed := tokens < 1
+		if !shed {
+			tokens--
+		}
+		{{- else}}
+		var wait time.Duration
+		if tokens < 1 {
+			wait = time.Duration((1 - tokens) / rate * float64(time.Second))
+		}
+		tokens--
+		{{- end}}
+		{{if .Prometheus}}rateLimitTokens.Set(tokens){{end}}
+		{{if .Mult}}mu.Unlock(){{end}}
+		{{- if .Drop}}
+		if shed {
+			// Don't block.
+			select {
+			case drop <- in:
+			default:
+			}
+			{{if .Prometheus}}rateLimitDropped.Inc(){{end}}
+			continue
+		}
+		{{- else}}
+		if wait > 0 {
+			time.Sleep(wait)
+			{{if .Prometheus}}rateLimitDelay.Add(wait.Seconds()){{end}}
+		}
+		{{- end}}
+		output <- in
+	}`))
+)
+
+func init() {
+	model.RegisterPartType("RateLimit", "Flow", &model.PartType{
+		New: func() model.Part {
+			return &RateLimit{
+				Rate:  10,
+				Burst: 1,
+			}
+		},
+		Init: `
+		var (
+			rateLimitTokens = prometheus.NewGaugeVec(
+				prometheus.GaugeOpts{
+					Namespace: "shenzhen_go",
+					Subsystem: "ratelimit",
+					Name:      "tokens",
+					Help:      "Tokens in the bucket of a RateLimit node; negative when values are waiting",
+				},
+				[]string{"node_name"},
+			)
+			rateLimitDelay = prometheus.NewCounterVec(
+				prometheus.CounterOpts{
+					Namespace: "shenzhen_go",
+					Subsystem: "ratelimit",
+					Name:      "delay_seconds",
+					Help:      "Cumulative time values were delayed by a RateLimit node in seconds",
+				},
+				[]string{"node_name"},
+			)
+			rateLimitDropped = prometheus.NewCounterVec(
+				prometheus.CounterOpts{
+					Namespace: "shenzhen_go",
+					Subsystem: "ratelimit",
+					Name:      "dropped",
+					Help:      "Values shed by a RateLimit node",
+				},
+				[]string{"node_name"},
+			)
+		)
+
+		func init() {
+			prometheus.MustRegister(
+				rateLimitTokens,
+				rateLimitDelay,
+				rateLimitDropped,
+			)
+		}
+		`,
+		Panels: []model.PartPanel{
+			{
+				Name: "RateLimit",
+				Editor: `
+				<div class="form">
+					<div class="formfield">
+						<input id="ratelimit-enableprometheus" name="ratelimit-enableprometheus" type="checkbox"></input>
+						<label for="ratelimit-enableprometheus">Enable Prometheus metrics</label>
+					</div>
+					<div class="formfield">
+						<label for="ratelimit-rate">Rate (per second)</label>
+						<input id="ratelimit-rate" name="ratelimit-rate" type="number" step="any" required title="Must be a number greater than 0." value="10"></input>
+					</div>
+					<div class="formfield">
+						<label for="ratelimit-burst">Burst</label>
+						<input id="ratelimit-burst" name="ratelimit-burst" type="number" required title="Must be a whole number, at least 1." value="1"></input>
+					</div>
+				</div>`,
+			},
+			{
+				Name: "Help",
+				Editor: `<div><p>
+				A RateLimit part passes values from the input to the output no faster 
+				than the rate, using a token bucket.
+			</p><p>
+				The bucket holds up to burst tokens, which must be at least 1, and 
+				gains tokens at the rate per second, which must be greater than 0. Each value takes a token. If there isn't one, the value waits
+				until there is. All instances share the same bucket.
+			</p><p>
+				If the drop output is connected, values arriving when there are no tokens 
+				are sent to drop instead of waiting, shedding the load. Like Queue, 
+				sending to drop doesn't block: if drop isn't ready, the value is discarded.
+			</p></div>`,
+			},
+		},
+	})
+}
+
+// RateLimit is a part which limits the rate of values passing through,
+// using a token bucket.
+type RateLimit struct {
+	Rate             float64 `json:"rate"`  // tokens added per second
+	Burst            int     `json:"burst"` // size of the bucket
+	EnablePrometheus bool    `json:"enable_prometheus,omitempty"`
+}
+
+// Clone returns a clone of this RateLimit.
+func (r *RateLimit) Clone() model.Part {
+	r0 := *r
+	return &r0
+}
+
+// Settings returns the settings that can be made configurable.
+func (r *RateLimit) Settings() []model.Setting {
+	return []model.Setting{
+		{Name: "rate", Type: "float64", Value: fmt.Sprint(r.Rate), Usage: "Values allowed per second"},
+		{Name: "burst", Type: "int", Value: fmt.Sprint(r.Burst), Usage: "Values allowed at once after a quiet period"},
+	}
+}
+
+// Impl returns the RateLimit implementation.
+func (r *RateLimit) Impl(n *model.Node) model.PartImpl {
+	// With no tokens, values would never be passed on.
+	rateCheck, imps := settingCheck(n, "rate", "rate", r.Rate > 0, "rate <= 0", "greater than 0")
+	burstCheck, burstImps := settingCheck(n, "burst", "burst", r.Burst >= 1, "burst < 1", "at least 1")
+	imps = append(append(imps, burstImps...), `"time"`)
+	params := struct {
+		RateDecl, BurstDecl, Checks string
+		Mult, Drop, Prometheus      bool
+		NodeName                    string
+	}{
+		RateDecl:   n.SettingDecl("rate", "rate"),
+		BurstDecl:  n.SettingDecl("burst", "burst"),
+		Checks:     rateCheck + burstCheck,
+		Mult:       !n.SingleInstance(),
+		Drop:       n.Connections["drop"] != "nil", // We know at design time whether a pin is nil.
+		Prometheus: r.EnablePrometheus,
+		NodeName:   n.Name,
+	}
+	h, b := bytes.NewBuffer(nil), bytes.NewBuffer(nil)
+	if err := rateLimitHeadTmpl.Execute(h, params); err != nil {
+		panic("couldn't execute ratelimit-head template: " + err.Error())
+	}
+	if err := rateLimitBodyTmpl.Execute(b, params); err != nil {
+		panic("couldn't execute ratelimit-body template: " + err.Error())
+	}
+	if params.Mult {
+		imps = append(imps, `"sync"`)
+	}
+	if r.EnablePrometheus {
+		imps = append(imps, `"github.com/prometheus/client_golang/prometheus"`)
+	}
+	tail := "close(output)"
+	if params.Drop {
+		tail += "\nclose(drop)"
+	}
+	return model.PartImpl{
+		Imports:   imps,
+		Head:      h.String(),
+		Body:      b.String(),
+		Tail:      tail,
+		NeedsInit: r.EnablePrometheus,
+	}
+}
+
+// Pins returns a map declaring an input and two outputs of the same arbitrary type.
+func (r *RateLimit) Pins() pin.Map { return rateLimitPins }
+
+// TypeKey returns "RateLimit".
+func (r *RateLimit) TypeKey() string { return "RateLimit" }
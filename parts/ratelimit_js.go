@@ -0,0 +1,60 @@
+// Copyright 2018 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//+build js
+
+package parts
+
+import (
+	"log"
+
+	"github.com/google/shenzhen-go/dom"
+)
+
+var (
+	inputRateLimitRate             = doc.ElementByID("ratelimit-rate")
+	inputRateLimitBurst            = doc.ElementByID("ratelimit-burst")
+	inputRateLimitEnablePrometheus = doc.ElementByID("ratelimit-enableprometheus")
+
+	focusedRateLimit *RateLimit
+)
+
+func init() {
+	inputRateLimitRate.AddEventListener("change", func(dom.Object) {
+		rate := inputRateLimitRate.Get("value").Float()
+		if rate <= 0 {
+			log.Printf("rate %v is not greater than 0", rate)
+			return
+		}
+		focusedRateLimit.Rate = rate
+	})
+	inputRateLimitBurst.AddEventListener("change", func(dom.Object) {
+		burst := inputRateLimitBurst.Get("value").Int()
+		if burst < 1 {
+			log.Printf("burst %d is less than 1", burst)
+			return
+		}
+		focusedRateLimit.Burst = burst
+	})
+	inputRateLimitEnablePrometheus.AddEventListener("change", func(dom.Object) {
+		focusedRateLimit.EnablePrometheus = inputRateLimitEnablePrometheus.Get("checked").Bool()
+	})
+}
+
+func (r *RateLimit) GainFocus() {
+	focusedRateLimit = r
+	inputRateLimitRate.Set("value", r.Rate)
+	inputRateLimitBurst.Set("value", r.Burst)
+	inputRateLimitEnablePrometheus.Set("checked", r.EnablePrometheus)
+}
@@ -0,0 +1,60 @@
+// Copyright 2018 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package parts
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestRateLimit(t *testing.T) {
+	r := &RateLimit{Rate: 1000, Burst: 2}
+	for _, mult := range []string{"1", "2"} {
+		typeCheckPipeline(t, r, "string", mult, "input", "output")
+		typeCheckPipeline(t, r, "string", mult, "input", "output", "drop")
+	}
+}
+
+func TestRateLimitRun(t *testing.T) {
+	t.Run("wait", func(t *testing.T) {
+		t.Parallel()
+		// After the burst, each value waits 1/rate for a token.
+		script := `start := time.Now()
+			for i := 0; i < 8; i++ { output <- i }
+			if d := time.Since(start); d < 200*time.Millisecond {
+				panic("8 values took " + d.String() + " at 20 per second with a burst of 2")
+			}`
+		got := runPart(t, &RateLimit{Rate: 20, Burst: 2}, "int", map[string]string{"input": script}, "output")
+		if want := []string{"0", "1", "2", "3", "4", "5", "6", "7"}; !reflect.DeepEqual(got["output"], want) {
+			t.Errorf("output = %q, want %q", got["output"], want)
+		}
+	})
+	t.Run("drop", func(t *testing.T) {
+		t.Parallel()
+		// Sending 5 values takes much less than the 1s for another token.
+		// Sending on drop doesn't block, so some dropped values may be lost.
+		script := `for i := 0; i < 5; i++ { output <- i }`
+		got := runPart(t, &RateLimit{Rate: 1, Burst: 2}, "int", map[string]string{"input": script}, "output", "drop")
+		if want := []string{"0", "1"}; !reflect.DeepEqual(got["output"], want) {
+			t.Errorf("output = %q, want %q", got["output"], want)
+		}
+		for _, d := range got["drop"] {
+			if d < "2" || d > "4" {
+				t.Errorf("drop = %q, want a subset of [2 3 4]", got["drop"])
+				break
+			}
+		}
+	})
+}
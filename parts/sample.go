@@ -0,0 +1,124 @@
+// Copyright 2018 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package parts
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/google/shenzhen-go/model"
+	"github.com/google/shenzhen-go/model/pin"
+)
+
+var samplePins = pin.NewMap(
+	&pin.Definition{
+		Name:      "input",
+		Direction: pin.Input,
+		Type:      "$Any",
+	},
+	&pin.Definition{
+		Name:      "output",
+		Direction: pin.Output,
+		Type:      "$Any",
+	},
+)
+
+func init() {
+	model.RegisterPartType("Sample", "Time", &model.PartType{
+		New: func() model.Part {
+			return &Sample{Interval: time.Second}
+		},
+		Panels: []model.PartPanel{
+			{
+				Name: "Sample",
+				Editor: `
+			<div class="form">
+				<div class="formfield">
+					<label for="sample-interval">Interval</label>
+					<input id="sample-interval" name="sample-interval" type="text" required title="Must be a parseable time.Duration, greater than 0s." value="1s"></input>
+				</div>
+			</div>`,
+			},
+			{
+				Name: "Help",
+				Editor: `<div>
+			<p>
+				A Sample part sends the latest value received on the output every 
+				interval. Nothing is sent for an interval in which no new value was 
+				received, and values superseded within an interval are discarded.
+			</p><p>
+				When the input is closed, the output is closed. A value received since
+				the last interval is discarded.
+			</p>
+			</div>`,
+			},
+		},
+	})
+}
+
+// Sample is a part which periodically sends the latest value received.
+type Sample struct {
+	Interval time.Duration `json:"interval"`
+}
+
+// Clone returns a clone of this Sample.
+func (s *Sample) Clone() model.Part {
+	s0 := *s
+	return &s0
+}
+
+// Settings returns the settings that can be made configurable.
+func (s *Sample) Settings() []model.Setting {
+	return []model.Setting{
+		{Name: "interval", Type: "time.Duration", Value: fmt.Sprintf("time.Duration(%d)", s.Interval), Usage: "Time between samples"},
+	}
+}
+
+// Impl returns the Sample implementation.
+func (s *Sample) Impl(n *model.Node) model.PartImpl {
+	// time.NewTicker panics if the interval isn't positive.
+	check, imps := settingCheck(n, "interval", "interval", s.Interval > 0, "interval <= 0", "greater than 0")
+	return model.PartImpl{
+		Imports: append(imps, `"time"`),
+		Head:    n.SettingDecl("interval", "interval") + check,
+		Body: fmt.Sprintf(`var latest %s
+		fresh := false
+		ticker := time.NewTicker(interval)
+	sampleLoop:
+		for {
+			select {
+			case in, open := <-input:
+				if !open {
+					break sampleLoop
+				}
+				latest, fresh = in, true
+			case <-ticker.C:
+				if !fresh {
+					break // select
+				}
+				fresh = false
+				output <- latest
+			}
+		}
+		ticker.Stop()`, n.TypeParams["$Any"]),
+		Tail: "close(output)",
+	}
+}
+
+// Pins returns a map declaring an input and an output of the same arbitrary type.
+func (s *Sample) Pins() pin.Map { return samplePins }
+
+// TypeKey returns "Sample".
+func (s *Sample) TypeKey() string { return "Sample" }
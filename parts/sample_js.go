@@ -0,0 +1,43 @@
+// Copyright 2018 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//+build js
+
+package parts
+
+import (
+	"log"
+	"time"
+)
+
+var (
+	inputSampleInterval = doc.ElementByID("sample-interval")
+
+	focusedSample *Sample
+)
+
+func init() {
+	inputSampleInterval.AddEventListener("change", durationChange(func(t time.Duration) {
+		if t <= 0 {
+			log.Printf("interval %v is not greater than 0s", t)
+			return
+		}
+		focusedSample.Interval = t
+	}))
+}
+
+func (s *Sample) GainFocus() {
+	focusedSample = s
+	inputSampleInterval.Set("value", s.Interval.String())
+}
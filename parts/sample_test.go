@@ -0,0 +1,39 @@
+// Copyright 2018 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package parts
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestSample(t *testing.T) {
+	for _, mult := range []string{"1", "2"} {
+		typeCheckPipeline(t, &Sample{Interval: time.Millisecond}, "string", mult, "input", "output")
+	}
+}
+
+func TestSampleRun(t *testing.T) {
+	script := `output <- 0; output <- 1; output <- 2
+	time.Sleep(230 * time.Millisecond)
+	output <- 3; output <- 4
+	time.Sleep(230 * time.Millisecond)
+	output <- 5`
+	got := runPart(t, &Sample{Interval: 100 * time.Millisecond}, "int", map[string]string{"input": script}, "output")
+	if want := []string{"2", "4"}; !reflect.DeepEqual(got["output"], want) {
+		t.Errorf("output = %q, want %q", got["output"], want)
+	}
+}
@@ -0,0 +1,218 @@
+// Copyright 2018 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package parts
+
+import (
+	"bytes"
+	"fmt"
+	"text/template"
+
+	"github.com/google/shenzhen-go/model"
+	"github.com/google/shenzhen-go/model/pin"
+)
+
+var splitBodyTmpl = template.Must(template.New("split-body").Parse(`
+	{{- if not .Outputs}}
+	for range input {
+	}
+	{{- else if eq .Mode "first_available"}}
+	for in := range input {
+		select {
+		{{- range .Outputs}}
+		case {{.Name}} <- in:
+		{{- end}}
+		}
+	}
+	{{- else}}
+	{{- if eq .Mode "hash"}}
+	h := fnv.New32a()
+	{{- else}}
+	next := 0
+	{{- end}}
+	for in := range input {
+		{{- if eq .Mode "hash"}}
+		h.Reset()
+		fmt.Fprint(h, {{.Key}})
+		switch h.Sum32() % {{len .Outputs}} {
+		{{- else}}
+		switch next {
+		{{- end}}
+		{{- range .Outputs}}
+		case {{.Index}}:
+			{{.Name}} <- in
+			{{- if eq $.Mode "round_robin"}}
+			next = {{.Next}}
+			{{- end}}
+		{{- end}}
+		}
+	}
+	{{- end}}`))
+
+func init() {
+	model.RegisterPartType("Split", "Flow", &model.PartType{
+		New: func() model.Part {
+			return &Split{
+				OutputNum: 2,
+				Mode:      SplitModeRoundRobin,
+			}
+		},
+		Panels: []model.PartPanel{
+			{
+				Name: "Split",
+				Editor: `<div class="form">
+				<div class="formfield">
+					<label for="split-outputnum">Number of outputs</label>
+					<input id="split-outputnum" name="split-outputnum" type="number" required title="Must be a whole number." value="2"></input>
+				</div>
+				<div class="formfield">
+					<label for="split-mode">Mode</label>
+					<select id="split-mode" name="split-mode">
+						<option value="round_robin" selected>Round-robin</option>
+						<option value="first_available">First available</option>
+						<option value="hash">Hash of key</option>
+					</select>
+				</div>
+				<div class="formfield">
+					<label for="split-key">Key</label>
+					<input id="split-key" name="split-key" type="text" title="In hash mode, a Go expression using the value in." placeholder="in"></input>
+				</div>
+			</div>`,
+			},
+			{
+				Name: "Help",
+				Editor: `<div>
+			<p>
+				A Split part sends each input value to exactly one of the outputs.
+				The number of outputs is configurable. Outputs that are not connected
+				are not used. When the input is closed, the outputs are closed.
+			</p><p>
+				The mode decides which output each value is sent to:
+			</p><ul>
+				<li>Round-robin sends to each output in turn.</li>
+				<li>First available sends to whichever output is ready to receive 
+				first, balancing the load between them.</li>
+				<li>Hash of key sends values with the same key to the same output. 
+				The key is a Go expression using the value (available as a value 
+				called <code>in</code>), and is formatted with fmt.Fprint and hashed.
+				If empty, the value itself is the key.</li>
+			</ul><p>
+				Unlike multiplicity, Split can share work between nodes that are 
+				configured differently.
+			</p>
+			</div>`,
+			},
+		},
+	})
+}
+
+// SplitMode describes how to choose an output for each value.
+type SplitMode string
+
+// Valid values of SplitMode.
+const (
+	SplitModeRoundRobin     SplitMode = "round_robin"
+	SplitModeFirstAvailable SplitMode = "first_available"
+	SplitModeHash           SplitMode = "hash"
+)
+
+// Split is a part that sends each input value to one of a configurable
+// number of outputs.
+type Split struct {
+	OutputNum uint      `json:"output_num"`
+	Mode      SplitMode `json:"mode"`
+	Key       string    `json:"key,omitempty"` // expression for the key in hash mode
+}
+
+// Clone returns a clone of this Split.
+func (s *Split) Clone() model.Part {
+	s0 := *s
+	return &s0
+}
+
+// splitOutput is a connected output of a Split node.
+type splitOutput struct {
+	Name        string
+	Index, Next int // among the connected outputs
+}
+
+// Impl returns the Split implementation.
+func (s *Split) Impl(n *model.Node) model.PartImpl {
+	switch s.Mode {
+	case SplitModeRoundRobin, SplitModeFirstAvailable, SplitModeHash:
+	default:
+		panic("unknown mode " + s.Mode)
+	}
+	var outs []*splitOutput
+	tail := bytes.NewBuffer(nil)
+	for i := uint(0); i < s.OutputNum; i++ {
+		name := fmt.Sprintf("output%d", i)
+		if n.Connections[name] == "nil" {
+			// We know at design time whether a pin is nil.
+			continue
+		}
+		outs = append(outs, &splitOutput{Name: name, Index: len(outs)})
+		fmt.Fprintf(tail, "close(%s)\n", name)
+	}
+	for i, o := range outs {
+		o.Next = (i + 1) % len(outs)
+	}
+	key := s.Key
+	if key == "" {
+		key = "in"
+	}
+	params := struct {
+		Mode    SplitMode
+		Key     string
+		Outputs []*splitOutput
+	}{
+		Mode:    s.Mode,
+		Key:     key,
+		Outputs: outs,
+	}
+	b := bytes.NewBuffer(nil)
+	if err := splitBodyTmpl.Execute(b, params); err != nil {
+		panic("couldn't execute split-body template: " + err.Error())
+	}
+	var imps []string
+	if s.Mode == SplitModeHash && len(outs) > 0 {
+		imps = []string{`"fmt"`, `"hash/fnv"`}
+	}
+	return model.PartImpl{
+		Imports: imps,
+		Body:    b.String(),
+		Tail:    tail.String(),
+	}
+}
+
+// Pins returns a map with 1 input and N outputs.
+func (s *Split) Pins() pin.Map {
+	m := pin.NewMap(&pin.Definition{
+		Name:      "input",
+		Direction: pin.Input,
+		Type:      "$Any",
+	})
+	for i := uint(0); i < s.OutputNum; i++ {
+		n := fmt.Sprintf("output%d", i)
+		m[n] = &pin.Definition{
+			Name:      n,
+			Direction: pin.Output,
+			Type:      "$Any",
+		}
+	}
+	return m
+}
+
+// TypeKey returns "Split".
+func (s *Split) TypeKey() string { return "Split" }
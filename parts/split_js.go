@@ -0,0 +1,46 @@
+// Copyright 2018 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//+build js
+
+package parts
+
+import "github.com/google/shenzhen-go/dom"
+
+var (
+	inputSplitOutputNum = doc.ElementByID("split-outputnum")
+	selectSplitMode     = doc.ElementByID("split-mode")
+	inputSplitKey       = doc.ElementByID("split-key")
+
+	focusedSplit *Split
+)
+
+func init() {
+	inputSplitOutputNum.AddEventListener("change", func(dom.Object) {
+		focusedSplit.OutputNum = uint(inputSplitOutputNum.Get("value").Int())
+	})
+	selectSplitMode.AddEventListener("change", func(dom.Object) {
+		focusedSplit.Mode = SplitMode(selectSplitMode.Get("value").String())
+	})
+	inputSplitKey.AddEventListener("change", func(dom.Object) {
+		focusedSplit.Key = inputSplitKey.Get("value").String()
+	})
+}
+
+func (s *Split) GainFocus() {
+	focusedSplit = s
+	inputSplitOutputNum.Set("value", s.OutputNum)
+	selectSplitMode.Set("value", s.Mode)
+	inputSplitKey.Set("value", s.Key)
+}
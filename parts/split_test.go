@@ -0,0 +1,88 @@
+// Copyright 2018 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package parts
+
+import (
+	"reflect"
+	"sort"
+	"strconv"
+	"testing"
+)
+
+func TestSplit(t *testing.T) {
+	for _, mode := range []SplitMode{SplitModeRoundRobin, SplitModeFirstAvailable, SplitModeHash} {
+		t.Run(string(mode), func(t *testing.T) {
+			s := &Split{OutputNum: 3, Mode: mode, Key: "len(in)"}
+			for _, mult := range []string{"1", "2"} {
+				typeCheckPipeline(t, s, "string", mult, "input")
+				typeCheckPipeline(t, s, "string", mult, "input", "output0", "output2")
+			}
+		})
+	}
+}
+
+func TestSplitRun(t *testing.T) {
+	script := `for i := 0; i < 9; i++ { output <- i }`
+	outputs := []string{"output0", "output2"}
+
+	t.Run("round_robin", func(t *testing.T) {
+		t.Parallel()
+		got := runPart(t, &Split{OutputNum: 3, Mode: SplitModeRoundRobin}, "int", map[string]string{"input": script}, outputs...)
+		want := map[string][]string{
+			"output0": {"0", "2", "4", "6", "8"},
+			"output2": {"1", "3", "5", "7"},
+		}
+		if !reflect.DeepEqual(got, want) {
+			t.Errorf("outputs = %q, want %q", got, want)
+		}
+	})
+
+	for _, mode := range []SplitMode{SplitModeFirstAvailable, SplitModeHash} {
+		mode := mode
+		t.Run(string(mode), func(t *testing.T) {
+			t.Parallel()
+			got := runPart(t, &Split{OutputNum: 3, Mode: mode, Key: "in % 3"}, "int", map[string]string{"input": script}, outputs...)
+			var all []int
+			keys := make(map[int]string) // key -> output
+			for _, o := range outputs {
+				for _, v := range got[o] {
+					i, err := strconv.Atoi(v)
+					if err != nil {
+						t.Fatalf("strconv.Atoi(%q) = error %v", v, err)
+					}
+					all = append(all, i)
+					if mode != SplitModeHash {
+						continue
+					}
+					if prev, ok := keys[i%3]; ok && prev != o {
+						t.Errorf("key %d sent to both %s and %s", i%3, prev, o)
+					}
+					keys[i%3] = o
+				}
+			}
+			sort.Ints(all)
+			if want := []int{0, 1, 2, 3, 4, 5, 6, 7, 8}; !reflect.DeepEqual(all, want) {
+				t.Errorf("values sent = %v, want %v", all, want)
+			}
+		})
+	}
+
+	t.Run("no outputs", func(t *testing.T) {
+		t.Parallel()
+		if got := runPart(t, &Split{OutputNum: 2, Mode: SplitModeRoundRobin}, "int", map[string]string{"input": script}); len(got) != 0 {
+			t.Errorf("outputs = %q, want none", got)
+		}
+	})
+}
@@ -0,0 +1,163 @@
+// Copyright 2018 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package parts
+
+import (
+	"bytes"
+	"fmt"
+	"text/template"
+	"time"
+
+	"github.com/google/shenzhen-go/model"
+	"github.com/google/shenzhen-go/model/pin"
+)
+
+var (
+	tickerPins = pin.NewMap(
+		&pin.Definition{
+			Name:      "stop",
+			Direction: pin.Input,
+			Type:      "struct{}",
+		},
+		&pin.Definition{
+			Name:      "output",
+			Direction: pin.Output,
+			Type:      "time.Time",
+		},
+	)
+
+	tickerBodyTmpl = template.Must(template.New("ticker-body").Parse(`
+	next := time.Now()
+tickLoop:
+	for n := 0; maxTicks <= 0 || n < maxTicks; n++ {
+		// Like time.Ticker, skip ticks rather than catching up with a slow
+		// receiver.
+		next = next.Add(interval)
+		if now := time.Now(); next.Before(now) {
+			next = now
+		}
+		wait := time.Until(next)
+		if jitter > 0 {
+			wait += time.Duration(rand.Int63n(int64(jitter)))
+		}
+		timer := time.NewTimer(wait)
+		select {
+		case <-stop:
+			timer.Stop()
+			break tickLoop
+		case t := <-timer.C:
+			select {
+			case <-stop:
+				break tickLoop
+			case output <- t:
+			}
+		}
+	}`))
+)
+
+func init() {
+	model.RegisterPartType("Ticker", "Time", &model.PartType{
+		New: func() model.Part {
+			return &Ticker{Interval: time.Second}
+		},
+		Panels: []model.PartPanel{
+			{
+				Name: "Ticker",
+				Editor: `
+			<div class="form">
+				<div class="formfield">
+					<label for="ticker-interval">Interval</label>
+					<input id="ticker-interval" name="ticker-interval" type="text" required title="Must be a parseable time.Duration, greater than 0s." value="1s"></input>
+				</div>
+				<div class="formfield">
+					<label for="ticker-jitter">Jitter</label>
+					<input id="ticker-jitter" name="ticker-jitter" type="text" required title="Must be a parseable time.Duration. 0s means no jitter." value="0s"></input>
+				</div>
+				<div class="formfield">
+					<label for="ticker-maxticks">Maximum ticks</label>
+					<input id="ticker-maxticks" name="ticker-maxticks" type="number" required title="Must be a whole number. 0 means no limit." value="0"></input>
+				</div>
+			</div>`,
+			},
+			{
+				Name: "Help",
+				Editor: `<div>
+			<p>
+				A Ticker part sends the current time on the output every interval
+				(which must be greater than 0s), until it has sent the maximum 
+				number of ticks (if not 0), or the stop input receives a value or
+				is closed. Then the output is closed.
+			</p><p>
+				With jitter, each tick is delayed by a random extra duration, up to
+				the jitter, so that tickers in different processes drift apart. 
+				Like time.Ticker, ticks are skipped if the receiver is slow.
+			</p>
+			</div>`,
+			},
+		},
+	})
+}
+
+// Ticker is a part which sends the time periodically.
+type Ticker struct {
+	Interval time.Duration `json:"interval"`
+	Jitter   time.Duration `json:"jitter,omitempty"`
+	MaxTicks int           `json:"max_ticks,omitempty"` // 0 means no limit
+}
+
+// Clone returns a clone of this Ticker.
+func (t *Ticker) Clone() model.Part {
+	t0 := *t
+	return &t0
+}
+
+// Settings returns the settings that can be made configurable.
+func (t *Ticker) Settings() []model.Setting {
+	return []model.Setting{
+		{Name: "interval", Type: "time.Duration", Value: fmt.Sprintf("time.Duration(%d)", t.Interval), Usage: "Time between ticks"},
+		{Name: "jitter", Type: "time.Duration", Value: fmt.Sprintf("time.Duration(%d)", t.Jitter), Usage: "Maximum random delay added to each tick"},
+		{Name: "max_ticks", Type: "int", Value: fmt.Sprint(t.MaxTicks), Usage: "Number of ticks to send, or 0 for no limit"},
+	}
+}
+
+// Impl returns the Ticker implementation.
+func (t *Ticker) Impl(n *model.Node) model.PartImpl {
+	b := bytes.NewBuffer(nil)
+	if err := tickerBodyTmpl.Execute(b, nil); err != nil {
+		panic("couldn't execute ticker-body template: " + err.Error())
+	}
+	// An interval that isn't positive would tick as fast as possible.
+	check, imps := settingCheck(n, "interval", "interval", t.Interval > 0, "interval <= 0", "greater than 0")
+	head := n.SettingDecl("interval", "interval") + "\n" +
+		n.SettingDecl("jitter", "jitter") + "\n" +
+		n.SettingDecl("maxTicks", "max_ticks") + check
+	tail := ""
+	if n.Connections["output"] != "nil" {
+		// We know at design time whether a pin is nil.
+		tail = "close(output)"
+	}
+	return model.PartImpl{
+		Imports: append(imps, `"math/rand"`, `"time"`),
+		Head:    head,
+		Body:    b.String(),
+		Tail:    tail,
+	}
+}
+
+// Pins returns a map declaring a stop input and an output of time.Time.
+func (t *Ticker) Pins() pin.Map { return tickerPins }
+
+// TypeKey returns "Ticker".
+func (t *Ticker) TypeKey() string { return "Ticker" }
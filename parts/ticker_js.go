@@ -0,0 +1,55 @@
+// Copyright 2018 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//+build js
+
+package parts
+
+import (
+	"log"
+	"time"
+
+	"github.com/google/shenzhen-go/dom"
+)
+
+var (
+	inputTickerInterval = doc.ElementByID("ticker-interval")
+	inputTickerJitter   = doc.ElementByID("ticker-jitter")
+	inputTickerMaxTicks = doc.ElementByID("ticker-maxticks")
+
+	focusedTicker *Ticker
+)
+
+func init() {
+	inputTickerInterval.AddEventListener("change", durationChange(func(t time.Duration) {
+		if t <= 0 {
+			log.Printf("interval %v is not greater than 0s", t)
+			return
+		}
+		focusedTicker.Interval = t
+	}))
+	inputTickerJitter.AddEventListener("change", durationChange(func(t time.Duration) {
+		focusedTicker.Jitter = t
+	}))
+	inputTickerMaxTicks.AddEventListener("change", func(dom.Object) {
+		focusedTicker.MaxTicks = inputTickerMaxTicks.Get("value").Int()
+	})
+}
+
+func (t *Ticker) GainFocus() {
+	focusedTicker = t
+	inputTickerInterval.Set("value", t.Interval.String())
+	inputTickerJitter.Set("value", t.Jitter.String())
+	inputTickerMaxTicks.Set("value", t.MaxTicks)
+}
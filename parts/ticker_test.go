@@ -0,0 +1,120 @@
+// Copyright 2018 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package parts
+
+import (
+	"testing"
+	"time"
+)
+
+func TestTicker(t *testing.T) {
+	for _, p := range []*Ticker{
+		{Interval: time.Millisecond},
+		{Interval: time.Millisecond, Jitter: time.Millisecond, MaxTicks: 3},
+	} {
+		for _, mult := range []string{"1", "2"} {
+			typeCheckPipeline(t, p, "struct{}", mult, "stop", "output")
+		}
+	}
+}
+
+func TestAfter(t *testing.T) {
+	for _, p := range []*After{
+		{Delay: time.Millisecond},
+		{Delay: time.Millisecond, Jitter: time.Millisecond},
+	} {
+		typeCheckPipeline(t, p, "struct{}", "1", "stop", "output")
+	}
+}
+
+func TestTickerRun(t *testing.T) {
+	tests := []struct {
+		name     string
+		ticker   *Ticker
+		stop     string
+		outputs  []string
+		min, max int
+	}{
+		{
+			name:    "max ticks",
+			ticker:  &Ticker{Interval: 10 * time.Millisecond, MaxTicks: 3},
+			stop:    `time.Sleep(time.Second)`,
+			outputs: []string{"output"},
+			min:     3,
+			max:     3,
+		},
+		{
+			name:    "stop",
+			ticker:  &Ticker{Interval: 20 * time.Millisecond},
+			stop:    `time.Sleep(110 * time.Millisecond)`,
+			outputs: []string{"output"},
+			min:     2,
+			max:     6,
+		},
+		{
+			name:   "no output",
+			ticker: &Ticker{Interval: 10 * time.Millisecond},
+			stop:   `time.Sleep(50 * time.Millisecond)`,
+		},
+	}
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+			got := runPart(t, test.ticker, "struct{}", map[string]string{"stop": test.stop}, test.outputs...)
+			if n := len(got["output"]); n < test.min || n > test.max {
+				t.Errorf("sent %d ticks, want between %d and %d", n, test.min, test.max)
+			}
+		})
+	}
+}
+
+func TestAfterRun(t *testing.T) {
+	tests := []struct {
+		name    string
+		after   *After
+		stop    string
+		outputs []string
+		want    int
+	}{
+		{
+			name:    "delay",
+			after:   &After{Delay: 20 * time.Millisecond},
+			stop:    `time.Sleep(time.Second)`,
+			outputs: []string{"output"},
+			want:    1,
+		},
+		{
+			name:    "stop",
+			after:   &After{Delay: time.Second},
+			outputs: []string{"output"},
+			want:    0,
+		},
+		{
+			name:  "no output",
+			after: &After{Delay: time.Second},
+		},
+	}
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+			got := runPart(t, test.after, "struct{}", map[string]string{"stop": test.stop}, test.outputs...)
+			if n := len(got["output"]); n != test.want {
+				t.Errorf("sent %d times, want %d", n, test.want)
+			}
+		})
+	}
+}
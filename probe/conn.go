@@ -0,0 +1,339 @@
+// Copyright 2018 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package probe
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"net"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+const (
+	// SampleInterval is how often channels are sampled.
+	SampleInterval = 250 * time.Millisecond
+
+	// TapInterval is the minimum time between samples of values sent on a
+	// tapped channel.
+	TapInterval = 100 * time.Millisecond
+
+	// MaxTapValue is the maximum length of a formatted value.
+	MaxTapValue = 1024
+)
+
+// Message is sent from an instrumented program to the server that started
+// it, as one line of JSON.
+type Message struct {
+	Channels []ChannelStats `json:"channels,omitempty"`
+	Tap      *Value         `json:"tap,omitempty"`  // sampled from a tapped channel
+	Held     *Value         `json:"held,omitempty"` // held at a breakpoint
+}
+
+// Command operations.
+const (
+	OpTap      = "tap"      // start sending samples of values
+	OpUntap    = "untap"    // stop sending samples of values
+	OpBreak    = "break"    // hold values sent until released
+	OpClear    = "clear"    // stop holding values, releasing any held value
+	OpStep     = "step"     // release the held value, and hold the next
+	OpContinue = "continue" // release the held value, and clear the breakpoint
+	OpDrop     = "drop"     // discard the held value, and hold the next
+)
+
+// Command is sent from the server to an instrumented program, as one line
+// of JSON.
+type Command struct {
+	Channel string `json:"channel"`
+	Op      string `json:"op"`
+}
+
+// Value is a value that was sent on a channel.
+type Value struct {
+	Channel string `json:"channel"`
+	Value   string `json:"value"` // formatted with %#v
+	Time    int64  `json:"time"`  // Unix time in nanoseconds
+}
+
+// ChannelStats is a sample of the activity of one channel.
+type ChannelStats struct {
+	Name  string  `json:"name"`
+	Len   int     `json:"len"`
+	Cap   int     `json:"cap"`
+	Sends uint64  `json:"sends"`
+	Rate  float64 `json:"rate"` // sends per second since the previous sample
+}
+
+// Channel counts the values sent on one channel, samples them when
+// tapped, and holds them at breakpoints.
+type Channel struct {
+	sends    uint64 // accessed atomically; first for alignment
+	tapping  uint32 // accessed atomically
+	breaking uint32 // accessed atomically
+	conn     *Conn
+	name     string
+	capacity int
+	length   func() int
+	release  chan string // the Op that released the held value
+
+	// Guarded by conn.mu.
+	lastSends uint64 // as of the previous sample
+	lastTap   time.Time
+	held      bool
+}
+
+// Sent records that a value was sent.
+func (c *Channel) Sent() {
+	if c == nil {
+		return
+	}
+	atomic.AddUint64(&c.sends, 1)
+}
+
+// Tapping reports whether the server wants samples of the values sent.
+func (c *Channel) Tapping() bool {
+	return c != nil && atomic.LoadUint32(&c.tapping) != 0
+}
+
+// Tap sends a value to the server, unless one was sent within TapInterval.
+func (c *Channel) Tap(v interface{}) {
+	if c == nil {
+		return
+	}
+	now := time.Now()
+	c.conn.mu.Lock()
+	defer c.conn.mu.Unlock()
+	if now.Sub(c.lastTap) < TapInterval {
+		return
+	}
+	c.lastTap = now
+	c.conn.send(&Message{Tap: c.value(v, now)})
+}
+
+// Breaking reports whether there is a breakpoint on the channel.
+func (c *Channel) Breaking() bool {
+	return c != nil && atomic.LoadUint32(&c.breaking) != 0
+}
+
+// Hold tells the server about a value, and waits until the server releases
+// it. It reports whether the value should be sent on (true), or dropped
+// (false). If the server disconnects, all values are released.
+func (c *Channel) Hold(v interface{}) bool {
+	if c == nil {
+		return true
+	}
+	c.conn.mu.Lock()
+	if c.conn.closed {
+		c.conn.mu.Unlock()
+		return true
+	}
+	c.held = true
+	c.conn.send(&Message{Held: c.value(v, time.Now())})
+	c.conn.mu.Unlock()
+	return <-c.release != OpDrop
+}
+
+// value formats a value for sending to the server.
+func (c *Channel) value(v interface{}, t time.Time) *Value {
+	s := fmt.Sprintf("%#v", v)
+	if len(s) > MaxTapValue {
+		s = s[:MaxTapValue] + "..."
+	}
+	return &Value{
+		Channel: c.name,
+		Value:   s,
+		Time:    t.UnixNano(),
+	}
+}
+
+// handle applies a command. Must be called with conn.mu held.
+func (c *Channel) handle(op string) {
+	switch op {
+	case OpTap:
+		atomic.StoreUint32(&c.tapping, 1)
+	case OpUntap:
+		atomic.StoreUint32(&c.tapping, 0)
+	case OpBreak:
+		atomic.StoreUint32(&c.breaking, 1)
+	case OpClear, OpContinue:
+		atomic.StoreUint32(&c.breaking, 0)
+		c.unhold(OpContinue)
+	case OpStep, OpDrop:
+		c.unhold(op)
+	}
+}
+
+// unhold releases the held value, if any. Must be called with conn.mu held.
+func (c *Channel) unhold(op string) {
+	if !c.held {
+		return
+	}
+	c.held = false
+	c.release <- op // buffered, and only one value is held at a time
+}
+
+// Conn is a connection from an instrumented program back to the server.
+// A nil *Conn is valid, and does nothing.
+type Conn struct {
+	conn net.Conn
+	enc  *json.Encoder
+
+	mu       sync.Mutex
+	channels []*Channel
+	breaks   map[string]bool // initial breakpoints
+	last     time.Time
+	closed   bool // whether commands can no longer be received
+
+	stop chan struct{}
+	done chan struct{}
+}
+
+// Dial connects to the server at addr, and starts sampling channels.
+// Channels with the names in breakpoints start with a breakpoint. If the
+// connection can't be made, it logs the error and returns nil.
+func Dial(addr string, breakpoints ...string) *Conn {
+	conn, err := net.Dial("tcp", addr)
+	if err != nil {
+		log.Printf("Couldn't connect to Shenzhen Go: %v", err)
+		return nil
+	}
+	c := &Conn{
+		conn:   conn,
+		enc:    json.NewEncoder(conn),
+		breaks: make(map[string]bool, len(breakpoints)),
+		last:   time.Now(),
+		stop:   make(chan struct{}),
+		done:   make(chan struct{}),
+	}
+	for _, b := range breakpoints {
+		c.breaks[b] = true
+	}
+	go c.sampleLoop()
+	go c.commandLoop()
+	return c
+}
+
+// Channel registers a channel for sampling. length should return the
+// current length of the channel.
+func (c *Conn) Channel(name string, capacity int, length func() int) *Channel {
+	if c == nil {
+		return nil
+	}
+	ch := &Channel{
+		conn:     c,
+		name:     name,
+		capacity: capacity,
+		length:   length,
+		release:  make(chan string, 1),
+	}
+	c.mu.Lock()
+	if c.breaks[name] {
+		ch.breaking = 1
+	}
+	c.channels = append(c.channels, ch)
+	c.mu.Unlock()
+	return ch
+}
+
+// Close sends a final sample and closes the connection.
+func (c *Conn) Close() {
+	if c == nil {
+		return
+	}
+	close(c.stop)
+	<-c.done
+	c.sample()
+	if err := c.conn.Close(); err != nil {
+		log.Printf("Couldn't close connection to Shenzhen Go: %v", err)
+	}
+}
+
+func (c *Conn) sampleLoop() {
+	defer close(c.done)
+	t := time.NewTicker(SampleInterval)
+	defer t.Stop()
+	for {
+		select {
+		case <-t.C:
+			c.sample()
+		case <-c.stop:
+			return
+		}
+	}
+}
+
+// commandLoop handles commands from the server until the connection closes,
+// then releases any held values.
+func (c *Conn) commandLoop() {
+	dec := json.NewDecoder(c.conn)
+	for {
+		var cmd Command
+		if err := dec.Decode(&cmd); err != nil {
+			break
+		}
+		c.mu.Lock()
+		for _, ch := range c.channels {
+			if ch.name == cmd.Channel {
+				ch.handle(cmd.Op)
+			}
+		}
+		c.mu.Unlock()
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.closed = true
+	for _, ch := range c.channels {
+		ch.handle(OpUntap)
+		ch.handle(OpClear)
+	}
+}
+
+// sample sends the current stats for every channel.
+func (c *Conn) sample() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if len(c.channels) == 0 {
+		return
+	}
+	now := time.Now()
+	secs := now.Sub(c.last).Seconds()
+	c.last = now
+	msg := Message{Channels: make([]ChannelStats, len(c.channels))}
+	for i, ch := range c.channels {
+		sends := atomic.LoadUint64(&ch.sends)
+		cs := ChannelStats{
+			Name:  ch.name,
+			Len:   ch.length(),
+			Cap:   ch.capacity,
+			Sends: sends,
+		}
+		if secs > 0 {
+			cs.Rate = float64(sends-ch.lastSends) / secs
+		}
+		ch.lastSends = sends
+		msg.Channels[i] = cs
+	}
+	c.send(&msg)
+}
+
+// send sends a message. Must be called with c.mu held.
+func (c *Conn) send(msg *Message) {
+	if err := c.enc.Encode(msg); err != nil {
+		log.Printf("Couldn't send to Shenzhen Go: %v", err)
+	}
+}
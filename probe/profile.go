@@ -0,0 +1,72 @@
+// Copyright 2018 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package probe
+
+import (
+	"log"
+	"os"
+	"path/filepath"
+	"runtime"
+	"runtime/pprof"
+	"sync"
+)
+
+// Names of the profile files written by StartProfiling.
+const (
+	CPUProfileFile  = "cpu.pprof"
+	HeapProfileFile = "heap.pprof"
+)
+
+// StartProfiling starts CPU profiling, and returns a func that stops it and
+// writes a heap profile. Both profiles are written into dir. Only the first
+// call to the func does anything, so it can be both deferred and called on
+// the way to exiting early.
+func StartProfiling(dir string) (stop func()) {
+	cpu, err := os.Create(filepath.Join(dir, CPUProfileFile))
+	if err != nil {
+		log.Printf("Couldn't create CPU profile: %v", err)
+		return func() {}
+	}
+	if err := pprof.StartCPUProfile(cpu); err != nil {
+		log.Printf("Couldn't start CPU profile: %v", err)
+		cpu.Close()
+		return func() {}
+	}
+
+	var once sync.Once
+	stop = func() {
+		once.Do(func() {
+			pprof.StopCPUProfile()
+			if err := cpu.Close(); err != nil {
+				log.Printf("Couldn't write CPU profile: %v", err)
+			}
+			writeHeapProfile(filepath.Join(dir, HeapProfileFile))
+		})
+	}
+	return stop
+}
+
+func writeHeapProfile(path string) {
+	f, err := os.Create(path)
+	if err != nil {
+		log.Printf("Couldn't create heap profile: %v", err)
+		return
+	}
+	defer f.Close()
+	runtime.GC() // Bring the allocation statistics up to date.
+	if err := pprof.WriteHeapProfile(f); err != nil {
+		log.Printf("Couldn't write heap profile: %v", err)
+	}
+}
@@ -0,0 +1,127 @@
+// Copyright 2018 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package probe
+
+import (
+	"bufio"
+	"encoding/json"
+	"io"
+	"log"
+	"os"
+	"sync"
+	"time"
+)
+
+// Record is one value sent on a channel, as written to a recording. A
+// recording is a file containing one Record per line, in JSON.
+type Record struct {
+	Channel string          `json:"channel"`
+	Time    int64           `json:"time"` // Unix time in nanoseconds
+	Value   json.RawMessage `json:"value"`
+}
+
+// Recorder writes values sent on channels to a recording.
+// A nil *Recorder is valid, and does nothing.
+type Recorder struct {
+	mu  sync.Mutex
+	f   *os.File
+	w   *bufio.Writer
+	enc *json.Encoder
+}
+
+// StartRecording creates (or truncates) the file at path, for recording.
+// If the file can't be created, it logs the error and returns nil.
+func StartRecording(path string) *Recorder {
+	f, err := os.Create(path)
+	if err != nil {
+		log.Printf("Couldn't create recording: %v", err)
+		return nil
+	}
+	w := bufio.NewWriter(f)
+	return &Recorder{
+		f:   f,
+		w:   w,
+		enc: json.NewEncoder(w),
+	}
+}
+
+// Record writes a value sent on a channel to the recording.
+func (r *Recorder) Record(channel string, v interface{}) {
+	if r == nil {
+		return
+	}
+	rec := Record{Channel: channel, Time: time.Now().UnixNano()}
+	val, err := json.Marshal(v)
+	if err != nil {
+		log.Printf("Couldn't record value sent on %s: %v", channel, err)
+		return
+	}
+	rec.Value = val
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.enc == nil {
+		return // closed
+	}
+	if err := r.enc.Encode(&rec); err != nil {
+		log.Printf("Couldn't record value sent on %s: %v", channel, err)
+	}
+}
+
+// Close finishes writing the recording.
+func (r *Recorder) Close() {
+	if r == nil {
+		return
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.enc = nil
+	if err := r.w.Flush(); err != nil {
+		log.Printf("Couldn't write recording: %v", err)
+	}
+	if err := r.f.Close(); err != nil {
+		log.Printf("Couldn't close recording: %v", err)
+	}
+}
+
+// Replay reads the values recorded for one channel from the recording at
+// path, and calls send with a function that decodes each value in turn.
+// It stops at the end of the recording, or at the first error, which it
+// logs.
+func Replay(path, channel string, send func(decode func(interface{}) error) error) {
+	f, err := os.Open(path)
+	if err != nil {
+		log.Printf("Couldn't open recording: %v", err)
+		return
+	}
+	defer f.Close()
+	dec := json.NewDecoder(bufio.NewReader(f))
+	for {
+		var rec Record
+		if err := dec.Decode(&rec); err != nil {
+			if err != io.EOF {
+				log.Printf("Couldn't read recording: %v", err)
+			}
+			return
+		}
+		if rec.Channel != channel {
+			continue
+		}
+		decode := func(v interface{}) error { return json.Unmarshal(rec.Value, v) }
+		if err := send(decode); err != nil {
+			log.Printf("Couldn't replay value for %s: %v", channel, err)
+			return
+		}
+	}
+}
@@ -26,11 +26,16 @@ const _ = proto.ProtoPackageIsVersion2 // please upgrade the proto package
 type ActionRequest_Action int32
 
 const (
-	ActionRequest_SAVE     ActionRequest_Action = 0
-	ActionRequest_REVERT   ActionRequest_Action = 1
-	ActionRequest_GENERATE ActionRequest_Action = 2
-	ActionRequest_BUILD    ActionRequest_Action = 3
-	ActionRequest_INSTALL  ActionRequest_Action = 4
+	ActionRequest_SAVE       ActionRequest_Action = 0
+	ActionRequest_REVERT     ActionRequest_Action = 1
+	ActionRequest_GENERATE   ActionRequest_Action = 2
+	ActionRequest_BUILD      ActionRequest_Action = 3
+	ActionRequest_INSTALL    ActionRequest_Action = 4
+	ActionRequest_RESTORE    ActionRequest_Action = 5
+	ActionRequest_DISCARD    ActionRequest_Action = 6
+	ActionRequest_TEST       ActionRequest_Action = 7
+	ActionRequest_TEST_STUBS ActionRequest_Action = 8
+	ActionRequest_BENCH      ActionRequest_Action = 9
 )
 
 var ActionRequest_Action_name = map[int32]string{
@@ -39,20 +44,62 @@ var ActionRequest_Action_name = map[int32]string{
 	2: "GENERATE",
 	3: "BUILD",
 	4: "INSTALL",
+	5: "RESTORE",
+	6: "DISCARD",
+	7: "TEST",
+	8: "TEST_STUBS",
+	9: "BENCH",
 }
 var ActionRequest_Action_value = map[string]int32{
-	"SAVE":     0,
-	"REVERT":   1,
-	"GENERATE": 2,
-	"BUILD":    3,
-	"INSTALL":  4,
+	"SAVE":       0,
+	"REVERT":     1,
+	"GENERATE":   2,
+	"BUILD":      3,
+	"INSTALL":    4,
+	"RESTORE":    5,
+	"DISCARD":    6,
+	"TEST":       7,
+	"TEST_STUBS": 8,
+	"BENCH":      9,
 }
 
 func (x ActionRequest_Action) String() string {
 	return proto.EnumName(ActionRequest_Action_name, int32(x))
 }
 func (ActionRequest_Action) EnumDescriptor() ([]byte, []int) {
-	return fileDescriptor_shenzhen_go_017a25db36f90c92, []int{4, 0}
+	return fileDescriptor_shenzhen_go_a331d73bb9c4cf31, []int{4, 0}
+}
+
+type DebugCommand_Op int32
+
+const (
+	DebugCommand_BREAK    DebugCommand_Op = 0
+	DebugCommand_CLEAR    DebugCommand_Op = 1
+	DebugCommand_STEP     DebugCommand_Op = 2
+	DebugCommand_CONTINUE DebugCommand_Op = 3
+	DebugCommand_DROP     DebugCommand_Op = 4
+)
+
+var DebugCommand_Op_name = map[int32]string{
+	0: "BREAK",
+	1: "CLEAR",
+	2: "STEP",
+	3: "CONTINUE",
+	4: "DROP",
+}
+var DebugCommand_Op_value = map[string]int32{
+	"BREAK":    0,
+	"CLEAR":    1,
+	"STEP":     2,
+	"CONTINUE": 3,
+	"DROP":     4,
+}
+
+func (x DebugCommand_Op) String() string {
+	return proto.EnumName(DebugCommand_Op_name, int32(x))
+}
+func (DebugCommand_Op) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_shenzhen_go_a331d73bb9c4cf31, []int{8, 0}
 }
 
 type Empty struct {
@@ -65,7 +112,7 @@ func (m *Empty) Reset()         { *m = Empty{} }
 func (m *Empty) String() string { return proto.CompactTextString(m) }
 func (*Empty) ProtoMessage()    {}
 func (*Empty) Descriptor() ([]byte, []int) {
-	return fileDescriptor_shenzhen_go_017a25db36f90c92, []int{0}
+	return fileDescriptor_shenzhen_go_a331d73bb9c4cf31, []int{0}
 }
 func (m *Empty) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_Empty.Unmarshal(m, b)
@@ -97,7 +144,7 @@ func (m *NodePin) Reset()         { *m = NodePin{} }
 func (m *NodePin) String() string { return proto.CompactTextString(m) }
 func (*NodePin) ProtoMessage()    {}
 func (*NodePin) Descriptor() ([]byte, []int) {
-	return fileDescriptor_shenzhen_go_017a25db36f90c92, []int{1}
+	return fileDescriptor_shenzhen_go_a331d73bb9c4cf31, []int{1}
 }
 func (m *NodePin) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_NodePin.Unmarshal(m, b)
@@ -144,7 +191,7 @@ func (m *ChannelConfig) Reset()         { *m = ChannelConfig{} }
 func (m *ChannelConfig) String() string { return proto.CompactTextString(m) }
 func (*ChannelConfig) ProtoMessage()    {}
 func (*ChannelConfig) Descriptor() ([]byte, []int) {
-	return fileDescriptor_shenzhen_go_017a25db36f90c92, []int{2}
+	return fileDescriptor_shenzhen_go_a331d73bb9c4cf31, []int{2}
 }
 func (m *ChannelConfig) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_ChannelConfig.Unmarshal(m, b)
@@ -195,6 +242,9 @@ type NodeConfig struct {
 	PartType             string   `protobuf:"bytes,7,opt,name=part_type,json=partType,proto3" json:"part_type,omitempty"`
 	X                    float64  `protobuf:"fixed64,8,opt,name=x,proto3" json:"x,omitempty"`
 	Y                    float64  `protobuf:"fixed64,9,opt,name=y,proto3" json:"y,omitempty"`
+	PanicPolicy          string   `protobuf:"bytes,10,opt,name=panic_policy,json=panicPolicy,proto3" json:"panic_policy,omitempty"`
+	MaxRestarts          int64    `protobuf:"varint,11,opt,name=max_restarts,json=maxRestarts,proto3" json:"max_restarts,omitempty"`
+	Configurable         []string `protobuf:"bytes,12,rep,name=configurable,proto3" json:"configurable,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -204,7 +254,7 @@ func (m *NodeConfig) Reset()         { *m = NodeConfig{} }
 func (m *NodeConfig) String() string { return proto.CompactTextString(m) }
 func (*NodeConfig) ProtoMessage()    {}
 func (*NodeConfig) Descriptor() ([]byte, []int) {
-	return fileDescriptor_shenzhen_go_017a25db36f90c92, []int{3}
+	return fileDescriptor_shenzhen_go_a331d73bb9c4cf31, []int{3}
 }
 func (m *NodeConfig) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_NodeConfig.Unmarshal(m, b)
@@ -287,9 +337,31 @@ func (m *NodeConfig) GetY() float64 {
 	return 0
 }
 
+func (m *NodeConfig) GetPanicPolicy() string {
+	if m != nil {
+		return m.PanicPolicy
+	}
+	return ""
+}
+
+func (m *NodeConfig) GetMaxRestarts() int64 {
+	if m != nil {
+		return m.MaxRestarts
+	}
+	return 0
+}
+
+func (m *NodeConfig) GetConfigurable() []string {
+	if m != nil {
+		return m.Configurable
+	}
+	return nil
+}
+
 type ActionRequest struct {
 	Graph                string               `protobuf:"bytes,1,opt,name=graph,proto3" json:"graph,omitempty"`
 	Action               ActionRequest_Action `protobuf:"varint,2,opt,name=action,proto3,enum=proto.ActionRequest_Action" json:"action,omitempty"`
+	Race                 bool                 `protobuf:"varint,3,opt,name=race,proto3" json:"race,omitempty"`
 	XXX_NoUnkeyedLiteral struct{}             `json:"-"`
 	XXX_unrecognized     []byte               `json:"-"`
 	XXX_sizecache        int32                `json:"-"`
@@ -299,7 +371,7 @@ func (m *ActionRequest) Reset()         { *m = ActionRequest{} }
 func (m *ActionRequest) String() string { return proto.CompactTextString(m) }
 func (*ActionRequest) ProtoMessage()    {}
 func (*ActionRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_shenzhen_go_017a25db36f90c92, []int{4}
+	return fileDescriptor_shenzhen_go_a331d73bb9c4cf31, []int{4}
 }
 func (m *ActionRequest) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_ActionRequest.Unmarshal(m, b)
@@ -333,18 +405,89 @@ func (m *ActionRequest) GetAction() ActionRequest_Action {
 	return ActionRequest_SAVE
 }
 
-type ActionResponse struct {
-	Output               string   `protobuf:"bytes,1,opt,name=output,proto3" json:"output,omitempty"`
+func (m *ActionRequest) GetRace() bool {
+	if m != nil {
+		return m.Race
+	}
+	return false
+}
+
+// Diagnostic is a problem found in part of a node's implementation.
+type Diagnostic struct {
+	Node                 string   `protobuf:"bytes,1,opt,name=node,proto3" json:"node,omitempty"`
+	Section              string   `protobuf:"bytes,2,opt,name=section,proto3" json:"section,omitempty"`
+	Line                 int64    `protobuf:"varint,3,opt,name=line,proto3" json:"line,omitempty"`
+	Message              string   `protobuf:"bytes,4,opt,name=message,proto3" json:"message,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
 }
 
+func (m *Diagnostic) Reset()         { *m = Diagnostic{} }
+func (m *Diagnostic) String() string { return proto.CompactTextString(m) }
+func (*Diagnostic) ProtoMessage()    {}
+func (*Diagnostic) Descriptor() ([]byte, []int) {
+	return fileDescriptor_shenzhen_go_a331d73bb9c4cf31, []int{5}
+}
+func (m *Diagnostic) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Diagnostic.Unmarshal(m, b)
+}
+func (m *Diagnostic) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Diagnostic.Marshal(b, m, deterministic)
+}
+func (dst *Diagnostic) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Diagnostic.Merge(dst, src)
+}
+func (m *Diagnostic) XXX_Size() int {
+	return xxx_messageInfo_Diagnostic.Size(m)
+}
+func (m *Diagnostic) XXX_DiscardUnknown() {
+	xxx_messageInfo_Diagnostic.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Diagnostic proto.InternalMessageInfo
+
+func (m *Diagnostic) GetNode() string {
+	if m != nil {
+		return m.Node
+	}
+	return ""
+}
+
+func (m *Diagnostic) GetSection() string {
+	if m != nil {
+		return m.Section
+	}
+	return ""
+}
+
+func (m *Diagnostic) GetLine() int64 {
+	if m != nil {
+		return m.Line
+	}
+	return 0
+}
+
+func (m *Diagnostic) GetMessage() string {
+	if m != nil {
+		return m.Message
+	}
+	return ""
+}
+
+type ActionResponse struct {
+	Output               string        `protobuf:"bytes,1,opt,name=output,proto3" json:"output,omitempty"`
+	Diagnostics          []*Diagnostic `protobuf:"bytes,2,rep,name=diagnostics,proto3" json:"diagnostics,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}      `json:"-"`
+	XXX_unrecognized     []byte        `json:"-"`
+	XXX_sizecache        int32         `json:"-"`
+}
+
 func (m *ActionResponse) Reset()         { *m = ActionResponse{} }
 func (m *ActionResponse) String() string { return proto.CompactTextString(m) }
 func (*ActionResponse) ProtoMessage()    {}
 func (*ActionResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_shenzhen_go_017a25db36f90c92, []int{5}
+	return fileDescriptor_shenzhen_go_a331d73bb9c4cf31, []int{6}
 }
 func (m *ActionResponse) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_ActionResponse.Unmarshal(m, b)
@@ -352,115 +495,848 @@ func (m *ActionResponse) XXX_Unmarshal(b []byte) error {
 func (m *ActionResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
 	return xxx_messageInfo_ActionResponse.Marshal(b, m, deterministic)
 }
-func (dst *ActionResponse) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_ActionResponse.Merge(dst, src)
+func (dst *ActionResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ActionResponse.Merge(dst, src)
+}
+func (m *ActionResponse) XXX_Size() int {
+	return xxx_messageInfo_ActionResponse.Size(m)
+}
+func (m *ActionResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_ActionResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ActionResponse proto.InternalMessageInfo
+
+func (m *ActionResponse) GetOutput() string {
+	if m != nil {
+		return m.Output
+	}
+	return ""
+}
+
+func (m *ActionResponse) GetDiagnostics() []*Diagnostic {
+	if m != nil {
+		return m.Diagnostics
+	}
+	return nil
+}
+
+type RunConfig struct {
+	Args                 []string `protobuf:"bytes,1,rep,name=args,proto3" json:"args,omitempty"`
+	Env                  []string `protobuf:"bytes,2,rep,name=env,proto3" json:"env,omitempty"`
+	Dir                  string   `protobuf:"bytes,3,opt,name=dir,proto3" json:"dir,omitempty"`
+	Race                 bool     `protobuf:"varint,4,opt,name=race,proto3" json:"race,omitempty"`
+	Profile              bool     `protobuf:"varint,5,opt,name=profile,proto3" json:"profile,omitempty"`
+	Instrument           bool     `protobuf:"varint,6,opt,name=instrument,proto3" json:"instrument,omitempty"`
+	Debug                bool     `protobuf:"varint,7,opt,name=debug,proto3" json:"debug,omitempty"`
+	Record               []string `protobuf:"bytes,8,rep,name=record,proto3" json:"record,omitempty"`
+	RecordFile           string   `protobuf:"bytes,9,opt,name=record_file,json=recordFile,proto3" json:"record_file,omitempty"`
+	Replay               []string `protobuf:"bytes,10,rep,name=replay,proto3" json:"replay,omitempty"`
+	ReplayFile           string   `protobuf:"bytes,11,opt,name=replay_file,json=replayFile,proto3" json:"replay_file,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *RunConfig) Reset()         { *m = RunConfig{} }
+func (m *RunConfig) String() string { return proto.CompactTextString(m) }
+func (*RunConfig) ProtoMessage()    {}
+func (*RunConfig) Descriptor() ([]byte, []int) {
+	return fileDescriptor_shenzhen_go_a331d73bb9c4cf31, []int{7}
+}
+func (m *RunConfig) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_RunConfig.Unmarshal(m, b)
+}
+func (m *RunConfig) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_RunConfig.Marshal(b, m, deterministic)
+}
+func (dst *RunConfig) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RunConfig.Merge(dst, src)
+}
+func (m *RunConfig) XXX_Size() int {
+	return xxx_messageInfo_RunConfig.Size(m)
+}
+func (m *RunConfig) XXX_DiscardUnknown() {
+	xxx_messageInfo_RunConfig.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_RunConfig proto.InternalMessageInfo
+
+func (m *RunConfig) GetArgs() []string {
+	if m != nil {
+		return m.Args
+	}
+	return nil
+}
+
+func (m *RunConfig) GetEnv() []string {
+	if m != nil {
+		return m.Env
+	}
+	return nil
+}
+
+func (m *RunConfig) GetDir() string {
+	if m != nil {
+		return m.Dir
+	}
+	return ""
+}
+
+func (m *RunConfig) GetRace() bool {
+	if m != nil {
+		return m.Race
+	}
+	return false
+}
+
+func (m *RunConfig) GetProfile() bool {
+	if m != nil {
+		return m.Profile
+	}
+	return false
+}
+
+func (m *RunConfig) GetInstrument() bool {
+	if m != nil {
+		return m.Instrument
+	}
+	return false
+}
+
+func (m *RunConfig) GetDebug() bool {
+	if m != nil {
+		return m.Debug
+	}
+	return false
+}
+
+func (m *RunConfig) GetRecord() []string {
+	if m != nil {
+		return m.Record
+	}
+	return nil
+}
+
+func (m *RunConfig) GetRecordFile() string {
+	if m != nil {
+		return m.RecordFile
+	}
+	return ""
+}
+
+func (m *RunConfig) GetReplay() []string {
+	if m != nil {
+		return m.Replay
+	}
+	return nil
+}
+
+func (m *RunConfig) GetReplayFile() string {
+	if m != nil {
+		return m.ReplayFile
+	}
+	return ""
+}
+
+// DebugCommand controls a breakpoint on a channel in a debug run.
+type DebugCommand struct {
+	Channel              string          `protobuf:"bytes,1,opt,name=channel,proto3" json:"channel,omitempty"`
+	Op                   DebugCommand_Op `protobuf:"varint,2,opt,name=op,proto3,enum=proto.DebugCommand_Op" json:"op,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}        `json:"-"`
+	XXX_unrecognized     []byte          `json:"-"`
+	XXX_sizecache        int32           `json:"-"`
+}
+
+func (m *DebugCommand) Reset()         { *m = DebugCommand{} }
+func (m *DebugCommand) String() string { return proto.CompactTextString(m) }
+func (*DebugCommand) ProtoMessage()    {}
+func (*DebugCommand) Descriptor() ([]byte, []int) {
+	return fileDescriptor_shenzhen_go_a331d73bb9c4cf31, []int{8}
+}
+func (m *DebugCommand) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_DebugCommand.Unmarshal(m, b)
+}
+func (m *DebugCommand) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_DebugCommand.Marshal(b, m, deterministic)
+}
+func (dst *DebugCommand) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_DebugCommand.Merge(dst, src)
+}
+func (m *DebugCommand) XXX_Size() int {
+	return xxx_messageInfo_DebugCommand.Size(m)
+}
+func (m *DebugCommand) XXX_DiscardUnknown() {
+	xxx_messageInfo_DebugCommand.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_DebugCommand proto.InternalMessageInfo
+
+func (m *DebugCommand) GetChannel() string {
+	if m != nil {
+		return m.Channel
+	}
+	return ""
+}
+
+func (m *DebugCommand) GetOp() DebugCommand_Op {
+	if m != nil {
+		return m.Op
+	}
+	return DebugCommand_BREAK
+}
+
+type Input struct {
+	Graph                string        `protobuf:"bytes,1,opt,name=graph,proto3" json:"graph,omitempty"`
+	In                   string        `protobuf:"bytes,2,opt,name=in,proto3" json:"in,omitempty"`
+	Config               *RunConfig    `protobuf:"bytes,3,opt,name=config,proto3" json:"config,omitempty"`
+	RunConfig            string        `protobuf:"bytes,4,opt,name=run_config,json=runConfig,proto3" json:"run_config,omitempty"`
+	RunId                string        `protobuf:"bytes,5,opt,name=run_id,json=runId,proto3" json:"run_id,omitempty"`
+	Debug                *DebugCommand `protobuf:"bytes,6,opt,name=debug,proto3" json:"debug,omitempty"`
+	Breakpoints          []string      `protobuf:"bytes,7,rep,name=breakpoints,proto3" json:"breakpoints,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}      `json:"-"`
+	XXX_unrecognized     []byte        `json:"-"`
+	XXX_sizecache        int32         `json:"-"`
+}
+
+func (m *Input) Reset()         { *m = Input{} }
+func (m *Input) String() string { return proto.CompactTextString(m) }
+func (*Input) ProtoMessage()    {}
+func (*Input) Descriptor() ([]byte, []int) {
+	return fileDescriptor_shenzhen_go_a331d73bb9c4cf31, []int{9}
+}
+func (m *Input) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Input.Unmarshal(m, b)
+}
+func (m *Input) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Input.Marshal(b, m, deterministic)
+}
+func (dst *Input) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Input.Merge(dst, src)
+}
+func (m *Input) XXX_Size() int {
+	return xxx_messageInfo_Input.Size(m)
+}
+func (m *Input) XXX_DiscardUnknown() {
+	xxx_messageInfo_Input.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Input proto.InternalMessageInfo
+
+func (m *Input) GetGraph() string {
+	if m != nil {
+		return m.Graph
+	}
+	return ""
+}
+
+func (m *Input) GetIn() string {
+	if m != nil {
+		return m.In
+	}
+	return ""
+}
+
+func (m *Input) GetConfig() *RunConfig {
+	if m != nil {
+		return m.Config
+	}
+	return nil
+}
+
+func (m *Input) GetRunConfig() string {
+	if m != nil {
+		return m.RunConfig
+	}
+	return ""
+}
+
+func (m *Input) GetRunId() string {
+	if m != nil {
+		return m.RunId
+	}
+	return ""
+}
+
+func (m *Input) GetDebug() *DebugCommand {
+	if m != nil {
+		return m.Debug
+	}
+	return nil
+}
+
+func (m *Input) GetBreakpoints() []string {
+	if m != nil {
+		return m.Breakpoints
+	}
+	return nil
+}
+
+type Output struct {
+	Out                  string          `protobuf:"bytes,1,opt,name=out,proto3" json:"out,omitempty"`
+	Err                  string          `protobuf:"bytes,2,opt,name=err,proto3" json:"err,omitempty"`
+	RunId                string          `protobuf:"bytes,3,opt,name=run_id,json=runId,proto3" json:"run_id,omitempty"`
+	Diagnostics          []*Diagnostic   `protobuf:"bytes,4,rep,name=diagnostics,proto3" json:"diagnostics,omitempty"`
+	Profile              []*NodeProfile  `protobuf:"bytes,5,rep,name=profile,proto3" json:"profile,omitempty"`
+	Channels             []*ChannelStats `protobuf:"bytes,6,rep,name=channels,proto3" json:"channels,omitempty"`
+	Held                 *HeldValue      `protobuf:"bytes,7,opt,name=held,proto3" json:"held,omitempty"`
+	Released             string          `protobuf:"bytes,8,opt,name=released,proto3" json:"released,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}        `json:"-"`
+	XXX_unrecognized     []byte          `json:"-"`
+	XXX_sizecache        int32           `json:"-"`
+}
+
+func (m *Output) Reset()         { *m = Output{} }
+func (m *Output) String() string { return proto.CompactTextString(m) }
+func (*Output) ProtoMessage()    {}
+func (*Output) Descriptor() ([]byte, []int) {
+	return fileDescriptor_shenzhen_go_a331d73bb9c4cf31, []int{10}
+}
+func (m *Output) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Output.Unmarshal(m, b)
+}
+func (m *Output) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Output.Marshal(b, m, deterministic)
+}
+func (dst *Output) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Output.Merge(dst, src)
+}
+func (m *Output) XXX_Size() int {
+	return xxx_messageInfo_Output.Size(m)
+}
+func (m *Output) XXX_DiscardUnknown() {
+	xxx_messageInfo_Output.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Output proto.InternalMessageInfo
+
+func (m *Output) GetOut() string {
+	if m != nil {
+		return m.Out
+	}
+	return ""
+}
+
+func (m *Output) GetErr() string {
+	if m != nil {
+		return m.Err
+	}
+	return ""
+}
+
+func (m *Output) GetRunId() string {
+	if m != nil {
+		return m.RunId
+	}
+	return ""
+}
+
+func (m *Output) GetDiagnostics() []*Diagnostic {
+	if m != nil {
+		return m.Diagnostics
+	}
+	return nil
+}
+
+func (m *Output) GetProfile() []*NodeProfile {
+	if m != nil {
+		return m.Profile
+	}
+	return nil
+}
+
+func (m *Output) GetChannels() []*ChannelStats {
+	if m != nil {
+		return m.Channels
+	}
+	return nil
+}
+
+func (m *Output) GetHeld() *HeldValue {
+	if m != nil {
+		return m.Held
+	}
+	return nil
+}
+
+func (m *Output) GetReleased() string {
+	if m != nil {
+		return m.Released
+	}
+	return ""
+}
+
+// HeldValue is a value held at a breakpoint on a channel.
+type HeldValue struct {
+	Channel              string   `protobuf:"bytes,1,opt,name=channel,proto3" json:"channel,omitempty"`
+	Value                string   `protobuf:"bytes,2,opt,name=value,proto3" json:"value,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *HeldValue) Reset()         { *m = HeldValue{} }
+func (m *HeldValue) String() string { return proto.CompactTextString(m) }
+func (*HeldValue) ProtoMessage()    {}
+func (*HeldValue) Descriptor() ([]byte, []int) {
+	return fileDescriptor_shenzhen_go_a331d73bb9c4cf31, []int{11}
+}
+func (m *HeldValue) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_HeldValue.Unmarshal(m, b)
+}
+func (m *HeldValue) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_HeldValue.Marshal(b, m, deterministic)
+}
+func (dst *HeldValue) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_HeldValue.Merge(dst, src)
+}
+func (m *HeldValue) XXX_Size() int {
+	return xxx_messageInfo_HeldValue.Size(m)
+}
+func (m *HeldValue) XXX_DiscardUnknown() {
+	xxx_messageInfo_HeldValue.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_HeldValue proto.InternalMessageInfo
+
+func (m *HeldValue) GetChannel() string {
+	if m != nil {
+		return m.Channel
+	}
+	return ""
+}
+
+func (m *HeldValue) GetValue() string {
+	if m != nil {
+		return m.Value
+	}
+	return ""
+}
+
+// NodeProfile is the profile data attributed to one node.
+type NodeProfile struct {
+	Node                 string   `protobuf:"bytes,1,opt,name=node,proto3" json:"node,omitempty"`
+	CpuNanos             int64    `protobuf:"varint,2,opt,name=cpu_nanos,json=cpuNanos,proto3" json:"cpu_nanos,omitempty"`
+	AllocBytes           int64    `protobuf:"varint,3,opt,name=alloc_bytes,json=allocBytes,proto3" json:"alloc_bytes,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *NodeProfile) Reset()         { *m = NodeProfile{} }
+func (m *NodeProfile) String() string { return proto.CompactTextString(m) }
+func (*NodeProfile) ProtoMessage()    {}
+func (*NodeProfile) Descriptor() ([]byte, []int) {
+	return fileDescriptor_shenzhen_go_a331d73bb9c4cf31, []int{12}
+}
+func (m *NodeProfile) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_NodeProfile.Unmarshal(m, b)
+}
+func (m *NodeProfile) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_NodeProfile.Marshal(b, m, deterministic)
+}
+func (dst *NodeProfile) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_NodeProfile.Merge(dst, src)
+}
+func (m *NodeProfile) XXX_Size() int {
+	return xxx_messageInfo_NodeProfile.Size(m)
+}
+func (m *NodeProfile) XXX_DiscardUnknown() {
+	xxx_messageInfo_NodeProfile.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_NodeProfile proto.InternalMessageInfo
+
+func (m *NodeProfile) GetNode() string {
+	if m != nil {
+		return m.Node
+	}
+	return ""
+}
+
+func (m *NodeProfile) GetCpuNanos() int64 {
+	if m != nil {
+		return m.CpuNanos
+	}
+	return 0
+}
+
+func (m *NodeProfile) GetAllocBytes() int64 {
+	if m != nil {
+		return m.AllocBytes
+	}
+	return 0
+}
+
+// ChannelStats is a sample of the activity of one channel.
+type ChannelStats struct {
+	Channel              string   `protobuf:"bytes,1,opt,name=channel,proto3" json:"channel,omitempty"`
+	Len                  int64    `protobuf:"varint,2,opt,name=len,proto3" json:"len,omitempty"`
+	Cap                  int64    `protobuf:"varint,3,opt,name=cap,proto3" json:"cap,omitempty"`
+	Sends                int64    `protobuf:"varint,4,opt,name=sends,proto3" json:"sends,omitempty"`
+	Rate                 float64  `protobuf:"fixed64,5,opt,name=rate,proto3" json:"rate,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ChannelStats) Reset()         { *m = ChannelStats{} }
+func (m *ChannelStats) String() string { return proto.CompactTextString(m) }
+func (*ChannelStats) ProtoMessage()    {}
+func (*ChannelStats) Descriptor() ([]byte, []int) {
+	return fileDescriptor_shenzhen_go_a331d73bb9c4cf31, []int{13}
+}
+func (m *ChannelStats) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ChannelStats.Unmarshal(m, b)
+}
+func (m *ChannelStats) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ChannelStats.Marshal(b, m, deterministic)
+}
+func (dst *ChannelStats) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ChannelStats.Merge(dst, src)
+}
+func (m *ChannelStats) XXX_Size() int {
+	return xxx_messageInfo_ChannelStats.Size(m)
+}
+func (m *ChannelStats) XXX_DiscardUnknown() {
+	xxx_messageInfo_ChannelStats.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ChannelStats proto.InternalMessageInfo
+
+func (m *ChannelStats) GetChannel() string {
+	if m != nil {
+		return m.Channel
+	}
+	return ""
+}
+
+func (m *ChannelStats) GetLen() int64 {
+	if m != nil {
+		return m.Len
+	}
+	return 0
+}
+
+func (m *ChannelStats) GetCap() int64 {
+	if m != nil {
+		return m.Cap
+	}
+	return 0
+}
+
+func (m *ChannelStats) GetSends() int64 {
+	if m != nil {
+		return m.Sends
+	}
+	return 0
+}
+
+func (m *ChannelStats) GetRate() float64 {
+	if m != nil {
+		return m.Rate
+	}
+	return 0
+}
+
+type RunInfo struct {
+	Id                   string   `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Graph                string   `protobuf:"bytes,2,opt,name=graph,proto3" json:"graph,omitempty"`
+	Args                 []string `protobuf:"bytes,3,rep,name=args,proto3" json:"args,omitempty"`
+	StartTime            int64    `protobuf:"varint,4,opt,name=start_time,json=startTime,proto3" json:"start_time,omitempty"`
+	Running              bool     `protobuf:"varint,5,opt,name=running,proto3" json:"running,omitempty"`
+	ExitStatus           string   `protobuf:"bytes,6,opt,name=exit_status,json=exitStatus,proto3" json:"exit_status,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *RunInfo) Reset()         { *m = RunInfo{} }
+func (m *RunInfo) String() string { return proto.CompactTextString(m) }
+func (*RunInfo) ProtoMessage()    {}
+func (*RunInfo) Descriptor() ([]byte, []int) {
+	return fileDescriptor_shenzhen_go_a331d73bb9c4cf31, []int{14}
+}
+func (m *RunInfo) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_RunInfo.Unmarshal(m, b)
+}
+func (m *RunInfo) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_RunInfo.Marshal(b, m, deterministic)
+}
+func (dst *RunInfo) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RunInfo.Merge(dst, src)
+}
+func (m *RunInfo) XXX_Size() int {
+	return xxx_messageInfo_RunInfo.Size(m)
+}
+func (m *RunInfo) XXX_DiscardUnknown() {
+	xxx_messageInfo_RunInfo.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_RunInfo proto.InternalMessageInfo
+
+func (m *RunInfo) GetId() string {
+	if m != nil {
+		return m.Id
+	}
+	return ""
+}
+
+func (m *RunInfo) GetGraph() string {
+	if m != nil {
+		return m.Graph
+	}
+	return ""
+}
+
+func (m *RunInfo) GetArgs() []string {
+	if m != nil {
+		return m.Args
+	}
+	return nil
+}
+
+func (m *RunInfo) GetStartTime() int64 {
+	if m != nil {
+		return m.StartTime
+	}
+	return 0
+}
+
+func (m *RunInfo) GetRunning() bool {
+	if m != nil {
+		return m.Running
+	}
+	return false
+}
+
+func (m *RunInfo) GetExitStatus() string {
+	if m != nil {
+		return m.ExitStatus
+	}
+	return ""
+}
+
+type ListRunsRequest struct {
+	Graph                string   `protobuf:"bytes,1,opt,name=graph,proto3" json:"graph,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ListRunsRequest) Reset()         { *m = ListRunsRequest{} }
+func (m *ListRunsRequest) String() string { return proto.CompactTextString(m) }
+func (*ListRunsRequest) ProtoMessage()    {}
+func (*ListRunsRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_shenzhen_go_a331d73bb9c4cf31, []int{15}
+}
+func (m *ListRunsRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ListRunsRequest.Unmarshal(m, b)
+}
+func (m *ListRunsRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ListRunsRequest.Marshal(b, m, deterministic)
+}
+func (dst *ListRunsRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ListRunsRequest.Merge(dst, src)
+}
+func (m *ListRunsRequest) XXX_Size() int {
+	return xxx_messageInfo_ListRunsRequest.Size(m)
+}
+func (m *ListRunsRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ListRunsRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ListRunsRequest proto.InternalMessageInfo
+
+func (m *ListRunsRequest) GetGraph() string {
+	if m != nil {
+		return m.Graph
+	}
+	return ""
+}
+
+type ListRunsResponse struct {
+	Runs                 []*RunInfo `protobuf:"bytes,1,rep,name=runs,proto3" json:"runs,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}   `json:"-"`
+	XXX_unrecognized     []byte     `json:"-"`
+	XXX_sizecache        int32      `json:"-"`
+}
+
+func (m *ListRunsResponse) Reset()         { *m = ListRunsResponse{} }
+func (m *ListRunsResponse) String() string { return proto.CompactTextString(m) }
+func (*ListRunsResponse) ProtoMessage()    {}
+func (*ListRunsResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_shenzhen_go_a331d73bb9c4cf31, []int{16}
+}
+func (m *ListRunsResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ListRunsResponse.Unmarshal(m, b)
+}
+func (m *ListRunsResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ListRunsResponse.Marshal(b, m, deterministic)
+}
+func (dst *ListRunsResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ListRunsResponse.Merge(dst, src)
+}
+func (m *ListRunsResponse) XXX_Size() int {
+	return xxx_messageInfo_ListRunsResponse.Size(m)
+}
+func (m *ListRunsResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_ListRunsResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ListRunsResponse proto.InternalMessageInfo
+
+func (m *ListRunsResponse) GetRuns() []*RunInfo {
+	if m != nil {
+		return m.Runs
+	}
+	return nil
+}
+
+type KillRunRequest struct {
+	Id                   string   `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Force                bool     `protobuf:"varint,2,opt,name=force,proto3" json:"force,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *KillRunRequest) Reset()         { *m = KillRunRequest{} }
+func (m *KillRunRequest) String() string { return proto.CompactTextString(m) }
+func (*KillRunRequest) ProtoMessage()    {}
+func (*KillRunRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_shenzhen_go_a331d73bb9c4cf31, []int{17}
+}
+func (m *KillRunRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_KillRunRequest.Unmarshal(m, b)
+}
+func (m *KillRunRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_KillRunRequest.Marshal(b, m, deterministic)
+}
+func (dst *KillRunRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_KillRunRequest.Merge(dst, src)
 }
-func (m *ActionResponse) XXX_Size() int {
-	return xxx_messageInfo_ActionResponse.Size(m)
+func (m *KillRunRequest) XXX_Size() int {
+	return xxx_messageInfo_KillRunRequest.Size(m)
 }
-func (m *ActionResponse) XXX_DiscardUnknown() {
-	xxx_messageInfo_ActionResponse.DiscardUnknown(m)
+func (m *KillRunRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_KillRunRequest.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_ActionResponse proto.InternalMessageInfo
+var xxx_messageInfo_KillRunRequest proto.InternalMessageInfo
 
-func (m *ActionResponse) GetOutput() string {
+func (m *KillRunRequest) GetId() string {
 	if m != nil {
-		return m.Output
+		return m.Id
 	}
 	return ""
 }
 
-type Input struct {
+func (m *KillRunRequest) GetForce() bool {
+	if m != nil {
+		return m.Force
+	}
+	return false
+}
+
+type TapRequest struct {
 	Graph                string   `protobuf:"bytes,1,opt,name=graph,proto3" json:"graph,omitempty"`
-	In                   string   `protobuf:"bytes,2,opt,name=in,proto3" json:"in,omitempty"`
+	Channel              string   `protobuf:"bytes,2,opt,name=channel,proto3" json:"channel,omitempty"`
+	RunId                string   `protobuf:"bytes,3,opt,name=run_id,json=runId,proto3" json:"run_id,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
 }
 
-func (m *Input) Reset()         { *m = Input{} }
-func (m *Input) String() string { return proto.CompactTextString(m) }
-func (*Input) ProtoMessage()    {}
-func (*Input) Descriptor() ([]byte, []int) {
-	return fileDescriptor_shenzhen_go_017a25db36f90c92, []int{6}
+func (m *TapRequest) Reset()         { *m = TapRequest{} }
+func (m *TapRequest) String() string { return proto.CompactTextString(m) }
+func (*TapRequest) ProtoMessage()    {}
+func (*TapRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_shenzhen_go_a331d73bb9c4cf31, []int{18}
 }
-func (m *Input) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_Input.Unmarshal(m, b)
+func (m *TapRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_TapRequest.Unmarshal(m, b)
 }
-func (m *Input) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_Input.Marshal(b, m, deterministic)
+func (m *TapRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_TapRequest.Marshal(b, m, deterministic)
 }
-func (dst *Input) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_Input.Merge(dst, src)
+func (dst *TapRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_TapRequest.Merge(dst, src)
 }
-func (m *Input) XXX_Size() int {
-	return xxx_messageInfo_Input.Size(m)
+func (m *TapRequest) XXX_Size() int {
+	return xxx_messageInfo_TapRequest.Size(m)
 }
-func (m *Input) XXX_DiscardUnknown() {
-	xxx_messageInfo_Input.DiscardUnknown(m)
+func (m *TapRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_TapRequest.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_Input proto.InternalMessageInfo
+var xxx_messageInfo_TapRequest proto.InternalMessageInfo
 
-func (m *Input) GetGraph() string {
+func (m *TapRequest) GetGraph() string {
 	if m != nil {
 		return m.Graph
 	}
 	return ""
 }
 
-func (m *Input) GetIn() string {
+func (m *TapRequest) GetChannel() string {
 	if m != nil {
-		return m.In
+		return m.Channel
 	}
 	return ""
 }
 
-type Output struct {
-	Out                  string   `protobuf:"bytes,1,opt,name=out,proto3" json:"out,omitempty"`
-	Err                  string   `protobuf:"bytes,2,opt,name=err,proto3" json:"err,omitempty"`
+func (m *TapRequest) GetRunId() string {
+	if m != nil {
+		return m.RunId
+	}
+	return ""
+}
+
+type TapSample struct {
+	Value                string   `protobuf:"bytes,1,opt,name=value,proto3" json:"value,omitempty"`
+	Time                 int64    `protobuf:"varint,2,opt,name=time,proto3" json:"time,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
 }
 
-func (m *Output) Reset()         { *m = Output{} }
-func (m *Output) String() string { return proto.CompactTextString(m) }
-func (*Output) ProtoMessage()    {}
-func (*Output) Descriptor() ([]byte, []int) {
-	return fileDescriptor_shenzhen_go_017a25db36f90c92, []int{7}
+func (m *TapSample) Reset()         { *m = TapSample{} }
+func (m *TapSample) String() string { return proto.CompactTextString(m) }
+func (*TapSample) ProtoMessage()    {}
+func (*TapSample) Descriptor() ([]byte, []int) {
+	return fileDescriptor_shenzhen_go_a331d73bb9c4cf31, []int{19}
 }
-func (m *Output) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_Output.Unmarshal(m, b)
+func (m *TapSample) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_TapSample.Unmarshal(m, b)
 }
-func (m *Output) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_Output.Marshal(b, m, deterministic)
+func (m *TapSample) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_TapSample.Marshal(b, m, deterministic)
 }
-func (dst *Output) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_Output.Merge(dst, src)
+func (dst *TapSample) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_TapSample.Merge(dst, src)
 }
-func (m *Output) XXX_Size() int {
-	return xxx_messageInfo_Output.Size(m)
+func (m *TapSample) XXX_Size() int {
+	return xxx_messageInfo_TapSample.Size(m)
 }
-func (m *Output) XXX_DiscardUnknown() {
-	xxx_messageInfo_Output.DiscardUnknown(m)
+func (m *TapSample) XXX_DiscardUnknown() {
+	xxx_messageInfo_TapSample.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_Output proto.InternalMessageInfo
+var xxx_messageInfo_TapSample proto.InternalMessageInfo
 
-func (m *Output) GetOut() string {
+func (m *TapSample) GetValue() string {
 	if m != nil {
-		return m.Out
+		return m.Value
 	}
 	return ""
 }
 
-func (m *Output) GetErr() string {
+func (m *TapSample) GetTime() int64 {
 	if m != nil {
-		return m.Err
+		return m.Time
 	}
-	return ""
+	return 0
 }
 
 type SetChannelRequest struct {
@@ -476,7 +1352,7 @@ func (m *SetChannelRequest) Reset()         { *m = SetChannelRequest{} }
 func (m *SetChannelRequest) String() string { return proto.CompactTextString(m) }
 func (*SetChannelRequest) ProtoMessage()    {}
 func (*SetChannelRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_shenzhen_go_017a25db36f90c92, []int{8}
+	return fileDescriptor_shenzhen_go_a331d73bb9c4cf31, []int{20}
 }
 func (m *SetChannelRequest) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_SetChannelRequest.Unmarshal(m, b)
@@ -522,6 +1398,8 @@ type SetGraphPropertiesRequest struct {
 	Name                 string   `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
 	PackagePath          string   `protobuf:"bytes,3,opt,name=package_path,json=packagePath,proto3" json:"package_path,omitempty"`
 	IsCommand            bool     `protobuf:"varint,4,opt,name=is_command,json=isCommand,proto3" json:"is_command,omitempty"`
+	HandleSignals        bool     `protobuf:"varint,5,opt,name=handle_signals,json=handleSignals,proto3" json:"handle_signals,omitempty"`
+	ShutdownTimeout      string   `protobuf:"bytes,6,opt,name=shutdown_timeout,json=shutdownTimeout,proto3" json:"shutdown_timeout,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -531,7 +1409,7 @@ func (m *SetGraphPropertiesRequest) Reset()         { *m = SetGraphPropertiesReq
 func (m *SetGraphPropertiesRequest) String() string { return proto.CompactTextString(m) }
 func (*SetGraphPropertiesRequest) ProtoMessage()    {}
 func (*SetGraphPropertiesRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_shenzhen_go_017a25db36f90c92, []int{9}
+	return fileDescriptor_shenzhen_go_a331d73bb9c4cf31, []int{21}
 }
 func (m *SetGraphPropertiesRequest) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_SetGraphPropertiesRequest.Unmarshal(m, b)
@@ -579,6 +1457,74 @@ func (m *SetGraphPropertiesRequest) GetIsCommand() bool {
 	return false
 }
 
+func (m *SetGraphPropertiesRequest) GetHandleSignals() bool {
+	if m != nil {
+		return m.HandleSignals
+	}
+	return false
+}
+
+func (m *SetGraphPropertiesRequest) GetShutdownTimeout() string {
+	if m != nil {
+		return m.ShutdownTimeout
+	}
+	return ""
+}
+
+type SetRunConfigRequest struct {
+	Graph                string     `protobuf:"bytes,1,opt,name=graph,proto3" json:"graph,omitempty"`
+	Name                 string     `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Config               *RunConfig `protobuf:"bytes,3,opt,name=config,proto3" json:"config,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}   `json:"-"`
+	XXX_unrecognized     []byte     `json:"-"`
+	XXX_sizecache        int32      `json:"-"`
+}
+
+func (m *SetRunConfigRequest) Reset()         { *m = SetRunConfigRequest{} }
+func (m *SetRunConfigRequest) String() string { return proto.CompactTextString(m) }
+func (*SetRunConfigRequest) ProtoMessage()    {}
+func (*SetRunConfigRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_shenzhen_go_a331d73bb9c4cf31, []int{22}
+}
+func (m *SetRunConfigRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_SetRunConfigRequest.Unmarshal(m, b)
+}
+func (m *SetRunConfigRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_SetRunConfigRequest.Marshal(b, m, deterministic)
+}
+func (dst *SetRunConfigRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SetRunConfigRequest.Merge(dst, src)
+}
+func (m *SetRunConfigRequest) XXX_Size() int {
+	return xxx_messageInfo_SetRunConfigRequest.Size(m)
+}
+func (m *SetRunConfigRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_SetRunConfigRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SetRunConfigRequest proto.InternalMessageInfo
+
+func (m *SetRunConfigRequest) GetGraph() string {
+	if m != nil {
+		return m.Graph
+	}
+	return ""
+}
+
+func (m *SetRunConfigRequest) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *SetRunConfigRequest) GetConfig() *RunConfig {
+	if m != nil {
+		return m.Config
+	}
+	return nil
+}
+
 type SetNodeRequest struct {
 	Graph                string      `protobuf:"bytes,1,opt,name=graph,proto3" json:"graph,omitempty"`
 	Node                 string      `protobuf:"bytes,2,opt,name=node,proto3" json:"node,omitempty"`
@@ -592,7 +1538,7 @@ func (m *SetNodeRequest) Reset()         { *m = SetNodeRequest{} }
 func (m *SetNodeRequest) String() string { return proto.CompactTextString(m) }
 func (*SetNodeRequest) ProtoMessage()    {}
 func (*SetNodeRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_shenzhen_go_017a25db36f90c92, []int{10}
+	return fileDescriptor_shenzhen_go_a331d73bb9c4cf31, []int{23}
 }
 func (m *SetNodeRequest) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_SetNodeRequest.Unmarshal(m, b)
@@ -647,7 +1593,7 @@ func (m *SetPositionRequest) Reset()         { *m = SetPositionRequest{} }
 func (m *SetPositionRequest) String() string { return proto.CompactTextString(m) }
 func (*SetPositionRequest) ProtoMessage()    {}
 func (*SetPositionRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_shenzhen_go_017a25db36f90c92, []int{11}
+	return fileDescriptor_shenzhen_go_a331d73bb9c4cf31, []int{24}
 }
 func (m *SetPositionRequest) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_SetPositionRequest.Unmarshal(m, b)
@@ -701,14 +1647,28 @@ func init() {
 	proto.RegisterType((*ChannelConfig)(nil), "proto.ChannelConfig")
 	proto.RegisterType((*NodeConfig)(nil), "proto.NodeConfig")
 	proto.RegisterType((*ActionRequest)(nil), "proto.ActionRequest")
+	proto.RegisterType((*Diagnostic)(nil), "proto.Diagnostic")
 	proto.RegisterType((*ActionResponse)(nil), "proto.ActionResponse")
+	proto.RegisterType((*RunConfig)(nil), "proto.RunConfig")
+	proto.RegisterType((*DebugCommand)(nil), "proto.DebugCommand")
 	proto.RegisterType((*Input)(nil), "proto.Input")
 	proto.RegisterType((*Output)(nil), "proto.Output")
+	proto.RegisterType((*HeldValue)(nil), "proto.HeldValue")
+	proto.RegisterType((*NodeProfile)(nil), "proto.NodeProfile")
+	proto.RegisterType((*ChannelStats)(nil), "proto.ChannelStats")
+	proto.RegisterType((*RunInfo)(nil), "proto.RunInfo")
+	proto.RegisterType((*ListRunsRequest)(nil), "proto.ListRunsRequest")
+	proto.RegisterType((*ListRunsResponse)(nil), "proto.ListRunsResponse")
+	proto.RegisterType((*KillRunRequest)(nil), "proto.KillRunRequest")
+	proto.RegisterType((*TapRequest)(nil), "proto.TapRequest")
+	proto.RegisterType((*TapSample)(nil), "proto.TapSample")
 	proto.RegisterType((*SetChannelRequest)(nil), "proto.SetChannelRequest")
 	proto.RegisterType((*SetGraphPropertiesRequest)(nil), "proto.SetGraphPropertiesRequest")
+	proto.RegisterType((*SetRunConfigRequest)(nil), "proto.SetRunConfigRequest")
 	proto.RegisterType((*SetNodeRequest)(nil), "proto.SetNodeRequest")
 	proto.RegisterType((*SetPositionRequest)(nil), "proto.SetPositionRequest")
 	proto.RegisterEnum("proto.ActionRequest_Action", ActionRequest_Action_name, ActionRequest_Action_value)
+	proto.RegisterEnum("proto.DebugCommand_Op", DebugCommand_Op_name, DebugCommand_Op_value)
 }
 
 // Reference imports to suppress errors if they are not otherwise used.
@@ -725,14 +1685,30 @@ const _ = grpc.SupportPackageIsVersion4
 type ShenzhenGoClient interface {
 	// Action performs an action (save, generate, install/build, etc).
 	Action(ctx context.Context, in *ActionRequest, opts ...grpc.CallOption) (ShenzhenGo_ActionClient, error)
-	// Run runs the program.
+	// Run runs the program in a new run session. The session outlives the
+	// stream; the first Output message carries its ID.
 	Run(ctx context.Context, opts ...grpc.CallOption) (ShenzhenGo_RunClient, error)
+	// ListRuns lists current and recently finished run sessions.
+	ListRuns(ctx context.Context, in *ListRunsRequest, opts ...grpc.CallOption) (*ListRunsResponse, error)
+	// AttachRun attaches to an existing run session. Output buffered since
+	// the session started is replayed first.
+	AttachRun(ctx context.Context, opts ...grpc.CallOption) (ShenzhenGo_AttachRunClient, error)
+	// KillRun interrupts or kills the process of a run session. If the
+	// process has already exited, the session is forgotten.
+	KillRun(ctx context.Context, in *KillRunRequest, opts ...grpc.CallOption) (*Empty, error)
+	// TapChannel streams a rate-limited sample of the values sent on a
+	// channel, while the run session is running. The session must be
+	// instrumented.
+	TapChannel(ctx context.Context, in *TapRequest, opts ...grpc.CallOption) (ShenzhenGo_TapChannelClient, error)
 	// SetNode either creates a new channel (name == "", config != nil)
 	// changes existing channel data such as name and attached pins (name is found, config != nil),
 	// or deletes a channel (name is found, config == nil).
 	SetChannel(ctx context.Context, in *SetChannelRequest, opts ...grpc.CallOption) (*Empty, error)
 	// SetGraphProperties changes metdata such as name and package path.
 	SetGraphProperties(ctx context.Context, in *SetGraphPropertiesRequest, opts ...grpc.CallOption) (*Empty, error)
+	// SetRunConfig either creates or changes a saved run configuration
+	// (config != nil), or deletes one (config == nil).
+	SetRunConfig(ctx context.Context, in *SetRunConfigRequest, opts ...grpc.CallOption) (*Empty, error)
 	// SetNode either creates a new node (name == "", config != nil)
 	// changes existing node such as name and multiplicity (name is found, config != nil),
 	// or deletes a node (name is found, config == nil).
@@ -812,6 +1788,87 @@ func (x *shenzhenGoRunClient) Recv() (*Output, error) {
 	return m, nil
 }
 
+func (c *shenzhenGoClient) ListRuns(ctx context.Context, in *ListRunsRequest, opts ...grpc.CallOption) (*ListRunsResponse, error) {
+	out := new(ListRunsResponse)
+	err := c.cc.Invoke(ctx, "/proto.ShenzhenGo/ListRuns", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *shenzhenGoClient) AttachRun(ctx context.Context, opts ...grpc.CallOption) (ShenzhenGo_AttachRunClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_ShenzhenGo_serviceDesc.Streams[2], "/proto.ShenzhenGo/AttachRun", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &shenzhenGoAttachRunClient{stream}
+	return x, nil
+}
+
+type ShenzhenGo_AttachRunClient interface {
+	Send(*Input) error
+	Recv() (*Output, error)
+	grpc.ClientStream
+}
+
+type shenzhenGoAttachRunClient struct {
+	grpc.ClientStream
+}
+
+func (x *shenzhenGoAttachRunClient) Send(m *Input) error {
+	return x.ClientStream.SendMsg(m)
+}
+
+func (x *shenzhenGoAttachRunClient) Recv() (*Output, error) {
+	m := new(Output)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *shenzhenGoClient) KillRun(ctx context.Context, in *KillRunRequest, opts ...grpc.CallOption) (*Empty, error) {
+	out := new(Empty)
+	err := c.cc.Invoke(ctx, "/proto.ShenzhenGo/KillRun", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *shenzhenGoClient) TapChannel(ctx context.Context, in *TapRequest, opts ...grpc.CallOption) (ShenzhenGo_TapChannelClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_ShenzhenGo_serviceDesc.Streams[3], "/proto.ShenzhenGo/TapChannel", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &shenzhenGoTapChannelClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type ShenzhenGo_TapChannelClient interface {
+	Recv() (*TapSample, error)
+	grpc.ClientStream
+}
+
+type shenzhenGoTapChannelClient struct {
+	grpc.ClientStream
+}
+
+func (x *shenzhenGoTapChannelClient) Recv() (*TapSample, error) {
+	m := new(TapSample)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
 func (c *shenzhenGoClient) SetChannel(ctx context.Context, in *SetChannelRequest, opts ...grpc.CallOption) (*Empty, error) {
 	out := new(Empty)
 	err := c.cc.Invoke(ctx, "/proto.ShenzhenGo/SetChannel", in, out, opts...)
@@ -830,6 +1887,15 @@ func (c *shenzhenGoClient) SetGraphProperties(ctx context.Context, in *SetGraphP
 	return out, nil
 }
 
+func (c *shenzhenGoClient) SetRunConfig(ctx context.Context, in *SetRunConfigRequest, opts ...grpc.CallOption) (*Empty, error) {
+	out := new(Empty)
+	err := c.cc.Invoke(ctx, "/proto.ShenzhenGo/SetRunConfig", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *shenzhenGoClient) SetNode(ctx context.Context, in *SetNodeRequest, opts ...grpc.CallOption) (*Empty, error) {
 	out := new(Empty)
 	err := c.cc.Invoke(ctx, "/proto.ShenzhenGo/SetNode", in, out, opts...)
@@ -852,14 +1918,30 @@ func (c *shenzhenGoClient) SetPosition(ctx context.Context, in *SetPositionReque
 type ShenzhenGoServer interface {
 	// Action performs an action (save, generate, install/build, etc).
 	Action(*ActionRequest, ShenzhenGo_ActionServer) error
-	// Run runs the program.
+	// Run runs the program in a new run session. The session outlives the
+	// stream; the first Output message carries its ID.
 	Run(ShenzhenGo_RunServer) error
+	// ListRuns lists current and recently finished run sessions.
+	ListRuns(context.Context, *ListRunsRequest) (*ListRunsResponse, error)
+	// AttachRun attaches to an existing run session. Output buffered since
+	// the session started is replayed first.
+	AttachRun(ShenzhenGo_AttachRunServer) error
+	// KillRun interrupts or kills the process of a run session. If the
+	// process has already exited, the session is forgotten.
+	KillRun(context.Context, *KillRunRequest) (*Empty, error)
+	// TapChannel streams a rate-limited sample of the values sent on a
+	// channel, while the run session is running. The session must be
+	// instrumented.
+	TapChannel(*TapRequest, ShenzhenGo_TapChannelServer) error
 	// SetNode either creates a new channel (name == "", config != nil)
 	// changes existing channel data such as name and attached pins (name is found, config != nil),
 	// or deletes a channel (name is found, config == nil).
 	SetChannel(context.Context, *SetChannelRequest) (*Empty, error)
 	// SetGraphProperties changes metdata such as name and package path.
 	SetGraphProperties(context.Context, *SetGraphPropertiesRequest) (*Empty, error)
+	// SetRunConfig either creates or changes a saved run configuration
+	// (config != nil), or deletes one (config == nil).
+	SetRunConfig(context.Context, *SetRunConfigRequest) (*Empty, error)
 	// SetNode either creates a new node (name == "", config != nil)
 	// changes existing node such as name and multiplicity (name is found, config != nil),
 	// or deletes a node (name is found, config == nil).
@@ -919,6 +2001,89 @@ func (x *shenzhenGoRunServer) Recv() (*Input, error) {
 	return m, nil
 }
 
+func _ShenzhenGo_ListRuns_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListRunsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ShenzhenGoServer).ListRuns(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/proto.ShenzhenGo/ListRuns",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ShenzhenGoServer).ListRuns(ctx, req.(*ListRunsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ShenzhenGo_AttachRun_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(ShenzhenGoServer).AttachRun(&shenzhenGoAttachRunServer{stream})
+}
+
+type ShenzhenGo_AttachRunServer interface {
+	Send(*Output) error
+	Recv() (*Input, error)
+	grpc.ServerStream
+}
+
+type shenzhenGoAttachRunServer struct {
+	grpc.ServerStream
+}
+
+func (x *shenzhenGoAttachRunServer) Send(m *Output) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func (x *shenzhenGoAttachRunServer) Recv() (*Input, error) {
+	m := new(Input)
+	if err := x.ServerStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func _ShenzhenGo_KillRun_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(KillRunRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ShenzhenGoServer).KillRun(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/proto.ShenzhenGo/KillRun",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ShenzhenGoServer).KillRun(ctx, req.(*KillRunRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ShenzhenGo_TapChannel_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(TapRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(ShenzhenGoServer).TapChannel(m, &shenzhenGoTapChannelServer{stream})
+}
+
+type ShenzhenGo_TapChannelServer interface {
+	Send(*TapSample) error
+	grpc.ServerStream
+}
+
+type shenzhenGoTapChannelServer struct {
+	grpc.ServerStream
+}
+
+func (x *shenzhenGoTapChannelServer) Send(m *TapSample) error {
+	return x.ServerStream.SendMsg(m)
+}
+
 func _ShenzhenGo_SetChannel_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(SetChannelRequest)
 	if err := dec(in); err != nil {
@@ -955,6 +2120,24 @@ func _ShenzhenGo_SetGraphProperties_Handler(srv interface{}, ctx context.Context
 	return interceptor(ctx, in, info, handler)
 }
 
+func _ShenzhenGo_SetRunConfig_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetRunConfigRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ShenzhenGoServer).SetRunConfig(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/proto.ShenzhenGo/SetRunConfig",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ShenzhenGoServer).SetRunConfig(ctx, req.(*SetRunConfigRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _ShenzhenGo_SetNode_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(SetNodeRequest)
 	if err := dec(in); err != nil {
@@ -995,6 +2178,14 @@ var _ShenzhenGo_serviceDesc = grpc.ServiceDesc{
 	ServiceName: "proto.ShenzhenGo",
 	HandlerType: (*ShenzhenGoServer)(nil),
 	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "ListRuns",
+			Handler:    _ShenzhenGo_ListRuns_Handler,
+		},
+		{
+			MethodName: "KillRun",
+			Handler:    _ShenzhenGo_KillRun_Handler,
+		},
 		{
 			MethodName: "SetChannel",
 			Handler:    _ShenzhenGo_SetChannel_Handler,
@@ -1003,6 +2194,10 @@ var _ShenzhenGo_serviceDesc = grpc.ServiceDesc{
 			MethodName: "SetGraphProperties",
 			Handler:    _ShenzhenGo_SetGraphProperties_Handler,
 		},
+		{
+			MethodName: "SetRunConfig",
+			Handler:    _ShenzhenGo_SetRunConfig_Handler,
+		},
 		{
 			MethodName: "SetNode",
 			Handler:    _ShenzhenGo_SetNode_Handler,
@@ -1024,56 +2219,124 @@ var _ShenzhenGo_serviceDesc = grpc.ServiceDesc{
 			ServerStreams: true,
 			ClientStreams: true,
 		},
+		{
+			StreamName:    "AttachRun",
+			Handler:       _ShenzhenGo_AttachRun_Handler,
+			ServerStreams: true,
+			ClientStreams: true,
+		},
+		{
+			StreamName:    "TapChannel",
+			Handler:       _ShenzhenGo_TapChannel_Handler,
+			ServerStreams: true,
+		},
 	},
 	Metadata: "shenzhen-go.proto",
 }
 
-func init() { proto.RegisterFile("shenzhen-go.proto", fileDescriptor_shenzhen_go_017a25db36f90c92) }
-
-var fileDescriptor_shenzhen_go_017a25db36f90c92 = []byte{
-	// 700 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x84, 0x94, 0xdb, 0x4e, 0x1b, 0x3d,
-	0x10, 0xc7, 0xe3, 0x1c, 0x36, 0xc9, 0x24, 0x44, 0x61, 0x04, 0x9f, 0x16, 0xd0, 0x27, 0xa5, 0xbe,
-	0xda, 0x4a, 0x40, 0x51, 0x90, 0xaa, 0xf6, 0x32, 0x0d, 0x29, 0x42, 0x42, 0x34, 0xda, 0xa4, 0x48,
-	0xed, 0x0d, 0x5a, 0x12, 0x93, 0xac, 0x9a, 0xd8, 0x66, 0xd7, 0x51, 0x49, 0x5f, 0xa0, 0xaf, 0xd1,
-	0x17, 0xab, 0xd4, 0x47, 0xa9, 0xec, 0xf5, 0xe6, 0x04, 0x85, 0xab, 0xf5, 0x7f, 0x3c, 0xff, 0x19,
-	0x1f, 0x7e, 0x5e, 0xd8, 0x8e, 0xc7, 0x8c, 0xff, 0x18, 0x33, 0x7e, 0x34, 0x12, 0xc7, 0x32, 0x12,
-	0x4a, 0x60, 0xc1, 0x7c, 0x68, 0x11, 0x0a, 0x9d, 0xa9, 0x54, 0x73, 0xfa, 0x06, 0x8a, 0x57, 0x62,
-	0xc8, 0xba, 0x21, 0x47, 0x84, 0x3c, 0x17, 0x43, 0xe6, 0x92, 0x06, 0xf1, 0xca, 0xbe, 0x19, 0x63,
-	0x1d, 0x72, 0x32, 0xe4, 0x6e, 0xd6, 0x84, 0xf4, 0x90, 0x7e, 0x81, 0xad, 0xf6, 0x38, 0xe0, 0x9c,
-	0x4d, 0xda, 0x82, 0xdf, 0x85, 0x23, 0x63, 0x0b, 0xa6, 0x4b, 0x5b, 0x30, 0x35, 0xb6, 0x41, 0x20,
-	0x8d, 0x2d, 0xef, 0xeb, 0x21, 0x52, 0xc8, 0xcb, 0x90, 0xc7, 0x6e, 0xae, 0x91, 0xf3, 0x2a, 0xcd,
-	0x5a, 0xb2, 0x9a, 0x63, 0xdb, 0xda, 0x37, 0x73, 0xf4, 0x0f, 0x01, 0xd0, 0x91, 0x67, 0x0a, 0xbb,
-	0x50, 0x1c, 0x88, 0xe9, 0x94, 0x71, 0x65, 0xd7, 0x94, 0x4a, 0x3d, 0xc3, 0x78, 0x70, 0x3b, 0x61,
-	0x43, 0x37, 0xd7, 0x20, 0x5e, 0xc9, 0x4f, 0x25, 0x52, 0xa8, 0x4e, 0x67, 0x13, 0x15, 0xca, 0x49,
-	0x38, 0x08, 0xd5, 0xdc, 0xcd, 0x1b, 0xe3, 0x5a, 0x4c, 0xf7, 0xfa, 0x1e, 0x84, 0xca, 0x2d, 0x18,
-	0xab, 0x19, 0xe3, 0x1e, 0x94, 0x64, 0x10, 0xa9, 0x9b, 0xc1, 0xdd, 0xc8, 0x75, 0x1a, 0xc4, 0xab,
-	0xfa, 0x45, 0xad, 0xdb, 0x77, 0x23, 0x3c, 0x80, 0xb2, 0x99, 0x52, 0x73, 0xc9, 0xdc, 0xa2, 0xa9,
-	0x67, 0x72, 0xfb, 0x73, 0xc9, 0xb0, 0x0a, 0xe4, 0xc1, 0x2d, 0x35, 0x88, 0x47, 0x7c, 0xf2, 0xa0,
-	0xd5, 0xdc, 0x2d, 0x27, 0x6a, 0x4e, 0x7f, 0x11, 0xd8, 0x6a, 0x0d, 0x54, 0x28, 0xb8, 0xcf, 0xee,
-	0x67, 0x2c, 0x56, 0xb8, 0x03, 0x85, 0x51, 0x14, 0xc8, 0xb1, 0xdd, 0x66, 0x22, 0xf0, 0x14, 0x9c,
-	0xc0, 0xa4, 0x99, 0x6d, 0xd6, 0x9a, 0x07, 0xf6, 0xc0, 0xd6, 0xbc, 0xa9, 0xb2, 0xa9, 0xf4, 0x0c,
-	0x9c, 0x24, 0x82, 0x25, 0xc8, 0xf7, 0x5a, 0xd7, 0x9d, 0x7a, 0x06, 0x01, 0x1c, 0xbf, 0x73, 0xdd,
-	0xf1, 0xfb, 0x75, 0x82, 0x55, 0x28, 0x9d, 0x77, 0xae, 0x3a, 0x7e, 0xab, 0xdf, 0xa9, 0x67, 0xb1,
-	0x0c, 0x85, 0x0f, 0x9f, 0x2f, 0x2e, 0xcf, 0xea, 0x39, 0xac, 0x40, 0xf1, 0xe2, 0xaa, 0xd7, 0x6f,
-	0x5d, 0x5e, 0xd6, 0xf3, 0xd4, 0x83, 0x5a, 0xda, 0x25, 0x96, 0x82, 0xc7, 0x0c, 0xff, 0x03, 0x47,
-	0xcc, 0x94, 0x9c, 0x29, 0xbb, 0x46, 0xab, 0xe8, 0x11, 0x14, 0x2e, 0xb8, 0x9c, 0xfd, 0x6b, 0x0f,
-	0x35, 0xc8, 0x2e, 0xd0, 0xc9, 0x86, 0x9c, 0x1e, 0x82, 0xf3, 0xc9, 0x18, 0x35, 0x1e, 0x62, 0x51,
-	0x4d, 0x0f, 0x75, 0x84, 0x45, 0x51, 0xca, 0x19, 0x8b, 0x22, 0x7a, 0x0f, 0xdb, 0x3d, 0xa6, 0x2c,
-	0x6a, 0xcf, 0x1f, 0x96, 0x86, 0x22, 0xc9, 0x5b, 0x40, 0x91, 0x48, 0x3c, 0x04, 0x67, 0x60, 0x60,
-	0x32, 0x4c, 0x54, 0x9a, 0x3b, 0xf6, 0x18, 0xd7, 0x08, 0xf6, 0x6d, 0x0e, 0xfd, 0x49, 0x60, 0xaf,
-	0xc7, 0xd4, 0xb9, 0x2e, 0xda, 0x8d, 0x84, 0x64, 0x91, 0x0a, 0x59, 0xfc, 0x7c, 0xef, 0x14, 0xd2,
-	0xec, 0x0a, 0xa4, 0xaf, 0xa0, 0x2a, 0x83, 0xc1, 0xb7, 0x60, 0xc4, 0x6e, 0x64, 0xa0, 0xc6, 0xa6,
-	0x77, 0xd9, 0xaf, 0xd8, 0x58, 0x37, 0x50, 0x63, 0xfc, 0x1f, 0x20, 0x8c, 0x6f, 0x34, 0xbb, 0x01,
-	0x1f, 0x1a, 0x22, 0x4b, 0x7e, 0x39, 0x8c, 0xdb, 0x49, 0x80, 0x32, 0xa8, 0xf5, 0x98, 0xd2, 0x6f,
-	0xe1, 0xe5, 0xee, 0xfa, 0xc9, 0x66, 0x57, 0x9e, 0xec, 0xeb, 0x8d, 0x3d, 0x6f, 0xaf, 0xbc, 0xb5,
-	0x8d, 0x0d, 0x7f, 0x05, 0xec, 0x31, 0xd5, 0x15, 0x71, 0xf8, 0x32, 0x91, 0x4f, 0xb5, 0x32, 0xa4,
-	0xe7, 0xd6, 0x48, 0xcf, 0x5b, 0xd2, 0x9b, 0xbf, 0xb3, 0x00, 0x3d, 0xfb, 0xfb, 0x39, 0x17, 0xf8,
-	0x7e, 0xc1, 0xe6, 0xce, 0x53, 0x28, 0xef, 0xef, 0x6e, 0x44, 0x13, 0xf4, 0x68, 0xe6, 0x84, 0xa0,
-	0x07, 0x39, 0x7f, 0xc6, 0xb1, 0x6a, 0x33, 0x0c, 0x72, 0xfb, 0x5b, 0x56, 0x25, 0x44, 0xd1, 0x8c,
-	0x47, 0x4e, 0x08, 0xbe, 0x05, 0x58, 0x32, 0x83, 0xae, 0x4d, 0x79, 0x84, 0xd1, 0x7e, 0x5a, 0x2a,
-	0xf9, 0x05, 0x66, 0xf0, 0xa3, 0x39, 0x87, 0x8d, 0x7b, 0xc7, 0xc6, 0xd2, 0xff, 0x34, 0x12, 0x8f,
-	0xea, 0x9c, 0x40, 0xd1, 0x5e, 0x1b, 0xee, 0x2e, 0xcd, 0x2b, 0xd7, 0xf8, 0xc8, 0xf1, 0x0e, 0x2a,
-	0x2b, 0x37, 0x80, 0x7b, 0x4b, 0xd7, 0xc6, 0xad, 0x6c, 0x3a, 0x6f, 0x1d, 0x23, 0x4f, 0xff, 0x06,
-	0x00, 0x00, 0xff, 0xff, 0xdd, 0x1b, 0x48, 0xe6, 0xe4, 0x05, 0x00, 0x00,
+func init() { proto.RegisterFile("shenzhen-go.proto", fileDescriptor_shenzhen_go_a331d73bb9c4cf31) }
+
+var fileDescriptor_shenzhen_go_a331d73bb9c4cf31 = []byte{
+	// 1613 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x9c, 0x57, 0x5f, 0x6f, 0xdb, 0xc8,
+	0x11, 0x37, 0x45, 0xfd, 0xe3, 0x48, 0xf1, 0x31, 0x7b, 0xb9, 0x2b, 0xe3, 0xe0, 0x5a, 0x95, 0xe8,
+	0x1f, 0x05, 0xb8, 0xe6, 0x02, 0x07, 0x17, 0xf4, 0xef, 0x83, 0x23, 0xeb, 0x72, 0xc6, 0x19, 0xb6,
+	0xb1, 0x54, 0x02, 0xb4, 0x40, 0x21, 0xac, 0xc9, 0xb5, 0xb4, 0x38, 0x6a, 0xc9, 0xe3, 0x2e, 0x73,
+	0x56, 0x5f, 0xfb, 0xde, 0xf7, 0x3e, 0xf4, 0x0b, 0xf5, 0x1b, 0xb4, 0xdf, 0xa2, 0x40, 0x3f, 0x40,
+	0xb1, 0x7f, 0x28, 0x52, 0xb2, 0xea, 0xa4, 0x7d, 0xd2, 0xcc, 0x8f, 0x33, 0x3b, 0xbb, 0x33, 0xbf,
+	0x99, 0x5d, 0xc1, 0x43, 0xb1, 0xa4, 0xfc, 0x4f, 0x4b, 0xca, 0x7f, 0xb1, 0xc8, 0x9e, 0xe5, 0x45,
+	0x26, 0x33, 0xd4, 0xd1, 0x3f, 0x61, 0x0f, 0x3a, 0xd3, 0x55, 0x2e, 0xd7, 0xe1, 0x17, 0xd0, 0xbb,
+	0xc8, 0x12, 0x7a, 0xc5, 0x38, 0x42, 0xd0, 0xe6, 0x59, 0x42, 0x03, 0x67, 0xe4, 0x8c, 0x3d, 0xac,
+	0x65, 0xe4, 0x83, 0x9b, 0x33, 0x1e, 0xb4, 0x34, 0xa4, 0xc4, 0xf0, 0xf7, 0xf0, 0x60, 0xb2, 0x24,
+	0x9c, 0xd3, 0x74, 0x92, 0xf1, 0x1b, 0xb6, 0xd0, 0x6e, 0x64, 0x55, 0xbb, 0x91, 0x95, 0x76, 0x8b,
+	0x49, 0xae, 0xdd, 0xda, 0x58, 0x89, 0x28, 0x84, 0x76, 0xce, 0xb8, 0x08, 0xdc, 0x91, 0x3b, 0x1e,
+	0x1c, 0x1f, 0x9a, 0xdd, 0x3c, 0xb3, 0xa1, 0xb1, 0xfe, 0x16, 0xfe, 0xbd, 0x05, 0xa0, 0x90, 0x7b,
+	0x16, 0x0e, 0xa0, 0x17, 0x67, 0xab, 0x15, 0xe5, 0xd2, 0xee, 0xa9, 0x52, 0xd5, 0x17, 0xca, 0xc9,
+	0x75, 0x4a, 0x93, 0xc0, 0x1d, 0x39, 0xe3, 0x3e, 0xae, 0x54, 0x14, 0xc2, 0x70, 0x55, 0xa6, 0x92,
+	0xe5, 0x29, 0x8b, 0x99, 0x5c, 0x07, 0x6d, 0xed, 0xb8, 0x85, 0xa9, 0x58, 0xdf, 0x13, 0x26, 0x83,
+	0x8e, 0x76, 0xd5, 0x32, 0x7a, 0x0c, 0xfd, 0x9c, 0x14, 0x72, 0x1e, 0xdf, 0x2c, 0x82, 0xee, 0xc8,
+	0x19, 0x0f, 0x71, 0x4f, 0xe9, 0x93, 0x9b, 0x05, 0x7a, 0x02, 0x9e, 0xfe, 0x24, 0xd7, 0x39, 0x0d,
+	0x7a, 0x7a, 0x3d, 0x6d, 0x3b, 0x5b, 0xe7, 0x14, 0x0d, 0xc1, 0xb9, 0x0d, 0xfa, 0x23, 0x67, 0xec,
+	0x60, 0xe7, 0x56, 0x69, 0xeb, 0xc0, 0x33, 0xda, 0x1a, 0xfd, 0x18, 0x86, 0x39, 0xe1, 0x2c, 0x9e,
+	0xe7, 0x59, 0xca, 0xe2, 0x75, 0x00, 0xda, 0x77, 0xa0, 0xb1, 0x2b, 0x0d, 0x29, 0x93, 0x15, 0xb9,
+	0x9d, 0x17, 0x54, 0x48, 0x52, 0x48, 0x11, 0x0c, 0x46, 0xce, 0xd8, 0xc5, 0x83, 0x15, 0xb9, 0xc5,
+	0x16, 0x52, 0x27, 0x8a, 0x75, 0x8e, 0xca, 0x42, 0x1d, 0x31, 0x18, 0x8e, 0x5c, 0x75, 0xa2, 0x26,
+	0x16, 0xfe, 0xdb, 0x81, 0x07, 0x27, 0xb1, 0x64, 0x19, 0xc7, 0xf4, 0xbb, 0x92, 0x0a, 0x89, 0x1e,
+	0x41, 0x67, 0x51, 0x90, 0x7c, 0x69, 0x13, 0x6a, 0x14, 0xf4, 0x02, 0xba, 0x44, 0x9b, 0xe9, 0x84,
+	0x1e, 0x1e, 0x3f, 0xb1, 0xa5, 0xd9, 0xf2, 0xad, 0x34, 0x6b, 0xaa, 0xd2, 0x55, 0x90, 0x98, 0xda,
+	0x4c, 0x6b, 0x39, 0xfc, 0xb3, 0x03, 0x5d, 0x63, 0x86, 0xfa, 0xd0, 0x8e, 0x4e, 0xde, 0x4e, 0xfd,
+	0x03, 0x04, 0xd0, 0xc5, 0xd3, 0xb7, 0x53, 0x3c, 0xf3, 0x1d, 0x34, 0x84, 0xfe, 0xeb, 0xe9, 0xc5,
+	0x14, 0x9f, 0xcc, 0xa6, 0x7e, 0x0b, 0x79, 0xd0, 0x79, 0xf5, 0xe6, 0xec, 0xfc, 0xd4, 0x77, 0xd1,
+	0x00, 0x7a, 0x67, 0x17, 0xd1, 0xec, 0xe4, 0xfc, 0xdc, 0x6f, 0x2b, 0x05, 0x4f, 0xa3, 0xd9, 0x25,
+	0x9e, 0xfa, 0x1d, 0xa5, 0x9c, 0x9e, 0x45, 0x93, 0x13, 0x7c, 0xea, 0x77, 0xd5, 0xaa, 0xb3, 0x69,
+	0x34, 0xf3, 0x7b, 0xe8, 0x10, 0x40, 0x49, 0xf3, 0x68, 0xf6, 0xe6, 0x55, 0xe4, 0xf7, 0xf5, 0x5a,
+	0xd3, 0x8b, 0xc9, 0xd7, 0xbe, 0x17, 0x2e, 0x01, 0x4e, 0x19, 0x59, 0xf0, 0x4c, 0x48, 0x16, 0xef,
+	0xa5, 0x74, 0x00, 0x3d, 0x41, 0xeb, 0x13, 0x7b, 0xb8, 0x52, 0x95, 0x75, 0xca, 0xb8, 0x39, 0x95,
+	0x8b, 0xb5, 0xac, 0xac, 0x57, 0x54, 0x08, 0xb2, 0xa0, 0x96, 0x37, 0x95, 0x1a, 0xfe, 0x11, 0x0e,
+	0xab, 0x1c, 0x89, 0x3c, 0xe3, 0x82, 0xa2, 0x4f, 0xa1, 0x9b, 0x95, 0x32, 0x2f, 0xa5, 0x8d, 0x67,
+	0x35, 0xf4, 0x02, 0x06, 0xc9, 0x66, 0x4f, 0x22, 0x68, 0xe9, 0x16, 0x78, 0x68, 0xf3, 0x5c, 0xef,
+	0x16, 0x37, 0xad, 0xc2, 0xbf, 0xb6, 0xc0, 0xc3, 0x25, 0xaf, 0x7b, 0x81, 0x14, 0x0b, 0x11, 0x38,
+	0xba, 0xd2, 0x5a, 0x56, 0x4d, 0x46, 0xf9, 0x3b, 0xbd, 0x9c, 0x87, 0x95, 0xa8, 0x90, 0x84, 0x15,
+	0x7a, 0xff, 0x1e, 0x56, 0xe2, 0xa6, 0x50, 0xed, 0xba, 0x50, 0xea, 0x48, 0x79, 0x91, 0xdd, 0xb0,
+	0x94, 0x5a, 0xba, 0x57, 0x2a, 0xfa, 0x21, 0x00, 0xe3, 0x42, 0x16, 0xa5, 0x6e, 0xb0, 0xae, 0xfe,
+	0xd8, 0x40, 0x14, 0x83, 0x12, 0x7a, 0x5d, 0x2e, 0x34, 0xe5, 0xfb, 0xd8, 0x28, 0xea, 0xd8, 0x05,
+	0x8d, 0xb3, 0x22, 0x09, 0xfa, 0x7a, 0x2b, 0x56, 0x43, 0x3f, 0x82, 0x81, 0x91, 0xe6, 0x3a, 0x96,
+	0xa7, 0x77, 0x05, 0x06, 0xfa, 0x4a, 0x85, 0xd3, 0x8e, 0x79, 0x4a, 0x54, 0x1b, 0x58, 0x47, 0xa5,
+	0x19, 0x47, 0x25, 0x19, 0xc7, 0x41, 0xe5, 0xa8, 0x20, 0xe5, 0x18, 0xfe, 0xc5, 0x81, 0xe1, 0xa9,
+	0x8a, 0x3d, 0xc9, 0x56, 0x2b, 0xc2, 0x13, 0x3d, 0x16, 0xcc, 0x50, 0xb2, 0xa9, 0xaf, 0x54, 0xf4,
+	0x33, 0x68, 0x65, 0xb9, 0xa5, 0xf6, 0xa7, 0x55, 0xca, 0x1b, 0xae, 0xcf, 0x2e, 0x73, 0xdc, 0xca,
+	0xf2, 0xf0, 0xb7, 0xd0, 0xba, 0xcc, 0x35, 0x91, 0xf0, 0xf4, 0xe4, 0x1b, 0xff, 0x40, 0x89, 0x93,
+	0xf3, 0xe9, 0x09, 0xf6, 0x1d, 0x4d, 0xe7, 0xd9, 0xf4, 0xca, 0x6f, 0x29, 0x0a, 0x4f, 0x2e, 0x2f,
+	0x66, 0x67, 0x17, 0x6f, 0xa6, 0xbe, 0xab, 0xf0, 0x53, 0x7c, 0x79, 0xe5, 0xb7, 0xc3, 0x7f, 0x3a,
+	0xd0, 0x39, 0xe3, 0x79, 0xf9, 0xdf, 0x9a, 0xec, 0x10, 0x5a, 0x9b, 0x29, 0xda, 0x62, 0x1c, 0x8d,
+	0xa1, 0x6b, 0x9a, 0x55, 0xd7, 0x6a, 0x70, 0xec, 0xdb, 0x9d, 0x6d, 0x0a, 0x8e, 0xed, 0x77, 0xf4,
+	0x19, 0x40, 0x51, 0xf2, 0xb9, 0xb5, 0x36, 0x14, 0xf4, 0x8a, 0x0d, 0x2f, 0x3e, 0x81, 0xae, 0xfa,
+	0xcc, 0x12, 0x5d, 0x4a, 0x0f, 0x77, 0x8a, 0x92, 0x9f, 0x25, 0xe8, 0x69, 0x55, 0xa8, 0xae, 0x5e,
+	0xfe, 0xe3, 0x3d, 0x07, 0xaf, 0xaa, 0x37, 0x82, 0xc1, 0x75, 0x41, 0xc9, 0xb7, 0x79, 0xc6, 0xb8,
+	0x14, 0x41, 0x4f, 0x57, 0xa2, 0x09, 0x29, 0x26, 0x76, 0x2f, 0x0d, 0x93, 0x7d, 0x70, 0xb3, 0x0d,
+	0xbd, 0xdd, 0xcc, 0x20, 0xb4, 0x28, 0xaa, 0x0b, 0x82, 0x16, 0x45, 0x63, 0x4b, 0x6e, 0x73, 0x4b,
+	0x3b, 0x4d, 0xd0, 0xfe, 0x90, 0x26, 0x40, 0x9f, 0x37, 0xa9, 0xaa, 0x1c, 0x50, 0xf3, 0xe2, 0x30,
+	0x5f, 0x6a, 0xfa, 0x7e, 0x01, 0x7d, 0x5b, 0x76, 0x11, 0x74, 0x47, 0x6e, 0xe3, 0xe0, 0xf6, 0xc6,
+	0x8a, 0x24, 0x91, 0x02, 0x6f, 0x8c, 0xd0, 0x4f, 0xa0, 0xbd, 0xa4, 0x69, 0x12, 0xf4, 0xb6, 0x8a,
+	0xf0, 0x35, 0x4d, 0x93, 0xb7, 0x24, 0x2d, 0x29, 0xd6, 0x5f, 0xd1, 0x11, 0xf4, 0x0b, 0x9a, 0x52,
+	0x22, 0x68, 0xa2, 0xc7, 0xba, 0x87, 0x37, 0x7a, 0xf8, 0x1b, 0xf0, 0x36, 0xe6, 0xf7, 0xb0, 0xf0,
+	0x11, 0x74, 0xde, 0x29, 0x13, 0x9b, 0x27, 0xa3, 0x84, 0x73, 0x18, 0x34, 0xce, 0xb1, 0x77, 0x58,
+	0x3d, 0x01, 0x2f, 0xce, 0xcb, 0x39, 0x27, 0x3c, 0x13, 0xda, 0xd9, 0xc5, 0xfd, 0x38, 0x2f, 0x2f,
+	0x94, 0xae, 0xfa, 0x84, 0xa4, 0x69, 0x16, 0xcf, 0xaf, 0xd7, 0x92, 0x0a, 0x3b, 0xb6, 0x40, 0x43,
+	0xaf, 0x14, 0x12, 0xbe, 0x83, 0x61, 0xf3, 0xe4, 0xf7, 0x6c, 0xd0, 0x07, 0x37, 0xa5, 0xdc, 0x46,
+	0x50, 0x62, 0x75, 0x85, 0x9b, 0x45, 0x95, 0xa8, 0x0e, 0x21, 0x28, 0x4f, 0x84, 0x66, 0xa1, 0x8b,
+	0x8d, 0x62, 0x26, 0x8c, 0x34, 0xa3, 0xc4, 0xc1, 0x5a, 0x0e, 0xff, 0xe6, 0x40, 0x0f, 0x97, 0xfc,
+	0x8c, 0xdf, 0x64, 0x9a, 0xfa, 0x89, 0x0d, 0xd7, 0x62, 0x49, 0xdd, 0x20, 0xad, 0x66, 0x83, 0x54,
+	0xf3, 0xcd, 0x6d, 0xcc, 0xb7, 0xcf, 0x00, 0xf4, 0x7d, 0x37, 0x97, 0x6c, 0x45, 0x6d, 0x50, 0x4f,
+	0x23, 0x33, 0x66, 0x9e, 0x02, 0x45, 0xc9, 0x39, 0xe3, 0x8b, 0x6a, 0x8c, 0x59, 0x55, 0xe5, 0x85,
+	0xde, 0x32, 0x39, 0x17, 0x92, 0xc8, 0x52, 0xe8, 0x1e, 0xf0, 0x30, 0x28, 0x28, 0xd2, 0x48, 0xf8,
+	0x73, 0xf8, 0xe8, 0x9c, 0x09, 0x89, 0x4b, 0x2e, 0xee, 0xbd, 0x1c, 0xc3, 0x97, 0xe0, 0xd7, 0x86,
+	0x76, 0xca, 0x87, 0xd0, 0x2e, 0x4a, 0x6e, 0x46, 0x71, 0xfd, 0x92, 0xb1, 0xc7, 0xc5, 0xfa, 0x5b,
+	0xf8, 0x12, 0x0e, 0xbf, 0x61, 0x69, 0x8a, 0xcb, 0xcd, 0xe5, 0xbb, 0x27, 0x0d, 0x37, 0x59, 0x11,
+	0x1b, 0x46, 0xf4, 0xb1, 0x51, 0xc2, 0x08, 0x60, 0x46, 0xf2, 0xfb, 0x2f, 0xec, 0x46, 0x11, 0x5b,
+	0xdb, 0x45, 0xdc, 0xdf, 0x79, 0xe1, 0x97, 0xe0, 0xcd, 0x48, 0x1e, 0x91, 0x55, 0x9e, 0xd2, 0x9a,
+	0x89, 0x4e, 0x83, 0x89, 0x2a, 0xfd, 0x3a, 0xc9, 0xa6, 0xfe, 0x5a, 0x0e, 0xbf, 0x83, 0x87, 0x11,
+	0x95, 0x96, 0x3f, 0xff, 0xef, 0x96, 0x3e, 0xdf, 0x19, 0x74, 0x8f, 0xb6, 0x1b, 0x72, 0x7b, 0xd8,
+	0x85, 0xff, 0x70, 0xe0, 0x71, 0x44, 0xe5, 0x6b, 0xb5, 0xe8, 0x55, 0x91, 0xe5, 0xb4, 0x90, 0x8c,
+	0xde, 0x5f, 0xa2, 0xcd, 0x2b, 0xb1, 0xd5, 0x78, 0x25, 0xea, 0x57, 0x56, 0xfc, 0x2d, 0x59, 0xd0,
+	0x79, 0x4e, 0xe4, 0xd2, 0xa6, 0x63, 0x60, 0xb1, 0x2b, 0x22, 0x97, 0x8a, 0x5c, 0x4c, 0xcc, 0x63,
+	0x33, 0x0b, 0xed, 0xf5, 0xe8, 0x31, 0x51, 0x5d, 0x28, 0x3f, 0x85, 0xc3, 0x25, 0xe1, 0x49, 0x4a,
+	0xe7, 0x82, 0x2d, 0x38, 0x49, 0x85, 0xe5, 0xd8, 0x03, 0x83, 0x46, 0x06, 0x44, 0x4f, 0xc1, 0x17,
+	0xcb, 0x52, 0x26, 0xd9, 0xf7, 0x5c, 0xb3, 0x54, 0x0d, 0x47, 0x43, 0xb7, 0x8f, 0x2a, 0x7c, 0x66,
+	0xe0, 0x90, 0xc1, 0xc7, 0x11, 0x95, 0xf5, 0x80, 0xff, 0x9f, 0x0f, 0xf5, 0xc1, 0x77, 0x46, 0x48,
+	0xe1, 0x30, 0xa2, 0x52, 0x8d, 0x96, 0xf7, 0x47, 0xc9, 0x92, 0x3a, 0x8a, 0x1a, 0x38, 0x4f, 0x77,
+	0xa2, 0x3c, 0x6c, 0x0c, 0xdc, 0x9d, 0x30, 0x7f, 0x00, 0x14, 0x51, 0x79, 0x95, 0x09, 0xf6, 0xfe,
+	0x57, 0xe6, 0xbe, 0x50, 0xfa, 0x9d, 0xec, 0x6e, 0xbd, 0x93, 0xdb, 0x46, 0x5b, 0x1f, 0xff, 0xab,
+	0x0d, 0x10, 0xd9, 0x3f, 0x2f, 0xaf, 0x33, 0xf4, 0xab, 0xcd, 0xd3, 0xf2, 0xd1, 0xbe, 0xe7, 0xe9,
+	0xd1, 0x27, 0x3b, 0xa8, 0x69, 0xd5, 0xf0, 0xe0, 0xb9, 0x83, 0xc6, 0xe0, 0xe2, 0x92, 0xa3, 0xa1,
+	0xb5, 0xd0, 0xb7, 0xf4, 0xd1, 0x03, 0xab, 0x99, 0x6b, 0x2d, 0x3c, 0x18, 0x3b, 0xcf, 0x1d, 0xf4,
+	0x3b, 0xe8, 0x57, 0xcd, 0x8e, 0xaa, 0xa7, 0xc2, 0xce, 0x98, 0x38, 0xfa, 0xc1, 0x1d, 0xbc, 0x0a,
+	0x85, 0x9e, 0x81, 0x77, 0x22, 0x25, 0x89, 0x97, 0x1f, 0x18, 0xee, 0x39, 0xf4, 0xec, 0x8c, 0x40,
+	0xd5, 0xf6, 0xb7, 0x67, 0xc6, 0x51, 0xb5, 0x88, 0xf9, 0xa7, 0x76, 0x80, 0xbe, 0xd4, 0xd3, 0xc1,
+	0xb6, 0x0e, 0xaa, 0x2a, 0x53, 0x0f, 0x8c, 0x23, 0xbf, 0x86, 0x4c, 0xbb, 0xeb, 0x0c, 0xbc, 0x04,
+	0xa8, 0x1b, 0x19, 0x05, 0xd6, 0xe6, 0x4e, 0x6f, 0xdf, 0x09, 0xf7, 0x15, 0xa0, 0xbb, 0xcd, 0x88,
+	0x46, 0xb5, 0xff, 0xfe, 0x3e, 0xbd, 0xb3, 0xce, 0xaf, 0x61, 0xd8, 0x64, 0x3e, 0x3a, 0xaa, 0x57,
+	0xd8, 0x6d, 0x87, 0x3b, 0xbe, 0xcf, 0xa1, 0x67, 0xa9, 0xbc, 0x49, 0xd2, 0x36, 0xb5, 0xef, 0x78,
+	0xfc, 0x12, 0x06, 0x0d, 0x56, 0xa2, 0xc7, 0xb5, 0xd7, 0x0e, 0x53, 0x77, 0x3d, 0xaf, 0xbb, 0x5a,
+	0x7d, 0xf1, 0x9f, 0x01, 0x00, 0xe2, 0x90, 0xda, 0x1a, 0x36, 0x0f, 0x00, 0x00,
 }
@@ -36,6 +36,26 @@ func (UnimplementedShenzhenGoClient) Run(ctx context.Context, opts ...grpcweb.Ca
 	return nil, nil
 }
 
+// ListRuns does nothing and returns nil, nil.
+func (UnimplementedShenzhenGoClient) ListRuns(ctx context.Context, in *ListRunsRequest, opts ...grpcweb.CallOption) (*ListRunsResponse, error) {
+	return nil, nil
+}
+
+// AttachRun does nothing and returns nil, nil.
+func (UnimplementedShenzhenGoClient) AttachRun(ctx context.Context, opts ...grpcweb.CallOption) (ShenzhenGo_AttachRunClient, error) {
+	return nil, nil
+}
+
+// KillRun does nothing and returns nil, nil.
+func (UnimplementedShenzhenGoClient) KillRun(ctx context.Context, in *KillRunRequest, opts ...grpcweb.CallOption) (*Empty, error) {
+	return nil, nil
+}
+
+// TapChannel does nothing and returns nil, nil.
+func (UnimplementedShenzhenGoClient) TapChannel(ctx context.Context, in *TapRequest, opts ...grpcweb.CallOption) (ShenzhenGo_TapChannelClient, error) {
+	return nil, nil
+}
+
 // SetChannel does nothing and returns nil, nil.
 func (UnimplementedShenzhenGoClient) SetChannel(ctx context.Context, in *SetChannelRequest, opts ...grpcweb.CallOption) (*Empty, error) {
 	return nil, nil
@@ -46,6 +66,11 @@ func (UnimplementedShenzhenGoClient) SetGraphProperties(ctx context.Context, in
 	return nil, nil
 }
 
+// SetRunConfig does nothing and returns nil, nil.
+func (UnimplementedShenzhenGoClient) SetRunConfig(ctx context.Context, in *SetRunConfigRequest, opts ...grpcweb.CallOption) (*Empty, error) {
+	return nil, nil
+}
+
 // SetNode does nothing and returns nil, nil.
 func (UnimplementedShenzhenGoClient) SetNode(ctx context.Context, in *SetNodeRequest, opts ...grpcweb.CallOption) (*Empty, error) {
 	return nil, nil
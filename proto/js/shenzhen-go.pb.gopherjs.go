@@ -13,11 +13,24 @@
 		ChannelConfig
 		NodeConfig
 		ActionRequest
+		Diagnostic
 		ActionResponse
+		RunConfig
+		DebugCommand
 		Input
 		Output
+		HeldValue
+		NodeProfile
+		ChannelStats
+		RunInfo
+		ListRunsRequest
+		ListRunsResponse
+		KillRunRequest
+		TapRequest
+		TapSample
 		SetChannelRequest
 		SetGraphPropertiesRequest
+		SetRunConfigRequest
 		SetNodeRequest
 		SetPositionRequest
 */
@@ -38,11 +51,16 @@ const _ = jspb.JspbPackageIsVersion2
 type ActionRequest_Action int
 
 const (
-	ActionRequest_SAVE     ActionRequest_Action = 0
-	ActionRequest_REVERT   ActionRequest_Action = 1
-	ActionRequest_GENERATE ActionRequest_Action = 2
-	ActionRequest_BUILD    ActionRequest_Action = 3
-	ActionRequest_INSTALL  ActionRequest_Action = 4
+	ActionRequest_SAVE       ActionRequest_Action = 0
+	ActionRequest_REVERT     ActionRequest_Action = 1
+	ActionRequest_GENERATE   ActionRequest_Action = 2
+	ActionRequest_BUILD      ActionRequest_Action = 3
+	ActionRequest_INSTALL    ActionRequest_Action = 4
+	ActionRequest_RESTORE    ActionRequest_Action = 5
+	ActionRequest_DISCARD    ActionRequest_Action = 6
+	ActionRequest_TEST       ActionRequest_Action = 7
+	ActionRequest_TEST_STUBS ActionRequest_Action = 8
+	ActionRequest_BENCH      ActionRequest_Action = 9
 )
 
 var ActionRequest_Action_name = map[int]string{
@@ -51,19 +69,58 @@ var ActionRequest_Action_name = map[int]string{
 	2: "GENERATE",
 	3: "BUILD",
 	4: "INSTALL",
+	5: "RESTORE",
+	6: "DISCARD",
+	7: "TEST",
+	8: "TEST_STUBS",
+	9: "BENCH",
 }
 var ActionRequest_Action_value = map[string]int{
-	"SAVE":     0,
-	"REVERT":   1,
-	"GENERATE": 2,
-	"BUILD":    3,
-	"INSTALL":  4,
+	"SAVE":       0,
+	"REVERT":     1,
+	"GENERATE":   2,
+	"BUILD":      3,
+	"INSTALL":    4,
+	"RESTORE":    5,
+	"DISCARD":    6,
+	"TEST":       7,
+	"TEST_STUBS": 8,
+	"BENCH":      9,
 }
 
 func (x ActionRequest_Action) String() string {
 	return ActionRequest_Action_name[int(x)]
 }
 
+type DebugCommand_Op int
+
+const (
+	DebugCommand_BREAK    DebugCommand_Op = 0
+	DebugCommand_CLEAR    DebugCommand_Op = 1
+	DebugCommand_STEP     DebugCommand_Op = 2
+	DebugCommand_CONTINUE DebugCommand_Op = 3
+	DebugCommand_DROP     DebugCommand_Op = 4
+)
+
+var DebugCommand_Op_name = map[int]string{
+	0: "BREAK",
+	1: "CLEAR",
+	2: "STEP",
+	3: "CONTINUE",
+	4: "DROP",
+}
+var DebugCommand_Op_value = map[string]int{
+	"BREAK":    0,
+	"CLEAR":    1,
+	"STEP":     2,
+	"CONTINUE": 3,
+	"DROP":     4,
+}
+
+func (x DebugCommand_Op) String() string {
+	return DebugCommand_Op_name[int(x)]
+}
+
 type Empty struct {
 }
 
@@ -297,6 +354,9 @@ type NodeConfig struct {
 	PartType     string
 	X            float64
 	Y            float64
+	PanicPolicy  string
+	MaxRestarts  int64
+	Configurable []string
 }
 
 // GetName gets the Name of the NodeConfig.
@@ -371,6 +431,30 @@ func (m *NodeConfig) GetY() (x float64) {
 	return m.Y
 }
 
+// GetPanicPolicy gets the PanicPolicy of the NodeConfig.
+func (m *NodeConfig) GetPanicPolicy() (x string) {
+	if m == nil {
+		return x
+	}
+	return m.PanicPolicy
+}
+
+// GetMaxRestarts gets the MaxRestarts of the NodeConfig.
+func (m *NodeConfig) GetMaxRestarts() (x int64) {
+	if m == nil {
+		return x
+	}
+	return m.MaxRestarts
+}
+
+// GetConfigurable gets the Configurable of the NodeConfig.
+func (m *NodeConfig) GetConfigurable() (x []string) {
+	if m == nil {
+		return x
+	}
+	return m.Configurable
+}
+
 // MarshalToWriter marshals NodeConfig to the provided writer.
 func (m *NodeConfig) MarshalToWriter(writer jspb.Writer) {
 	if m == nil {
@@ -413,6 +497,18 @@ func (m *NodeConfig) MarshalToWriter(writer jspb.Writer) {
 		writer.WriteFloat64(9, m.Y)
 	}
 
+	if len(m.PanicPolicy) > 0 {
+		writer.WriteString(10, m.PanicPolicy)
+	}
+
+	if m.MaxRestarts != 0 {
+		writer.WriteInt64(11, m.MaxRestarts)
+	}
+
+	for _, val := range m.Configurable {
+		writer.WriteString(12, val)
+	}
+
 	return
 }
 
@@ -449,6 +545,12 @@ func (m *NodeConfig) UnmarshalFromReader(reader jspb.Reader) *NodeConfig {
 			m.X = reader.ReadFloat64()
 		case 9:
 			m.Y = reader.ReadFloat64()
+		case 10:
+			m.PanicPolicy = reader.ReadString()
+		case 11:
+			m.MaxRestarts = reader.ReadInt64()
+		case 12:
+			m.Configurable = append(m.Configurable, reader.ReadString())
 		default:
 			reader.SkipField()
 		}
@@ -473,6 +575,7 @@ func (m *NodeConfig) Unmarshal(rawBytes []byte) (*NodeConfig, error) {
 type ActionRequest struct {
 	Graph  string
 	Action ActionRequest_Action
+	Race   bool
 }
 
 // GetGraph gets the Graph of the ActionRequest.
@@ -491,6 +594,14 @@ func (m *ActionRequest) GetAction() (x ActionRequest_Action) {
 	return m.Action
 }
 
+// GetRace gets the Race of the ActionRequest.
+func (m *ActionRequest) GetRace() (x bool) {
+	if m == nil {
+		return x
+	}
+	return m.Race
+}
+
 // MarshalToWriter marshals ActionRequest to the provided writer.
 func (m *ActionRequest) MarshalToWriter(writer jspb.Writer) {
 	if m == nil {
@@ -505,28 +616,1427 @@ func (m *ActionRequest) MarshalToWriter(writer jspb.Writer) {
 		writer.WriteEnum(2, int(m.Action))
 	}
 
+	if m.Race {
+		writer.WriteBool(3, m.Race)
+	}
+
+	return
+}
+
+// Marshal marshals ActionRequest to a slice of bytes.
+func (m *ActionRequest) Marshal() []byte {
+	writer := jspb.NewWriter()
+	m.MarshalToWriter(writer)
+	return writer.GetResult()
+}
+
+// UnmarshalFromReader unmarshals a ActionRequest from the provided reader.
+func (m *ActionRequest) UnmarshalFromReader(reader jspb.Reader) *ActionRequest {
+	for reader.Next() {
+		if m == nil {
+			m = &ActionRequest{}
+		}
+
+		switch reader.GetFieldNumber() {
+		case 1:
+			m.Graph = reader.ReadString()
+		case 2:
+			m.Action = ActionRequest_Action(reader.ReadEnum())
+		case 3:
+			m.Race = reader.ReadBool()
+		default:
+			reader.SkipField()
+		}
+	}
+
+	return m
+}
+
+// Unmarshal unmarshals a ActionRequest from a slice of bytes.
+func (m *ActionRequest) Unmarshal(rawBytes []byte) (*ActionRequest, error) {
+	reader := jspb.NewReader(rawBytes)
+
+	m = m.UnmarshalFromReader(reader)
+
+	if err := reader.Err(); err != nil {
+		return nil, err
+	}
+
+	return m, nil
+}
+
+// Diagnostic is a problem found in part of a node's implementation.
+type Diagnostic struct {
+	Node    string
+	Section string
+	Line    int64
+	Message string
+}
+
+// GetNode gets the Node of the Diagnostic.
+func (m *Diagnostic) GetNode() (x string) {
+	if m == nil {
+		return x
+	}
+	return m.Node
+}
+
+// GetSection gets the Section of the Diagnostic.
+func (m *Diagnostic) GetSection() (x string) {
+	if m == nil {
+		return x
+	}
+	return m.Section
+}
+
+// GetLine gets the Line of the Diagnostic.
+func (m *Diagnostic) GetLine() (x int64) {
+	if m == nil {
+		return x
+	}
+	return m.Line
+}
+
+// GetMessage gets the Message of the Diagnostic.
+func (m *Diagnostic) GetMessage() (x string) {
+	if m == nil {
+		return x
+	}
+	return m.Message
+}
+
+// MarshalToWriter marshals Diagnostic to the provided writer.
+func (m *Diagnostic) MarshalToWriter(writer jspb.Writer) {
+	if m == nil {
+		return
+	}
+
+	if len(m.Node) > 0 {
+		writer.WriteString(1, m.Node)
+	}
+
+	if len(m.Section) > 0 {
+		writer.WriteString(2, m.Section)
+	}
+
+	if m.Line != 0 {
+		writer.WriteInt64(3, m.Line)
+	}
+
+	if len(m.Message) > 0 {
+		writer.WriteString(4, m.Message)
+	}
+
+	return
+}
+
+// Marshal marshals Diagnostic to a slice of bytes.
+func (m *Diagnostic) Marshal() []byte {
+	writer := jspb.NewWriter()
+	m.MarshalToWriter(writer)
+	return writer.GetResult()
+}
+
+// UnmarshalFromReader unmarshals a Diagnostic from the provided reader.
+func (m *Diagnostic) UnmarshalFromReader(reader jspb.Reader) *Diagnostic {
+	for reader.Next() {
+		if m == nil {
+			m = &Diagnostic{}
+		}
+
+		switch reader.GetFieldNumber() {
+		case 1:
+			m.Node = reader.ReadString()
+		case 2:
+			m.Section = reader.ReadString()
+		case 3:
+			m.Line = reader.ReadInt64()
+		case 4:
+			m.Message = reader.ReadString()
+		default:
+			reader.SkipField()
+		}
+	}
+
+	return m
+}
+
+// Unmarshal unmarshals a Diagnostic from a slice of bytes.
+func (m *Diagnostic) Unmarshal(rawBytes []byte) (*Diagnostic, error) {
+	reader := jspb.NewReader(rawBytes)
+
+	m = m.UnmarshalFromReader(reader)
+
+	if err := reader.Err(); err != nil {
+		return nil, err
+	}
+
+	return m, nil
+}
+
+type ActionResponse struct {
+	Output      string
+	Diagnostics []*Diagnostic
+}
+
+// GetOutput gets the Output of the ActionResponse.
+func (m *ActionResponse) GetOutput() (x string) {
+	if m == nil {
+		return x
+	}
+	return m.Output
+}
+
+// GetDiagnostics gets the Diagnostics of the ActionResponse.
+func (m *ActionResponse) GetDiagnostics() (x []*Diagnostic) {
+	if m == nil {
+		return x
+	}
+	return m.Diagnostics
+}
+
+// MarshalToWriter marshals ActionResponse to the provided writer.
+func (m *ActionResponse) MarshalToWriter(writer jspb.Writer) {
+	if m == nil {
+		return
+	}
+
+	if len(m.Output) > 0 {
+		writer.WriteString(1, m.Output)
+	}
+
+	for _, msg := range m.Diagnostics {
+		writer.WriteMessage(2, func() {
+			msg.MarshalToWriter(writer)
+		})
+	}
+
+	return
+}
+
+// Marshal marshals ActionResponse to a slice of bytes.
+func (m *ActionResponse) Marshal() []byte {
+	writer := jspb.NewWriter()
+	m.MarshalToWriter(writer)
+	return writer.GetResult()
+}
+
+// UnmarshalFromReader unmarshals a ActionResponse from the provided reader.
+func (m *ActionResponse) UnmarshalFromReader(reader jspb.Reader) *ActionResponse {
+	for reader.Next() {
+		if m == nil {
+			m = &ActionResponse{}
+		}
+
+		switch reader.GetFieldNumber() {
+		case 1:
+			m.Output = reader.ReadString()
+		case 2:
+			reader.ReadMessage(func() {
+				m.Diagnostics = append(m.Diagnostics, new(Diagnostic).UnmarshalFromReader(reader))
+			})
+		default:
+			reader.SkipField()
+		}
+	}
+
+	return m
+}
+
+// Unmarshal unmarshals a ActionResponse from a slice of bytes.
+func (m *ActionResponse) Unmarshal(rawBytes []byte) (*ActionResponse, error) {
+	reader := jspb.NewReader(rawBytes)
+
+	m = m.UnmarshalFromReader(reader)
+
+	if err := reader.Err(); err != nil {
+		return nil, err
+	}
+
+	return m, nil
+}
+
+type RunConfig struct {
+	Args       []string
+	Env        []string
+	Dir        string
+	Race       bool
+	Profile    bool
+	Instrument bool
+	Debug      bool
+	Record     []string
+	RecordFile string
+	Replay     []string
+	ReplayFile string
+}
+
+// GetArgs gets the Args of the RunConfig.
+func (m *RunConfig) GetArgs() (x []string) {
+	if m == nil {
+		return x
+	}
+	return m.Args
+}
+
+// GetEnv gets the Env of the RunConfig.
+func (m *RunConfig) GetEnv() (x []string) {
+	if m == nil {
+		return x
+	}
+	return m.Env
+}
+
+// GetDir gets the Dir of the RunConfig.
+func (m *RunConfig) GetDir() (x string) {
+	if m == nil {
+		return x
+	}
+	return m.Dir
+}
+
+// GetRace gets the Race of the RunConfig.
+func (m *RunConfig) GetRace() (x bool) {
+	if m == nil {
+		return x
+	}
+	return m.Race
+}
+
+// GetProfile gets the Profile of the RunConfig.
+func (m *RunConfig) GetProfile() (x bool) {
+	if m == nil {
+		return x
+	}
+	return m.Profile
+}
+
+// GetInstrument gets the Instrument of the RunConfig.
+func (m *RunConfig) GetInstrument() (x bool) {
+	if m == nil {
+		return x
+	}
+	return m.Instrument
+}
+
+// GetDebug gets the Debug of the RunConfig.
+func (m *RunConfig) GetDebug() (x bool) {
+	if m == nil {
+		return x
+	}
+	return m.Debug
+}
+
+// GetRecord gets the Record of the RunConfig.
+func (m *RunConfig) GetRecord() (x []string) {
+	if m == nil {
+		return x
+	}
+	return m.Record
+}
+
+// GetRecordFile gets the RecordFile of the RunConfig.
+func (m *RunConfig) GetRecordFile() (x string) {
+	if m == nil {
+		return x
+	}
+	return m.RecordFile
+}
+
+// GetReplay gets the Replay of the RunConfig.
+func (m *RunConfig) GetReplay() (x []string) {
+	if m == nil {
+		return x
+	}
+	return m.Replay
+}
+
+// GetReplayFile gets the ReplayFile of the RunConfig.
+func (m *RunConfig) GetReplayFile() (x string) {
+	if m == nil {
+		return x
+	}
+	return m.ReplayFile
+}
+
+// MarshalToWriter marshals RunConfig to the provided writer.
+func (m *RunConfig) MarshalToWriter(writer jspb.Writer) {
+	if m == nil {
+		return
+	}
+
+	for _, val := range m.Args {
+		writer.WriteString(1, val)
+	}
+
+	for _, val := range m.Env {
+		writer.WriteString(2, val)
+	}
+
+	if len(m.Dir) > 0 {
+		writer.WriteString(3, m.Dir)
+	}
+
+	if m.Race {
+		writer.WriteBool(4, m.Race)
+	}
+
+	if m.Profile {
+		writer.WriteBool(5, m.Profile)
+	}
+
+	if m.Instrument {
+		writer.WriteBool(6, m.Instrument)
+	}
+
+	if m.Debug {
+		writer.WriteBool(7, m.Debug)
+	}
+
+	for _, val := range m.Record {
+		writer.WriteString(8, val)
+	}
+
+	if len(m.RecordFile) > 0 {
+		writer.WriteString(9, m.RecordFile)
+	}
+
+	for _, val := range m.Replay {
+		writer.WriteString(10, val)
+	}
+
+	if len(m.ReplayFile) > 0 {
+		writer.WriteString(11, m.ReplayFile)
+	}
+
+	return
+}
+
+// Marshal marshals RunConfig to a slice of bytes.
+func (m *RunConfig) Marshal() []byte {
+	writer := jspb.NewWriter()
+	m.MarshalToWriter(writer)
+	return writer.GetResult()
+}
+
+// UnmarshalFromReader unmarshals a RunConfig from the provided reader.
+func (m *RunConfig) UnmarshalFromReader(reader jspb.Reader) *RunConfig {
+	for reader.Next() {
+		if m == nil {
+			m = &RunConfig{}
+		}
+
+		switch reader.GetFieldNumber() {
+		case 1:
+			m.Args = append(m.Args, reader.ReadString())
+		case 2:
+			m.Env = append(m.Env, reader.ReadString())
+		case 3:
+			m.Dir = reader.ReadString()
+		case 4:
+			m.Race = reader.ReadBool()
+		case 5:
+			m.Profile = reader.ReadBool()
+		case 6:
+			m.Instrument = reader.ReadBool()
+		case 7:
+			m.Debug = reader.ReadBool()
+		case 8:
+			m.Record = append(m.Record, reader.ReadString())
+		case 9:
+			m.RecordFile = reader.ReadString()
+		case 10:
+			m.Replay = append(m.Replay, reader.ReadString())
+		case 11:
+			m.ReplayFile = reader.ReadString()
+		default:
+			reader.SkipField()
+		}
+	}
+
+	return m
+}
+
+// Unmarshal unmarshals a RunConfig from a slice of bytes.
+func (m *RunConfig) Unmarshal(rawBytes []byte) (*RunConfig, error) {
+	reader := jspb.NewReader(rawBytes)
+
+	m = m.UnmarshalFromReader(reader)
+
+	if err := reader.Err(); err != nil {
+		return nil, err
+	}
+
+	return m, nil
+}
+
+// DebugCommand controls a breakpoint on a channel in a debug run.
+type DebugCommand struct {
+	Channel string
+	Op      DebugCommand_Op
+}
+
+// GetChannel gets the Channel of the DebugCommand.
+func (m *DebugCommand) GetChannel() (x string) {
+	if m == nil {
+		return x
+	}
+	return m.Channel
+}
+
+// GetOp gets the Op of the DebugCommand.
+func (m *DebugCommand) GetOp() (x DebugCommand_Op) {
+	if m == nil {
+		return x
+	}
+	return m.Op
+}
+
+// MarshalToWriter marshals DebugCommand to the provided writer.
+func (m *DebugCommand) MarshalToWriter(writer jspb.Writer) {
+	if m == nil {
+		return
+	}
+
+	if len(m.Channel) > 0 {
+		writer.WriteString(1, m.Channel)
+	}
+
+	if int(m.Op) != 0 {
+		writer.WriteEnum(2, int(m.Op))
+	}
+
+	return
+}
+
+// Marshal marshals DebugCommand to a slice of bytes.
+func (m *DebugCommand) Marshal() []byte {
+	writer := jspb.NewWriter()
+	m.MarshalToWriter(writer)
+	return writer.GetResult()
+}
+
+// UnmarshalFromReader unmarshals a DebugCommand from the provided reader.
+func (m *DebugCommand) UnmarshalFromReader(reader jspb.Reader) *DebugCommand {
+	for reader.Next() {
+		if m == nil {
+			m = &DebugCommand{}
+		}
+
+		switch reader.GetFieldNumber() {
+		case 1:
+			m.Channel = reader.ReadString()
+		case 2:
+			m.Op = DebugCommand_Op(reader.ReadEnum())
+		default:
+			reader.SkipField()
+		}
+	}
+
+	return m
+}
+
+// Unmarshal unmarshals a DebugCommand from a slice of bytes.
+func (m *DebugCommand) Unmarshal(rawBytes []byte) (*DebugCommand, error) {
+	reader := jspb.NewReader(rawBytes)
+
+	m = m.UnmarshalFromReader(reader)
+
+	if err := reader.Err(); err != nil {
+		return nil, err
+	}
+
+	return m, nil
+}
+
+type Input struct {
+	Graph       string
+	In          string
+	Config      *RunConfig
+	RunConfig   string
+	RunId       string
+	Debug       *DebugCommand
+	Breakpoints []string
+}
+
+// GetGraph gets the Graph of the Input.
+func (m *Input) GetGraph() (x string) {
+	if m == nil {
+		return x
+	}
+	return m.Graph
+}
+
+// GetIn gets the In of the Input.
+func (m *Input) GetIn() (x string) {
+	if m == nil {
+		return x
+	}
+	return m.In
+}
+
+// GetConfig gets the Config of the Input.
+func (m *Input) GetConfig() (x *RunConfig) {
+	if m == nil {
+		return x
+	}
+	return m.Config
+}
+
+// GetRunConfig gets the RunConfig of the Input.
+func (m *Input) GetRunConfig() (x string) {
+	if m == nil {
+		return x
+	}
+	return m.RunConfig
+}
+
+// GetRunId gets the RunId of the Input.
+func (m *Input) GetRunId() (x string) {
+	if m == nil {
+		return x
+	}
+	return m.RunId
+}
+
+// GetDebug gets the Debug of the Input.
+func (m *Input) GetDebug() (x *DebugCommand) {
+	if m == nil {
+		return x
+	}
+	return m.Debug
+}
+
+// GetBreakpoints gets the Breakpoints of the Input.
+func (m *Input) GetBreakpoints() (x []string) {
+	if m == nil {
+		return x
+	}
+	return m.Breakpoints
+}
+
+// MarshalToWriter marshals Input to the provided writer.
+func (m *Input) MarshalToWriter(writer jspb.Writer) {
+	if m == nil {
+		return
+	}
+
+	if len(m.Graph) > 0 {
+		writer.WriteString(1, m.Graph)
+	}
+
+	if len(m.In) > 0 {
+		writer.WriteString(2, m.In)
+	}
+
+	if m.Config != nil {
+		writer.WriteMessage(3, func() {
+			m.Config.MarshalToWriter(writer)
+		})
+	}
+
+	if len(m.RunConfig) > 0 {
+		writer.WriteString(4, m.RunConfig)
+	}
+
+	if len(m.RunId) > 0 {
+		writer.WriteString(5, m.RunId)
+	}
+
+	if m.Debug != nil {
+		writer.WriteMessage(6, func() {
+			m.Debug.MarshalToWriter(writer)
+		})
+	}
+
+	for _, val := range m.Breakpoints {
+		writer.WriteString(7, val)
+	}
+
+	return
+}
+
+// Marshal marshals Input to a slice of bytes.
+func (m *Input) Marshal() []byte {
+	writer := jspb.NewWriter()
+	m.MarshalToWriter(writer)
+	return writer.GetResult()
+}
+
+// UnmarshalFromReader unmarshals a Input from the provided reader.
+func (m *Input) UnmarshalFromReader(reader jspb.Reader) *Input {
+	for reader.Next() {
+		if m == nil {
+			m = &Input{}
+		}
+
+		switch reader.GetFieldNumber() {
+		case 1:
+			m.Graph = reader.ReadString()
+		case 2:
+			m.In = reader.ReadString()
+		case 3:
+			reader.ReadMessage(func() {
+				m.Config = m.Config.UnmarshalFromReader(reader)
+			})
+		case 4:
+			m.RunConfig = reader.ReadString()
+		case 5:
+			m.RunId = reader.ReadString()
+		case 6:
+			reader.ReadMessage(func() {
+				m.Debug = m.Debug.UnmarshalFromReader(reader)
+			})
+		case 7:
+			m.Breakpoints = append(m.Breakpoints, reader.ReadString())
+		default:
+			reader.SkipField()
+		}
+	}
+
+	return m
+}
+
+// Unmarshal unmarshals a Input from a slice of bytes.
+func (m *Input) Unmarshal(rawBytes []byte) (*Input, error) {
+	reader := jspb.NewReader(rawBytes)
+
+	m = m.UnmarshalFromReader(reader)
+
+	if err := reader.Err(); err != nil {
+		return nil, err
+	}
+
+	return m, nil
+}
+
+type Output struct {
+	Out         string
+	Err         string
+	RunId       string
+	Diagnostics []*Diagnostic
+	Profile     []*NodeProfile
+	Channels    []*ChannelStats
+	Held        *HeldValue
+	Released    string
+}
+
+// GetOut gets the Out of the Output.
+func (m *Output) GetOut() (x string) {
+	if m == nil {
+		return x
+	}
+	return m.Out
+}
+
+// GetErr gets the Err of the Output.
+func (m *Output) GetErr() (x string) {
+	if m == nil {
+		return x
+	}
+	return m.Err
+}
+
+// GetRunId gets the RunId of the Output.
+func (m *Output) GetRunId() (x string) {
+	if m == nil {
+		return x
+	}
+	return m.RunId
+}
+
+// GetDiagnostics gets the Diagnostics of the Output.
+func (m *Output) GetDiagnostics() (x []*Diagnostic) {
+	if m == nil {
+		return x
+	}
+	return m.Diagnostics
+}
+
+// GetProfile gets the Profile of the Output.
+func (m *Output) GetProfile() (x []*NodeProfile) {
+	if m == nil {
+		return x
+	}
+	return m.Profile
+}
+
+// GetChannels gets the Channels of the Output.
+func (m *Output) GetChannels() (x []*ChannelStats) {
+	if m == nil {
+		return x
+	}
+	return m.Channels
+}
+
+// GetHeld gets the Held of the Output.
+func (m *Output) GetHeld() (x *HeldValue) {
+	if m == nil {
+		return x
+	}
+	return m.Held
+}
+
+// GetReleased gets the Released of the Output.
+func (m *Output) GetReleased() (x string) {
+	if m == nil {
+		return x
+	}
+	return m.Released
+}
+
+// MarshalToWriter marshals Output to the provided writer.
+func (m *Output) MarshalToWriter(writer jspb.Writer) {
+	if m == nil {
+		return
+	}
+
+	if len(m.Out) > 0 {
+		writer.WriteString(1, m.Out)
+	}
+
+	if len(m.Err) > 0 {
+		writer.WriteString(2, m.Err)
+	}
+
+	if len(m.RunId) > 0 {
+		writer.WriteString(3, m.RunId)
+	}
+
+	for _, msg := range m.Diagnostics {
+		writer.WriteMessage(4, func() {
+			msg.MarshalToWriter(writer)
+		})
+	}
+
+	for _, msg := range m.Profile {
+		writer.WriteMessage(5, func() {
+			msg.MarshalToWriter(writer)
+		})
+	}
+
+	for _, msg := range m.Channels {
+		writer.WriteMessage(6, func() {
+			msg.MarshalToWriter(writer)
+		})
+	}
+
+	if m.Held != nil {
+		writer.WriteMessage(7, func() {
+			m.Held.MarshalToWriter(writer)
+		})
+	}
+
+	if len(m.Released) > 0 {
+		writer.WriteString(8, m.Released)
+	}
+
+	return
+}
+
+// Marshal marshals Output to a slice of bytes.
+func (m *Output) Marshal() []byte {
+	writer := jspb.NewWriter()
+	m.MarshalToWriter(writer)
+	return writer.GetResult()
+}
+
+// UnmarshalFromReader unmarshals a Output from the provided reader.
+func (m *Output) UnmarshalFromReader(reader jspb.Reader) *Output {
+	for reader.Next() {
+		if m == nil {
+			m = &Output{}
+		}
+
+		switch reader.GetFieldNumber() {
+		case 1:
+			m.Out = reader.ReadString()
+		case 2:
+			m.Err = reader.ReadString()
+		case 3:
+			m.RunId = reader.ReadString()
+		case 4:
+			reader.ReadMessage(func() {
+				m.Diagnostics = append(m.Diagnostics, new(Diagnostic).UnmarshalFromReader(reader))
+			})
+		case 5:
+			reader.ReadMessage(func() {
+				m.Profile = append(m.Profile, new(NodeProfile).UnmarshalFromReader(reader))
+			})
+		case 6:
+			reader.ReadMessage(func() {
+				m.Channels = append(m.Channels, new(ChannelStats).UnmarshalFromReader(reader))
+			})
+		case 7:
+			reader.ReadMessage(func() {
+				m.Held = m.Held.UnmarshalFromReader(reader)
+			})
+		case 8:
+			m.Released = reader.ReadString()
+		default:
+			reader.SkipField()
+		}
+	}
+
+	return m
+}
+
+// Unmarshal unmarshals a Output from a slice of bytes.
+func (m *Output) Unmarshal(rawBytes []byte) (*Output, error) {
+	reader := jspb.NewReader(rawBytes)
+
+	m = m.UnmarshalFromReader(reader)
+
+	if err := reader.Err(); err != nil {
+		return nil, err
+	}
+
+	return m, nil
+}
+
+// HeldValue is a value held at a breakpoint on a channel.
+type HeldValue struct {
+	Channel string
+	Value   string
+}
+
+// GetChannel gets the Channel of the HeldValue.
+func (m *HeldValue) GetChannel() (x string) {
+	if m == nil {
+		return x
+	}
+	return m.Channel
+}
+
+// GetValue gets the Value of the HeldValue.
+func (m *HeldValue) GetValue() (x string) {
+	if m == nil {
+		return x
+	}
+	return m.Value
+}
+
+// MarshalToWriter marshals HeldValue to the provided writer.
+func (m *HeldValue) MarshalToWriter(writer jspb.Writer) {
+	if m == nil {
+		return
+	}
+
+	if len(m.Channel) > 0 {
+		writer.WriteString(1, m.Channel)
+	}
+
+	if len(m.Value) > 0 {
+		writer.WriteString(2, m.Value)
+	}
+
+	return
+}
+
+// Marshal marshals HeldValue to a slice of bytes.
+func (m *HeldValue) Marshal() []byte {
+	writer := jspb.NewWriter()
+	m.MarshalToWriter(writer)
+	return writer.GetResult()
+}
+
+// UnmarshalFromReader unmarshals a HeldValue from the provided reader.
+func (m *HeldValue) UnmarshalFromReader(reader jspb.Reader) *HeldValue {
+	for reader.Next() {
+		if m == nil {
+			m = &HeldValue{}
+		}
+
+		switch reader.GetFieldNumber() {
+		case 1:
+			m.Channel = reader.ReadString()
+		case 2:
+			m.Value = reader.ReadString()
+		default:
+			reader.SkipField()
+		}
+	}
+
+	return m
+}
+
+// Unmarshal unmarshals a HeldValue from a slice of bytes.
+func (m *HeldValue) Unmarshal(rawBytes []byte) (*HeldValue, error) {
+	reader := jspb.NewReader(rawBytes)
+
+	m = m.UnmarshalFromReader(reader)
+
+	if err := reader.Err(); err != nil {
+		return nil, err
+	}
+
+	return m, nil
+}
+
+// NodeProfile is the profile data attributed to one node.
+type NodeProfile struct {
+	Node       string
+	CpuNanos   int64
+	AllocBytes int64
+}
+
+// GetNode gets the Node of the NodeProfile.
+func (m *NodeProfile) GetNode() (x string) {
+	if m == nil {
+		return x
+	}
+	return m.Node
+}
+
+// GetCpuNanos gets the CpuNanos of the NodeProfile.
+func (m *NodeProfile) GetCpuNanos() (x int64) {
+	if m == nil {
+		return x
+	}
+	return m.CpuNanos
+}
+
+// GetAllocBytes gets the AllocBytes of the NodeProfile.
+func (m *NodeProfile) GetAllocBytes() (x int64) {
+	if m == nil {
+		return x
+	}
+	return m.AllocBytes
+}
+
+// MarshalToWriter marshals NodeProfile to the provided writer.
+func (m *NodeProfile) MarshalToWriter(writer jspb.Writer) {
+	if m == nil {
+		return
+	}
+
+	if len(m.Node) > 0 {
+		writer.WriteString(1, m.Node)
+	}
+
+	if m.CpuNanos != 0 {
+		writer.WriteInt64(2, m.CpuNanos)
+	}
+
+	if m.AllocBytes != 0 {
+		writer.WriteInt64(3, m.AllocBytes)
+	}
+
+	return
+}
+
+// Marshal marshals NodeProfile to a slice of bytes.
+func (m *NodeProfile) Marshal() []byte {
+	writer := jspb.NewWriter()
+	m.MarshalToWriter(writer)
+	return writer.GetResult()
+}
+
+// UnmarshalFromReader unmarshals a NodeProfile from the provided reader.
+func (m *NodeProfile) UnmarshalFromReader(reader jspb.Reader) *NodeProfile {
+	for reader.Next() {
+		if m == nil {
+			m = &NodeProfile{}
+		}
+
+		switch reader.GetFieldNumber() {
+		case 1:
+			m.Node = reader.ReadString()
+		case 2:
+			m.CpuNanos = reader.ReadInt64()
+		case 3:
+			m.AllocBytes = reader.ReadInt64()
+		default:
+			reader.SkipField()
+		}
+	}
+
+	return m
+}
+
+// Unmarshal unmarshals a NodeProfile from a slice of bytes.
+func (m *NodeProfile) Unmarshal(rawBytes []byte) (*NodeProfile, error) {
+	reader := jspb.NewReader(rawBytes)
+
+	m = m.UnmarshalFromReader(reader)
+
+	if err := reader.Err(); err != nil {
+		return nil, err
+	}
+
+	return m, nil
+}
+
+// ChannelStats is a sample of the activity of one channel.
+type ChannelStats struct {
+	Channel string
+	Len     int64
+	Cap     int64
+	Sends   int64
+	Rate    float64
+}
+
+// GetChannel gets the Channel of the ChannelStats.
+func (m *ChannelStats) GetChannel() (x string) {
+	if m == nil {
+		return x
+	}
+	return m.Channel
+}
+
+// GetLen gets the Len of the ChannelStats.
+func (m *ChannelStats) GetLen() (x int64) {
+	if m == nil {
+		return x
+	}
+	return m.Len
+}
+
+// GetCap gets the Cap of the ChannelStats.
+func (m *ChannelStats) GetCap() (x int64) {
+	if m == nil {
+		return x
+	}
+	return m.Cap
+}
+
+// GetSends gets the Sends of the ChannelStats.
+func (m *ChannelStats) GetSends() (x int64) {
+	if m == nil {
+		return x
+	}
+	return m.Sends
+}
+
+// GetRate gets the Rate of the ChannelStats.
+func (m *ChannelStats) GetRate() (x float64) {
+	if m == nil {
+		return x
+	}
+	return m.Rate
+}
+
+// MarshalToWriter marshals ChannelStats to the provided writer.
+func (m *ChannelStats) MarshalToWriter(writer jspb.Writer) {
+	if m == nil {
+		return
+	}
+
+	if len(m.Channel) > 0 {
+		writer.WriteString(1, m.Channel)
+	}
+
+	if m.Len != 0 {
+		writer.WriteInt64(2, m.Len)
+	}
+
+	if m.Cap != 0 {
+		writer.WriteInt64(3, m.Cap)
+	}
+
+	if m.Sends != 0 {
+		writer.WriteInt64(4, m.Sends)
+	}
+
+	if m.Rate != 0 {
+		writer.WriteFloat64(5, m.Rate)
+	}
+
+	return
+}
+
+// Marshal marshals ChannelStats to a slice of bytes.
+func (m *ChannelStats) Marshal() []byte {
+	writer := jspb.NewWriter()
+	m.MarshalToWriter(writer)
+	return writer.GetResult()
+}
+
+// UnmarshalFromReader unmarshals a ChannelStats from the provided reader.
+func (m *ChannelStats) UnmarshalFromReader(reader jspb.Reader) *ChannelStats {
+	for reader.Next() {
+		if m == nil {
+			m = &ChannelStats{}
+		}
+
+		switch reader.GetFieldNumber() {
+		case 1:
+			m.Channel = reader.ReadString()
+		case 2:
+			m.Len = reader.ReadInt64()
+		case 3:
+			m.Cap = reader.ReadInt64()
+		case 4:
+			m.Sends = reader.ReadInt64()
+		case 5:
+			m.Rate = reader.ReadFloat64()
+		default:
+			reader.SkipField()
+		}
+	}
+
+	return m
+}
+
+// Unmarshal unmarshals a ChannelStats from a slice of bytes.
+func (m *ChannelStats) Unmarshal(rawBytes []byte) (*ChannelStats, error) {
+	reader := jspb.NewReader(rawBytes)
+
+	m = m.UnmarshalFromReader(reader)
+
+	if err := reader.Err(); err != nil {
+		return nil, err
+	}
+
+	return m, nil
+}
+
+type RunInfo struct {
+	Id         string
+	Graph      string
+	Args       []string
+	StartTime  int64
+	Running    bool
+	ExitStatus string
+}
+
+// GetId gets the Id of the RunInfo.
+func (m *RunInfo) GetId() (x string) {
+	if m == nil {
+		return x
+	}
+	return m.Id
+}
+
+// GetGraph gets the Graph of the RunInfo.
+func (m *RunInfo) GetGraph() (x string) {
+	if m == nil {
+		return x
+	}
+	return m.Graph
+}
+
+// GetArgs gets the Args of the RunInfo.
+func (m *RunInfo) GetArgs() (x []string) {
+	if m == nil {
+		return x
+	}
+	return m.Args
+}
+
+// GetStartTime gets the StartTime of the RunInfo.
+func (m *RunInfo) GetStartTime() (x int64) {
+	if m == nil {
+		return x
+	}
+	return m.StartTime
+}
+
+// GetRunning gets the Running of the RunInfo.
+func (m *RunInfo) GetRunning() (x bool) {
+	if m == nil {
+		return x
+	}
+	return m.Running
+}
+
+// GetExitStatus gets the ExitStatus of the RunInfo.
+func (m *RunInfo) GetExitStatus() (x string) {
+	if m == nil {
+		return x
+	}
+	return m.ExitStatus
+}
+
+// MarshalToWriter marshals RunInfo to the provided writer.
+func (m *RunInfo) MarshalToWriter(writer jspb.Writer) {
+	if m == nil {
+		return
+	}
+
+	if len(m.Id) > 0 {
+		writer.WriteString(1, m.Id)
+	}
+
+	if len(m.Graph) > 0 {
+		writer.WriteString(2, m.Graph)
+	}
+
+	for _, val := range m.Args {
+		writer.WriteString(3, val)
+	}
+
+	if m.StartTime != 0 {
+		writer.WriteInt64(4, m.StartTime)
+	}
+
+	if m.Running {
+		writer.WriteBool(5, m.Running)
+	}
+
+	if len(m.ExitStatus) > 0 {
+		writer.WriteString(6, m.ExitStatus)
+	}
+
+	return
+}
+
+// Marshal marshals RunInfo to a slice of bytes.
+func (m *RunInfo) Marshal() []byte {
+	writer := jspb.NewWriter()
+	m.MarshalToWriter(writer)
+	return writer.GetResult()
+}
+
+// UnmarshalFromReader unmarshals a RunInfo from the provided reader.
+func (m *RunInfo) UnmarshalFromReader(reader jspb.Reader) *RunInfo {
+	for reader.Next() {
+		if m == nil {
+			m = &RunInfo{}
+		}
+
+		switch reader.GetFieldNumber() {
+		case 1:
+			m.Id = reader.ReadString()
+		case 2:
+			m.Graph = reader.ReadString()
+		case 3:
+			m.Args = append(m.Args, reader.ReadString())
+		case 4:
+			m.StartTime = reader.ReadInt64()
+		case 5:
+			m.Running = reader.ReadBool()
+		case 6:
+			m.ExitStatus = reader.ReadString()
+		default:
+			reader.SkipField()
+		}
+	}
+
+	return m
+}
+
+// Unmarshal unmarshals a RunInfo from a slice of bytes.
+func (m *RunInfo) Unmarshal(rawBytes []byte) (*RunInfo, error) {
+	reader := jspb.NewReader(rawBytes)
+
+	m = m.UnmarshalFromReader(reader)
+
+	if err := reader.Err(); err != nil {
+		return nil, err
+	}
+
+	return m, nil
+}
+
+type ListRunsRequest struct {
+	Graph string
+}
+
+// GetGraph gets the Graph of the ListRunsRequest.
+func (m *ListRunsRequest) GetGraph() (x string) {
+	if m == nil {
+		return x
+	}
+	return m.Graph
+}
+
+// MarshalToWriter marshals ListRunsRequest to the provided writer.
+func (m *ListRunsRequest) MarshalToWriter(writer jspb.Writer) {
+	if m == nil {
+		return
+	}
+
+	if len(m.Graph) > 0 {
+		writer.WriteString(1, m.Graph)
+	}
+
+	return
+}
+
+// Marshal marshals ListRunsRequest to a slice of bytes.
+func (m *ListRunsRequest) Marshal() []byte {
+	writer := jspb.NewWriter()
+	m.MarshalToWriter(writer)
+	return writer.GetResult()
+}
+
+// UnmarshalFromReader unmarshals a ListRunsRequest from the provided reader.
+func (m *ListRunsRequest) UnmarshalFromReader(reader jspb.Reader) *ListRunsRequest {
+	for reader.Next() {
+		if m == nil {
+			m = &ListRunsRequest{}
+		}
+
+		switch reader.GetFieldNumber() {
+		case 1:
+			m.Graph = reader.ReadString()
+		default:
+			reader.SkipField()
+		}
+	}
+
+	return m
+}
+
+// Unmarshal unmarshals a ListRunsRequest from a slice of bytes.
+func (m *ListRunsRequest) Unmarshal(rawBytes []byte) (*ListRunsRequest, error) {
+	reader := jspb.NewReader(rawBytes)
+
+	m = m.UnmarshalFromReader(reader)
+
+	if err := reader.Err(); err != nil {
+		return nil, err
+	}
+
+	return m, nil
+}
+
+type ListRunsResponse struct {
+	Runs []*RunInfo
+}
+
+// GetRuns gets the Runs of the ListRunsResponse.
+func (m *ListRunsResponse) GetRuns() (x []*RunInfo) {
+	if m == nil {
+		return x
+	}
+	return m.Runs
+}
+
+// MarshalToWriter marshals ListRunsResponse to the provided writer.
+func (m *ListRunsResponse) MarshalToWriter(writer jspb.Writer) {
+	if m == nil {
+		return
+	}
+
+	for _, msg := range m.Runs {
+		writer.WriteMessage(1, func() {
+			msg.MarshalToWriter(writer)
+		})
+	}
+
 	return
 }
 
-// Marshal marshals ActionRequest to a slice of bytes.
-func (m *ActionRequest) Marshal() []byte {
+// Marshal marshals ListRunsResponse to a slice of bytes.
+func (m *ListRunsResponse) Marshal() []byte {
 	writer := jspb.NewWriter()
 	m.MarshalToWriter(writer)
 	return writer.GetResult()
 }
 
-// UnmarshalFromReader unmarshals a ActionRequest from the provided reader.
-func (m *ActionRequest) UnmarshalFromReader(reader jspb.Reader) *ActionRequest {
+// UnmarshalFromReader unmarshals a ListRunsResponse from the provided reader.
+func (m *ListRunsResponse) UnmarshalFromReader(reader jspb.Reader) *ListRunsResponse {
 	for reader.Next() {
 		if m == nil {
-			m = &ActionRequest{}
+			m = &ListRunsResponse{}
 		}
 
 		switch reader.GetFieldNumber() {
 		case 1:
-			m.Graph = reader.ReadString()
-		case 2:
-			m.Action = ActionRequest_Action(reader.ReadEnum())
+			reader.ReadMessage(func() {
+				m.Runs = append(m.Runs, new(RunInfo).UnmarshalFromReader(reader))
+			})
 		default:
 			reader.SkipField()
 		}
@@ -535,8 +2045,8 @@ func (m *ActionRequest) UnmarshalFromReader(reader jspb.Reader) *ActionRequest {
 	return m
 }
 
-// Unmarshal unmarshals a ActionRequest from a slice of bytes.
-func (m *ActionRequest) Unmarshal(rawBytes []byte) (*ActionRequest, error) {
+// Unmarshal unmarshals a ListRunsResponse from a slice of bytes.
+func (m *ListRunsResponse) Unmarshal(rawBytes []byte) (*ListRunsResponse, error) {
 	reader := jspb.NewReader(rawBytes)
 
 	m = m.UnmarshalFromReader(reader)
@@ -548,48 +2058,63 @@ func (m *ActionRequest) Unmarshal(rawBytes []byte) (*ActionRequest, error) {
 	return m, nil
 }
 
-type ActionResponse struct {
-	Output string
+type KillRunRequest struct {
+	Id    string
+	Force bool
 }
 
-// GetOutput gets the Output of the ActionResponse.
-func (m *ActionResponse) GetOutput() (x string) {
+// GetId gets the Id of the KillRunRequest.
+func (m *KillRunRequest) GetId() (x string) {
 	if m == nil {
 		return x
 	}
-	return m.Output
+	return m.Id
 }
 
-// MarshalToWriter marshals ActionResponse to the provided writer.
-func (m *ActionResponse) MarshalToWriter(writer jspb.Writer) {
+// GetForce gets the Force of the KillRunRequest.
+func (m *KillRunRequest) GetForce() (x bool) {
+	if m == nil {
+		return x
+	}
+	return m.Force
+}
+
+// MarshalToWriter marshals KillRunRequest to the provided writer.
+func (m *KillRunRequest) MarshalToWriter(writer jspb.Writer) {
 	if m == nil {
 		return
 	}
 
-	if len(m.Output) > 0 {
-		writer.WriteString(1, m.Output)
+	if len(m.Id) > 0 {
+		writer.WriteString(1, m.Id)
+	}
+
+	if m.Force {
+		writer.WriteBool(2, m.Force)
 	}
 
 	return
 }
 
-// Marshal marshals ActionResponse to a slice of bytes.
-func (m *ActionResponse) Marshal() []byte {
+// Marshal marshals KillRunRequest to a slice of bytes.
+func (m *KillRunRequest) Marshal() []byte {
 	writer := jspb.NewWriter()
 	m.MarshalToWriter(writer)
 	return writer.GetResult()
 }
 
-// UnmarshalFromReader unmarshals a ActionResponse from the provided reader.
-func (m *ActionResponse) UnmarshalFromReader(reader jspb.Reader) *ActionResponse {
+// UnmarshalFromReader unmarshals a KillRunRequest from the provided reader.
+func (m *KillRunRequest) UnmarshalFromReader(reader jspb.Reader) *KillRunRequest {
 	for reader.Next() {
 		if m == nil {
-			m = &ActionResponse{}
+			m = &KillRunRequest{}
 		}
 
 		switch reader.GetFieldNumber() {
 		case 1:
-			m.Output = reader.ReadString()
+			m.Id = reader.ReadString()
+		case 2:
+			m.Force = reader.ReadBool()
 		default:
 			reader.SkipField()
 		}
@@ -598,8 +2123,8 @@ func (m *ActionResponse) UnmarshalFromReader(reader jspb.Reader) *ActionResponse
 	return m
 }
 
-// Unmarshal unmarshals a ActionResponse from a slice of bytes.
-func (m *ActionResponse) Unmarshal(rawBytes []byte) (*ActionResponse, error) {
+// Unmarshal unmarshals a KillRunRequest from a slice of bytes.
+func (m *KillRunRequest) Unmarshal(rawBytes []byte) (*KillRunRequest, error) {
 	reader := jspb.NewReader(rawBytes)
 
 	m = m.UnmarshalFromReader(reader)
@@ -611,29 +2136,38 @@ func (m *ActionResponse) Unmarshal(rawBytes []byte) (*ActionResponse, error) {
 	return m, nil
 }
 
-type Input struct {
-	Graph string
-	In    string
+type TapRequest struct {
+	Graph   string
+	Channel string
+	RunId   string
 }
 
-// GetGraph gets the Graph of the Input.
-func (m *Input) GetGraph() (x string) {
+// GetGraph gets the Graph of the TapRequest.
+func (m *TapRequest) GetGraph() (x string) {
 	if m == nil {
 		return x
 	}
 	return m.Graph
 }
 
-// GetIn gets the In of the Input.
-func (m *Input) GetIn() (x string) {
+// GetChannel gets the Channel of the TapRequest.
+func (m *TapRequest) GetChannel() (x string) {
 	if m == nil {
 		return x
 	}
-	return m.In
+	return m.Channel
 }
 
-// MarshalToWriter marshals Input to the provided writer.
-func (m *Input) MarshalToWriter(writer jspb.Writer) {
+// GetRunId gets the RunId of the TapRequest.
+func (m *TapRequest) GetRunId() (x string) {
+	if m == nil {
+		return x
+	}
+	return m.RunId
+}
+
+// MarshalToWriter marshals TapRequest to the provided writer.
+func (m *TapRequest) MarshalToWriter(writer jspb.Writer) {
 	if m == nil {
 		return
 	}
@@ -642,32 +2176,38 @@ func (m *Input) MarshalToWriter(writer jspb.Writer) {
 		writer.WriteString(1, m.Graph)
 	}
 
-	if len(m.In) > 0 {
-		writer.WriteString(2, m.In)
+	if len(m.Channel) > 0 {
+		writer.WriteString(2, m.Channel)
+	}
+
+	if len(m.RunId) > 0 {
+		writer.WriteString(3, m.RunId)
 	}
 
 	return
 }
 
-// Marshal marshals Input to a slice of bytes.
-func (m *Input) Marshal() []byte {
+// Marshal marshals TapRequest to a slice of bytes.
+func (m *TapRequest) Marshal() []byte {
 	writer := jspb.NewWriter()
 	m.MarshalToWriter(writer)
 	return writer.GetResult()
 }
 
-// UnmarshalFromReader unmarshals a Input from the provided reader.
-func (m *Input) UnmarshalFromReader(reader jspb.Reader) *Input {
+// UnmarshalFromReader unmarshals a TapRequest from the provided reader.
+func (m *TapRequest) UnmarshalFromReader(reader jspb.Reader) *TapRequest {
 	for reader.Next() {
 		if m == nil {
-			m = &Input{}
+			m = &TapRequest{}
 		}
 
 		switch reader.GetFieldNumber() {
 		case 1:
 			m.Graph = reader.ReadString()
 		case 2:
-			m.In = reader.ReadString()
+			m.Channel = reader.ReadString()
+		case 3:
+			m.RunId = reader.ReadString()
 		default:
 			reader.SkipField()
 		}
@@ -676,8 +2216,8 @@ func (m *Input) UnmarshalFromReader(reader jspb.Reader) *Input {
 	return m
 }
 
-// Unmarshal unmarshals a Input from a slice of bytes.
-func (m *Input) Unmarshal(rawBytes []byte) (*Input, error) {
+// Unmarshal unmarshals a TapRequest from a slice of bytes.
+func (m *TapRequest) Unmarshal(rawBytes []byte) (*TapRequest, error) {
 	reader := jspb.NewReader(rawBytes)
 
 	m = m.UnmarshalFromReader(reader)
@@ -689,63 +2229,63 @@ func (m *Input) Unmarshal(rawBytes []byte) (*Input, error) {
 	return m, nil
 }
 
-type Output struct {
-	Out string
-	Err string
+type TapSample struct {
+	Value string
+	Time  int64
 }
 
-// GetOut gets the Out of the Output.
-func (m *Output) GetOut() (x string) {
+// GetValue gets the Value of the TapSample.
+func (m *TapSample) GetValue() (x string) {
 	if m == nil {
 		return x
 	}
-	return m.Out
+	return m.Value
 }
 
-// GetErr gets the Err of the Output.
-func (m *Output) GetErr() (x string) {
+// GetTime gets the Time of the TapSample.
+func (m *TapSample) GetTime() (x int64) {
 	if m == nil {
 		return x
 	}
-	return m.Err
+	return m.Time
 }
 
-// MarshalToWriter marshals Output to the provided writer.
-func (m *Output) MarshalToWriter(writer jspb.Writer) {
+// MarshalToWriter marshals TapSample to the provided writer.
+func (m *TapSample) MarshalToWriter(writer jspb.Writer) {
 	if m == nil {
 		return
 	}
 
-	if len(m.Out) > 0 {
-		writer.WriteString(1, m.Out)
+	if len(m.Value) > 0 {
+		writer.WriteString(1, m.Value)
 	}
 
-	if len(m.Err) > 0 {
-		writer.WriteString(2, m.Err)
+	if m.Time != 0 {
+		writer.WriteInt64(2, m.Time)
 	}
 
 	return
 }
 
-// Marshal marshals Output to a slice of bytes.
-func (m *Output) Marshal() []byte {
+// Marshal marshals TapSample to a slice of bytes.
+func (m *TapSample) Marshal() []byte {
 	writer := jspb.NewWriter()
 	m.MarshalToWriter(writer)
 	return writer.GetResult()
 }
 
-// UnmarshalFromReader unmarshals a Output from the provided reader.
-func (m *Output) UnmarshalFromReader(reader jspb.Reader) *Output {
+// UnmarshalFromReader unmarshals a TapSample from the provided reader.
+func (m *TapSample) UnmarshalFromReader(reader jspb.Reader) *TapSample {
 	for reader.Next() {
 		if m == nil {
-			m = &Output{}
+			m = &TapSample{}
 		}
 
 		switch reader.GetFieldNumber() {
 		case 1:
-			m.Out = reader.ReadString()
+			m.Value = reader.ReadString()
 		case 2:
-			m.Err = reader.ReadString()
+			m.Time = reader.ReadInt64()
 		default:
 			reader.SkipField()
 		}
@@ -754,8 +2294,8 @@ func (m *Output) UnmarshalFromReader(reader jspb.Reader) *Output {
 	return m
 }
 
-// Unmarshal unmarshals a Output from a slice of bytes.
-func (m *Output) Unmarshal(rawBytes []byte) (*Output, error) {
+// Unmarshal unmarshals a TapSample from a slice of bytes.
+func (m *TapSample) Unmarshal(rawBytes []byte) (*TapSample, error) {
 	reader := jspb.NewReader(rawBytes)
 
 	m = m.UnmarshalFromReader(reader)
@@ -865,10 +2405,12 @@ func (m *SetChannelRequest) Unmarshal(rawBytes []byte) (*SetChannelRequest, erro
 }
 
 type SetGraphPropertiesRequest struct {
-	Graph       string
-	Name        string
-	PackagePath string
-	IsCommand   bool
+	Graph           string
+	Name            string
+	PackagePath     string
+	IsCommand       bool
+	HandleSignals   bool
+	ShutdownTimeout string
 }
 
 // GetGraph gets the Graph of the SetGraphPropertiesRequest.
@@ -903,6 +2445,22 @@ func (m *SetGraphPropertiesRequest) GetIsCommand() (x bool) {
 	return m.IsCommand
 }
 
+// GetHandleSignals gets the HandleSignals of the SetGraphPropertiesRequest.
+func (m *SetGraphPropertiesRequest) GetHandleSignals() (x bool) {
+	if m == nil {
+		return x
+	}
+	return m.HandleSignals
+}
+
+// GetShutdownTimeout gets the ShutdownTimeout of the SetGraphPropertiesRequest.
+func (m *SetGraphPropertiesRequest) GetShutdownTimeout() (x string) {
+	if m == nil {
+		return x
+	}
+	return m.ShutdownTimeout
+}
+
 // MarshalToWriter marshals SetGraphPropertiesRequest to the provided writer.
 func (m *SetGraphPropertiesRequest) MarshalToWriter(writer jspb.Writer) {
 	if m == nil {
@@ -925,6 +2483,14 @@ func (m *SetGraphPropertiesRequest) MarshalToWriter(writer jspb.Writer) {
 		writer.WriteBool(4, m.IsCommand)
 	}
 
+	if m.HandleSignals {
+		writer.WriteBool(5, m.HandleSignals)
+	}
+
+	if len(m.ShutdownTimeout) > 0 {
+		writer.WriteString(6, m.ShutdownTimeout)
+	}
+
 	return
 }
 
@@ -951,6 +2517,10 @@ func (m *SetGraphPropertiesRequest) UnmarshalFromReader(reader jspb.Reader) *Set
 			m.PackagePath = reader.ReadString()
 		case 4:
 			m.IsCommand = reader.ReadBool()
+		case 5:
+			m.HandleSignals = reader.ReadBool()
+		case 6:
+			m.ShutdownTimeout = reader.ReadString()
 		default:
 			reader.SkipField()
 		}
@@ -972,6 +2542,103 @@ func (m *SetGraphPropertiesRequest) Unmarshal(rawBytes []byte) (*SetGraphPropert
 	return m, nil
 }
 
+type SetRunConfigRequest struct {
+	Graph  string
+	Name   string
+	Config *RunConfig
+}
+
+// GetGraph gets the Graph of the SetRunConfigRequest.
+func (m *SetRunConfigRequest) GetGraph() (x string) {
+	if m == nil {
+		return x
+	}
+	return m.Graph
+}
+
+// GetName gets the Name of the SetRunConfigRequest.
+func (m *SetRunConfigRequest) GetName() (x string) {
+	if m == nil {
+		return x
+	}
+	return m.Name
+}
+
+// GetConfig gets the Config of the SetRunConfigRequest.
+func (m *SetRunConfigRequest) GetConfig() (x *RunConfig) {
+	if m == nil {
+		return x
+	}
+	return m.Config
+}
+
+// MarshalToWriter marshals SetRunConfigRequest to the provided writer.
+func (m *SetRunConfigRequest) MarshalToWriter(writer jspb.Writer) {
+	if m == nil {
+		return
+	}
+
+	if len(m.Graph) > 0 {
+		writer.WriteString(1, m.Graph)
+	}
+
+	if len(m.Name) > 0 {
+		writer.WriteString(2, m.Name)
+	}
+
+	if m.Config != nil {
+		writer.WriteMessage(3, func() {
+			m.Config.MarshalToWriter(writer)
+		})
+	}
+
+	return
+}
+
+// Marshal marshals SetRunConfigRequest to a slice of bytes.
+func (m *SetRunConfigRequest) Marshal() []byte {
+	writer := jspb.NewWriter()
+	m.MarshalToWriter(writer)
+	return writer.GetResult()
+}
+
+// UnmarshalFromReader unmarshals a SetRunConfigRequest from the provided reader.
+func (m *SetRunConfigRequest) UnmarshalFromReader(reader jspb.Reader) *SetRunConfigRequest {
+	for reader.Next() {
+		if m == nil {
+			m = &SetRunConfigRequest{}
+		}
+
+		switch reader.GetFieldNumber() {
+		case 1:
+			m.Graph = reader.ReadString()
+		case 2:
+			m.Name = reader.ReadString()
+		case 3:
+			reader.ReadMessage(func() {
+				m.Config = m.Config.UnmarshalFromReader(reader)
+			})
+		default:
+			reader.SkipField()
+		}
+	}
+
+	return m
+}
+
+// Unmarshal unmarshals a SetRunConfigRequest from a slice of bytes.
+func (m *SetRunConfigRequest) Unmarshal(rawBytes []byte) (*SetRunConfigRequest, error) {
+	reader := jspb.NewReader(rawBytes)
+
+	m = m.UnmarshalFromReader(reader)
+
+	if err := reader.Err(); err != nil {
+		return nil, err
+	}
+
+	return m, nil
+}
+
 type SetNodeRequest struct {
 	Graph  string
 	Node   string
@@ -1190,14 +2857,30 @@ const _ = grpcweb.GrpcWebPackageIsVersion3
 type ShenzhenGoClient interface {
 	// Action performs an action (save, generate, install/build, etc).
 	Action(ctx context.Context, in *ActionRequest, opts ...grpcweb.CallOption) (ShenzhenGo_ActionClient, error)
-	// Run runs the program.
+	// Run runs the program in a new run session. The session outlives the
+	// stream; the first Output message carries its ID.
 	Run(ctx context.Context, opts ...grpcweb.CallOption) (ShenzhenGo_RunClient, error)
+	// ListRuns lists current and recently finished run sessions.
+	ListRuns(ctx context.Context, in *ListRunsRequest, opts ...grpcweb.CallOption) (*ListRunsResponse, error)
+	// AttachRun attaches to an existing run session. Output buffered since
+	// the session started is replayed first.
+	AttachRun(ctx context.Context, opts ...grpcweb.CallOption) (ShenzhenGo_AttachRunClient, error)
+	// KillRun interrupts or kills the process of a run session. If the
+	// process has already exited, the session is forgotten.
+	KillRun(ctx context.Context, in *KillRunRequest, opts ...grpcweb.CallOption) (*Empty, error)
+	// TapChannel streams a rate-limited sample of the values sent on a
+	// channel, while the run session is running. The session must be
+	// instrumented.
+	TapChannel(ctx context.Context, in *TapRequest, opts ...grpcweb.CallOption) (ShenzhenGo_TapChannelClient, error)
 	// SetNode either creates a new channel (name == "", config != nil)
 	// changes existing channel data such as name and attached pins (name is found, config != nil),
 	// or deletes a channel (name is found, config == nil).
 	SetChannel(ctx context.Context, in *SetChannelRequest, opts ...grpcweb.CallOption) (*Empty, error)
 	// SetGraphProperties changes metdata such as name and package path.
 	SetGraphProperties(ctx context.Context, in *SetGraphPropertiesRequest, opts ...grpcweb.CallOption) (*Empty, error)
+	// SetRunConfig either creates or changes a saved run configuration
+	// (config != nil), or deletes one (config == nil).
+	SetRunConfig(ctx context.Context, in *SetRunConfigRequest, opts ...grpcweb.CallOption) (*Empty, error)
 	// SetNode either creates a new node (name == "", config != nil)
 	// changes existing node such as name and multiplicity (name is found, config != nil),
 	// or deletes a node (name is found, config == nil).
@@ -1281,6 +2964,88 @@ func (x *shenzhenGoRunClient) Recv() (*Output, error) {
 	return new(Output).Unmarshal(resp)
 }
 
+func (c *shenzhenGoClient) ListRuns(ctx context.Context, in *ListRunsRequest, opts ...grpcweb.CallOption) (*ListRunsResponse, error) {
+	resp, err := c.client.RPCCall(ctx, "ListRuns", in.Marshal(), opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	return new(ListRunsResponse).Unmarshal(resp)
+}
+
+func (c *shenzhenGoClient) AttachRun(ctx context.Context, opts ...grpcweb.CallOption) (ShenzhenGo_AttachRunClient, error) {
+	srv, err := c.client.NewClientStream(ctx, true, true, "AttachRun", opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	return &shenzhenGoAttachRunClient{srv}, nil
+}
+
+type ShenzhenGo_AttachRunClient interface {
+	Send(*Input) error
+	Recv() (*Output, error)
+	grpcweb.ClientStream
+}
+
+type shenzhenGoAttachRunClient struct {
+	grpcweb.ClientStream
+}
+
+func (x *shenzhenGoAttachRunClient) Send(req *Input) error {
+	return x.SendMsg(req.Marshal())
+}
+
+func (x *shenzhenGoAttachRunClient) Recv() (*Output, error) {
+	resp, err := x.RecvMsg()
+	if err != nil {
+		return nil, err
+	}
+
+	return new(Output).Unmarshal(resp)
+}
+
+func (c *shenzhenGoClient) KillRun(ctx context.Context, in *KillRunRequest, opts ...grpcweb.CallOption) (*Empty, error) {
+	resp, err := c.client.RPCCall(ctx, "KillRun", in.Marshal(), opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	return new(Empty).Unmarshal(resp)
+}
+
+func (c *shenzhenGoClient) TapChannel(ctx context.Context, in *TapRequest, opts ...grpcweb.CallOption) (ShenzhenGo_TapChannelClient, error) {
+	srv, err := c.client.NewClientStream(ctx, false, true, "TapChannel", opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	err = srv.SendMsg(in.Marshal())
+	if err != nil {
+		return nil, err
+	}
+
+	return &shenzhenGoTapChannelClient{srv}, nil
+}
+
+type ShenzhenGo_TapChannelClient interface {
+	Recv() (*TapSample, error)
+	grpcweb.ClientStream
+}
+
+type shenzhenGoTapChannelClient struct {
+	grpcweb.ClientStream
+}
+
+func (x *shenzhenGoTapChannelClient) Recv() (*TapSample, error) {
+	resp, err := x.RecvMsg()
+	if err != nil {
+		return nil, err
+	}
+
+	return new(TapSample).Unmarshal(resp)
+}
+
 func (c *shenzhenGoClient) SetChannel(ctx context.Context, in *SetChannelRequest, opts ...grpcweb.CallOption) (*Empty, error) {
 	resp, err := c.client.RPCCall(ctx, "SetChannel", in.Marshal(), opts...)
 	if err != nil {
@@ -1299,6 +3064,15 @@ func (c *shenzhenGoClient) SetGraphProperties(ctx context.Context, in *SetGraphP
 	return new(Empty).Unmarshal(resp)
 }
 
+func (c *shenzhenGoClient) SetRunConfig(ctx context.Context, in *SetRunConfigRequest, opts ...grpcweb.CallOption) (*Empty, error) {
+	resp, err := c.client.RPCCall(ctx, "SetRunConfig", in.Marshal(), opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	return new(Empty).Unmarshal(resp)
+}
+
 func (c *shenzhenGoClient) SetNode(ctx context.Context, in *SetNodeRequest, opts ...grpcweb.CallOption) (*Empty, error) {
 	resp, err := c.client.RPCCall(ctx, "SetNode", in.Marshal(), opts...)
 	if err != nil {
@@ -18,7 +18,8 @@ import (
 	"context"
 	"fmt"
 	"log"
-	"os/exec"
+	"path/filepath"
+	"strings"
 
 	"github.com/golang/protobuf/proto"
 	"google.golang.org/grpc/codes"
@@ -50,14 +51,37 @@ func (c *server) Action(req *pb.ActionRequest, stream pb.ShenzhenGo_ActionServer
 
 	switch req.Action {
 	case pb.ActionRequest_SAVE:
+		// Saving would remove a stale journal before it was looked at.
+		if err := g.checkMutable(); err != nil {
+			return err
+		}
 		return SaveJSONFile(g.Graph)
 	case pb.ActionRequest_REVERT:
 		return g.reload()
+	case pb.ActionRequest_RESTORE:
+		skipped, err := g.restore()
+		if err != nil {
+			return err
+		}
+		if skipped > 0 {
+			fmt.Fprintf(actionStreamWriter{stream}, "(%d journal entries could not be restored)\n", skipped)
+		}
+		return nil
+	case pb.ActionRequest_DISCARD:
+		return g.discard()
 	case pb.ActionRequest_GENERATE:
 		_, err := GeneratePackage(actionStreamWriter{stream}, g.Graph)
 		return err
 	case pb.ActionRequest_BUILD:
-		return Build(actionStreamWriter{stream}, g.Graph)
+		return Build(actionStreamWriter{stream}, g.Graph, req.Race)
+	case pb.ActionRequest_TEST:
+		return Test(actionStreamWriter{stream}, g.Graph, req.Race, func(ds []*pb.Diagnostic) {
+			stream.Send(&pb.ActionResponse{Diagnostics: ds})
+		})
+	case pb.ActionRequest_BENCH:
+		return Bench(actionStreamWriter{stream}, g.Graph)
+	case pb.ActionRequest_TEST_STUBS:
+		return GenerateTestStubs(actionStreamWriter{stream}, g.Graph)
 	case pb.ActionRequest_INSTALL:
 		return Install(actionStreamWriter{stream}, g.Graph)
 	default:
@@ -65,77 +89,151 @@ func (c *server) Action(req *pb.ActionRequest, stream pb.ShenzhenGo_ActionServer
 	}
 }
 
-type runSvrWriter struct {
-	svr pb.ShenzhenGo_RunServer
-	fn  func([]byte) *pb.Output
-}
+func (c *server) Run(svr pb.ShenzhenGo_RunServer) error {
+	log.Print("api: Run()")
 
-func (w *runSvrWriter) Write(b []byte) (int, error) {
-	if err := w.svr.Send(w.fn(b)); err != nil {
-		return 0, err
+	first, err := svr.Recv()
+	if err != nil {
+		return err
 	}
-	return len(b), nil
+	g, err := c.lookupGraph(first.Graph)
+	if err != nil {
+		return err
+	}
+	g.Lock()
+	rc, err := g.runConfig(first)
+	g.Unlock()
+	if err != nil {
+		return err
+	}
+
+	rs := c.runs.new(first.Graph, rc.Args)
+	go rs.exec(g, rc, first.Breakpoints)
+	return rs.attach(svr)
 }
 
-func (c *server) Run(svr pb.ShenzhenGo_RunServer) error {
-	log.Print("api: Run()")
+func (c *server) ListRuns(ctx context.Context, req *pb.ListRunsRequest) (*pb.ListRunsResponse, error) {
+	log.Printf("api: ListRuns(%s)", proto.MarshalTextString(req))
+	return &pb.ListRunsResponse{Runs: c.runs.list(req.Graph)}, nil
+}
+
+func (c *server) AttachRun(svr pb.ShenzhenGo_AttachRunServer) error {
+	log.Print("api: AttachRun()")
 
 	first, err := svr.Recv()
 	if err != nil {
 		return err
 	}
-	g, err := c.lookupGraph(first.Graph)
+	rs, err := c.runs.lookup(first.RunId)
 	if err != nil {
 		return err
 	}
+	return rs.attach(svr)
+}
 
-	stdout := &runSvrWriter{svr, func(b []byte) *pb.Output { return &pb.Output{Out: string(b)} }}
-	stderr := &runSvrWriter{svr, func(b []byte) *pb.Output { return &pb.Output{Err: string(b)} }}
+func (c *server) KillRun(ctx context.Context, req *pb.KillRunRequest) (*pb.Empty, error) {
+	log.Printf("api: KillRun(%s)", proto.MarshalTextString(req))
 
+	rs, err := c.runs.lookup(req.Id)
+	if err != nil {
+		return &pb.Empty{}, err
+	}
+	if !rs.info().Running {
+		c.runs.remove(req.Id)
+		return &pb.Empty{}, nil
+	}
+	if err := rs.signal(req.Force); err != nil {
+		return &pb.Empty{}, status.Errorf(codes.Internal, "signalling process: %v", err)
+	}
+	return &pb.Empty{}, nil
+}
+
+func (c *server) TapChannel(req *pb.TapRequest, svr pb.ShenzhenGo_TapChannelServer) error {
+	log.Printf("api: TapChannel(%s)", proto.MarshalTextString(req))
+
+	g, err := c.lookupGraph(req.Graph)
+	if err != nil {
+		return err
+	}
 	g.Lock()
-	gp, err := GenerateRunner(stderr, g.Graph)
+	_, found := g.Channels[req.Channel]
 	g.Unlock()
+	if !found {
+		return status.Errorf(codes.NotFound, "no such channel %q", req.Channel)
+	}
+
+	var rs *runSession
+	if req.RunId != "" {
+		rs, err = c.runs.lookup(req.RunId)
+	} else {
+		rs, err = c.runs.latest(req.Graph)
+	}
 	if err != nil {
 		return err
 	}
-
-	cmd := exec.CommandContext(svr.Context(), "go", "run", gp)
-	fmt.Fprintf(stderr, "%v\n", cmd.Args)
-
-	// A pipe is better for input; managing a buffer is fiddly, and cmd.Wait
-	// will wait until read returns, which doesn't mesh well with the
-	// behaviour of svr.Recv.
-	stdin, err := cmd.StdinPipe()
+	samples, untap, err := rs.tap(req.Channel)
 	if err != nil {
-		return status.Errorf(codes.Internal, "attaching stdin pipe: %v", err)
-	}
-	cmd.Stdout, cmd.Stderr = stdout, stderr
-	// go run compiles and forks a temporary binary. Need to control it as a process group.
-	setpgid(cmd)
-	go func() {
-		for {
-			in, err := svr.Recv()
-			if err != nil {
-				stdin.Close()
-				return
-			}
-			if _, err := stdin.Write([]byte(in.In)); err != nil {
-				return
+		return err
+	}
+	defer untap()
+	for {
+		select {
+		case s, ok := <-samples:
+			if !ok {
+				return nil
 			}
-			if in.In == "\x03" { // ^C
-				//log.Printf("svr.Recv got ^C, sending os.Interrupt to %d\n", cmd.Process.Pid)
-				if err := interrupt(cmd.Process); err != nil {
-					fmt.Fprintf(stderr, "(interrupt(%d) = %v\n", cmd.Process.Pid, err)
-				}
+			if err := svr.Send(s); err != nil {
+				return err
 			}
+		case <-svr.Context().Done():
+			return svr.Context().Err()
 		}
-	}()
-	if err := cmd.Run(); err != nil {
-		fmt.Fprintf(stderr, "(process %v)", err)
-		return status.Errorf(codes.Aborted, "cmd.Run() = %v", err)
 	}
-	fmt.Fprintln(stderr, "(process succeeded)")
-	return nil
+}
+
+// runConfig works out how to run the program, given the first Input message.
+// The working directory of the result is absolute (or empty, meaning the
+// server's working directory).
+func (sg *serveGraph) runConfig(first *pb.Input) (*model.RunConfig, error) {
+	rc := new(model.RunConfig)
+	switch {
+	case first.Config != nil:
+		if err := validateRunConfig(first.Config); err != nil {
+			return nil, err
+		}
+		rc = runConfigFromProto(first.Config)
+	case first.RunConfig != "":
+		src := sg.RunConfigs[first.RunConfig]
+		if src == nil {
+			return nil, status.Errorf(codes.NotFound, "no such run config %q", first.RunConfig)
+		}
+		*rc = *src
+	}
+	if len(rc.Args) > 0 && strings.HasSuffix(rc.Args[0], ".go") {
+		// go run would treat it as another source file.
+		return nil, status.Errorf(codes.InvalidArgument, "first program argument %q must not end in .go", rc.Args[0])
+	}
+	// rc may share slices with a stored run config, so don't append to them.
+	chans := make([]string, 0, len(rc.Record)+len(rc.Replay))
+	chans = append(chans, rc.Record...)
+	chans = append(chans, rc.Replay...)
+	for _, c := range chans {
+		if _, found := sg.Channels[c]; !found {
+			return nil, status.Errorf(codes.NotFound, "no such channel %q", c)
+		}
+	}
+	rc.Dir = sg.resolvePath(rc.Dir)
+	rc.RecordFile = sg.resolvePath(rc.RecordFile)
+	rc.ReplayFile = sg.resolvePath(rc.ReplayFile)
+	return rc, nil
+}
+
+// resolvePath makes a path relative to the graph file absolute.
+func (sg *serveGraph) resolvePath(p string) string {
+	if p == "" || filepath.IsAbs(p) || sg.FilePath == "" {
+		return p
+	}
+	return filepath.Join(filepath.Dir(sg.FilePath), p)
 }
 
 func (c *server) SetChannel(ctx context.Context, req *pb.SetChannelRequest) (*pb.Empty, error) {
@@ -151,19 +249,29 @@ func (c *server) SetChannel(ctx context.Context, req *pb.SetChannelRequest) (*pb
 	}
 	g.Lock()
 	defer g.Unlock()
+	if err := g.checkMutable(); err != nil {
+		return &pb.Empty{}, err
+	}
+	if err := g.setChannel(req); err != nil {
+		return &pb.Empty{}, err
+	}
+	g.record(journalSetChannel, req)
+	return &pb.Empty{}, nil
+}
 
+func (sg *serveGraph) setChannel(req *pb.SetChannelRequest) error {
 	var nps map[model.NodePin]struct{}
 
 	if req.Config != nil {
 		// TODO: More validation (name, type, etc)
 		if req.Config.Name == "nil" {
-			return &pb.Empty{}, status.Errorf(codes.InvalidArgument, "channels may not be named %q", req.Config.Name)
+			return status.Errorf(codes.InvalidArgument, "channels may not be named %q", req.Config.Name)
 		}
 
 		if req.Channel != req.Config.Name {
 			// Check that the new name is available...
-			if _, found := g.Channels[req.Config.Name]; found {
-				return &pb.Empty{}, status.Errorf(codes.AlreadyExists, "target name %q already exists", req.Config.Name)
+			if _, found := sg.Channels[req.Config.Name]; found {
+				return status.Errorf(codes.AlreadyExists, "target name %q already exists", req.Config.Name)
 			}
 		}
 
@@ -171,43 +279,43 @@ func (c *server) SetChannel(ctx context.Context, req *pb.SetChannelRequest) (*pb
 		// that the pins exist at the same time.
 		nps = make(map[model.NodePin]struct{}, len(req.Config.Pins))
 		for _, np := range req.Config.Pins {
-			n, err := g.lookupNode(np.Node)
+			n, err := sg.lookupNode(np.Node)
 			if err != nil {
-				return &pb.Empty{}, err
+				return err
 			}
 			if _, found := n.Connections[np.Pin]; !found {
-				return &pb.Empty{}, status.Errorf(codes.NotFound, "node %q pin %q does not exist", np.Node, np.Pin)
+				return status.Errorf(codes.NotFound, "node %q pin %q does not exist", np.Node, np.Pin)
 			}
 			nps[model.NodePin{Node: np.Node, Pin: np.Pin}] = struct{}{}
 		}
 	}
 
 	if req.Channel != "" {
-		old, err := g.lookupChannel(req.Channel)
+		old, err := sg.lookupChannel(req.Channel)
 		if err != nil {
-			return &pb.Empty{}, err
+			return err
 		}
 
 		// Update existing channel data by deleting the old one from the map
 		// and any connections, then setting the new one below.
-		g.DeleteChannel(old)
+		sg.DeleteChannel(old)
 
 		if req.Config == nil {
 			// Deletion was intended, job complete.
-			return &pb.Empty{}, nil
+			return nil
 		}
 	}
 
 	// Set entry in map, update connections on node side.
-	g.Channels[req.Config.Name] = &model.Channel{
+	sg.Channels[req.Config.Name] = &model.Channel{
 		Name:     req.Config.Name,
 		Capacity: int(req.Config.Cap),
 		Pins:     nps,
 	}
 	for np := range nps {
-		g.Nodes[np.Node].Connections[np.Pin] = req.Config.Name
+		sg.Nodes[np.Node].Connections[np.Pin] = req.Config.Name
 	}
-	return &pb.Empty{}, nil
+	return nil
 }
 
 func (c *server) SetGraphProperties(ctx context.Context, req *pb.SetGraphPropertiesRequest) (*pb.Empty, error) {
@@ -216,12 +324,102 @@ func (c *server) SetGraphProperties(ctx context.Context, req *pb.SetGraphPropert
 	if err != nil {
 		return &pb.Empty{}, err
 	}
-	g.Name = req.Name
-	g.PackagePath = req.PackagePath
-	g.IsCommand = req.IsCommand
+	g.Lock()
+	defer g.Unlock()
+	if err := g.checkMutable(); err != nil {
+		return &pb.Empty{}, err
+	}
+	if err := g.setGraphProperties(req); err != nil {
+		return &pb.Empty{}, err
+	}
+	g.record(journalSetGraphProperties, req)
 	return &pb.Empty{}, nil
 }
 
+func (sg *serveGraph) setGraphProperties(req *pb.SetGraphPropertiesRequest) error {
+	if _, err := model.ParseShutdownTimeout(req.ShutdownTimeout); err != nil {
+		return status.Errorf(codes.InvalidArgument, "shutdown timeout: %v", err)
+	}
+	sg.Name = req.Name
+	sg.PackagePath = req.PackagePath
+	sg.IsCommand = req.IsCommand
+	sg.HandleSignals = req.HandleSignals
+	sg.ShutdownTimeout = req.ShutdownTimeout
+	return nil
+}
+
+func (c *server) SetRunConfig(ctx context.Context, req *pb.SetRunConfigRequest) (*pb.Empty, error) {
+	log.Printf("api: SetRunConfig(%s)", proto.MarshalTextString(req))
+
+	if req.Name == "" {
+		return &pb.Empty{}, status.Error(codes.InvalidArgument, "run config name must not be empty")
+	}
+
+	g, err := c.lookupGraph(req.Graph)
+	if err != nil {
+		return &pb.Empty{}, err
+	}
+	g.Lock()
+	defer g.Unlock()
+	if err := g.checkMutable(); err != nil {
+		return &pb.Empty{}, err
+	}
+	if err := g.setRunConfig(req); err != nil {
+		return &pb.Empty{}, err
+	}
+	g.record(journalSetRunConfig, req)
+	return &pb.Empty{}, nil
+}
+
+func (sg *serveGraph) setRunConfig(req *pb.SetRunConfigRequest) error {
+	if req.Config == nil {
+		if _, found := sg.RunConfigs[req.Name]; !found {
+			return status.Errorf(codes.NotFound, "no such run config %q", req.Name)
+		}
+		delete(sg.RunConfigs, req.Name)
+		return nil
+	}
+	if err := validateRunConfig(req.Config); err != nil {
+		return err
+	}
+	if sg.RunConfigs == nil {
+		sg.RunConfigs = make(map[string]*model.RunConfig)
+	}
+	sg.RunConfigs[req.Name] = runConfigFromProto(req.Config)
+	return nil
+}
+
+func runConfigFromProto(rc *pb.RunConfig) *model.RunConfig {
+	return &model.RunConfig{
+		Args:       rc.Args,
+		Env:        rc.Env,
+		Dir:        rc.Dir,
+		Race:       rc.Race,
+		Profile:    rc.Profile,
+		Instrument: rc.Instrument,
+		Debug:      rc.Debug,
+		Record:     rc.Record,
+		RecordFile: rc.RecordFile,
+		Replay:     rc.Replay,
+		ReplayFile: rc.ReplayFile,
+	}
+}
+
+func validateRunConfig(rc *pb.RunConfig) error {
+	for _, e := range rc.Env {
+		if strings.Index(e, "=") <= 0 {
+			return status.Errorf(codes.InvalidArgument, "environment variable %q is not of the form KEY=value", e)
+		}
+	}
+	if len(rc.Record) > 0 && rc.RecordFile == "" {
+		return status.Error(codes.InvalidArgument, "recording channels needs a file to record into")
+	}
+	if len(rc.Replay) > 0 && rc.ReplayFile == "" {
+		return status.Error(codes.InvalidArgument, "replaying channels needs a file to replay from")
+	}
+	return nil
+}
+
 func (c *server) SetNode(ctx context.Context, req *pb.SetNodeRequest) (*pb.Empty, error) {
 	log.Printf("api: SetNode(%s)", proto.MarshalTextString(req))
 
@@ -235,13 +433,23 @@ func (c *server) SetNode(ctx context.Context, req *pb.SetNodeRequest) (*pb.Empty
 	}
 	g.Lock()
 	defer g.Unlock()
+	if err := g.checkMutable(); err != nil {
+		return &pb.Empty{}, err
+	}
+	if err := g.setNode(req); err != nil {
+		return &pb.Empty{}, err
+	}
+	g.record(journalSetNode, req)
+	return &pb.Empty{}, nil
+}
 
+func (sg *serveGraph) setNode(req *pb.SetNodeRequest) error {
 	var part model.Part
 	if req.Config != nil {
 		if req.Node != req.Config.Name {
 			// Check the new name is available...
-			if _, exists := g.Nodes[req.Config.Name]; exists {
-				return &pb.Empty{}, status.Errorf(codes.AlreadyExists, "node %q already exists", req.Config.Name)
+			if _, exists := sg.Nodes[req.Config.Name]; exists {
+				return status.Errorf(codes.AlreadyExists, "node %q already exists", req.Config.Name)
 			}
 		}
 
@@ -250,25 +458,41 @@ func (c *server) SetNode(ctx context.Context, req *pb.SetNodeRequest) (*pb.Empty
 			Type: req.Config.PartType,
 		}).Unmarshal()
 		if err != nil {
-			return &pb.Empty{}, status.Errorf(codes.FailedPrecondition, "part unmarshal: %v", err)
+			return status.Errorf(codes.FailedPrecondition, "part unmarshal: %v", err)
 		}
 		part = p
+
+		if !model.ValidPanicPolicy(req.Config.PanicPolicy) {
+			return status.Errorf(codes.InvalidArgument, "unknown panic policy %q", req.Config.PanicPolicy)
+		}
+		if req.Config.MaxRestarts < 0 {
+			return status.Error(codes.InvalidArgument, "max restarts must not be negative")
+		}
+		n := &model.Node{
+			Name:         req.Config.Name,
+			Multiplicity: req.Config.Multiplicity,
+			Configurable: req.Config.Configurable,
+			Part:         part,
+		}
+		if err := n.CheckConfigurable(); err != nil {
+			return status.Errorf(codes.InvalidArgument, "%v", err)
+		}
 	}
 
 	var conns map[string]string
 	if req.Node != "" {
-		old, err := g.lookupNode(req.Node)
+		old, err := sg.lookupNode(req.Node)
 		if err != nil {
-			return &pb.Empty{}, err
+			return err
 		}
 
 		// Delete old node, only clean up channels if deleting this node
 		// is the intention.
-		g.DeleteNode(old, req.Config == nil)
+		sg.DeleteNode(old, req.Config == nil)
 
 		if req.Config == nil {
 			// Deletion was intended, job complete.
-			return &pb.Empty{}, nil
+			return nil
 		}
 
 		conns = old.Connections
@@ -281,15 +505,18 @@ func (c *server) SetNode(ctx context.Context, req *pb.SetNodeRequest) (*pb.Empty
 		Multiplicity: req.Config.Multiplicity,
 		Enabled:      req.Config.Enabled,
 		Wait:         req.Config.Wait,
+		PanicPolicy:  req.Config.PanicPolicy,
+		MaxRestarts:  int(req.Config.MaxRestarts),
+		Configurable: req.Config.Configurable,
 		Part:         part,
 		X:            req.Config.X,
 		Y:            req.Config.Y,
 		Connections:  conns,
 	}
-	g.Nodes[req.Config.Name] = n
+	sg.Nodes[req.Config.Name] = n
 	n.RefreshConnections()
-	g.RefreshChannelsPins() // Changing the part might have changed available pins.
-	return &pb.Empty{}, nil
+	sg.RefreshChannelsPins() // Changing the part might have changed available pins.
+	return nil
 }
 
 func (c *server) SetPosition(ctx context.Context, req *pb.SetPositionRequest) (*pb.Empty, error) {
@@ -300,10 +527,21 @@ func (c *server) SetPosition(ctx context.Context, req *pb.SetPositionRequest) (*
 	}
 	g.Lock()
 	defer g.Unlock()
-	n, err := g.lookupNode(req.Node)
-	if err != nil {
+	if err := g.checkMutable(); err != nil {
 		return &pb.Empty{}, err
 	}
-	n.X, n.Y = req.X, req.Y
+	if err := g.setPosition(req); err != nil {
+		return &pb.Empty{}, err
+	}
+	g.record(journalSetPosition, req)
 	return &pb.Empty{}, nil
 }
+
+func (sg *serveGraph) setPosition(req *pb.SetPositionRequest) error {
+	n, err := sg.lookupNode(req.Node)
+	if err != nil {
+		return err
+	}
+	n.X, n.Y = req.X, req.Y
+	return nil
+}
@@ -32,3 +32,7 @@ func setpgid(cmd *exec.Cmd) {
 func interrupt(proc *os.Process) error {
 	return syscall.Kill(-proc.Pid, syscall.SIGINT)
 }
+
+func kill(proc *os.Process) error {
+	return syscall.Kill(-proc.Pid, syscall.SIGKILL)
+}
@@ -26,3 +26,7 @@ func setpgid(*exec.Cmd) {}
 func interrupt(proc *os.Process) error {
 	return proc.Signal(os.Interrupt)
 }
+
+func kill(proc *os.Process) error {
+	return proc.Kill()
+}
@@ -16,6 +16,8 @@ package server
 
 import (
 	"context"
+	"path/filepath"
+	"reflect"
 	"testing"
 
 	"google.golang.org/grpc/codes"
@@ -418,13 +420,23 @@ func TestSetGraphProperties(t *testing.T) {
 			},
 			code: codes.NotFound,
 		},
+		{
+			name: "Bad shutdown timeout",
+			req: &pb.SetGraphPropertiesRequest{
+				Graph:           "foo",
+				ShutdownTimeout: "soon",
+			},
+			code: codes.InvalidArgument,
+		},
 		{
 			name: "Ok",
 			req: &pb.SetGraphPropertiesRequest{
-				Graph:       "foo",
-				Name:        "name",
-				PackagePath: "package/path",
-				IsCommand:   true,
+				Graph:           "foo",
+				Name:            "name",
+				PackagePath:     "package/path",
+				IsCommand:       true,
+				HandleSignals:   true,
+				ShutdownTimeout: "5s",
 			},
 			code: codes.OK,
 		},
@@ -446,6 +458,12 @@ func TestSetGraphProperties(t *testing.T) {
 	if got, want := foo.IsCommand, true; got != want {
 		t.Errorf("foo.IsCommand = %t, want %t", got, want)
 	}
+	if got, want := foo.HandleSignals, true; got != want {
+		t.Errorf("foo.HandleSignals = %t, want %t", got, want)
+	}
+	if got, want := foo.ShutdownTimeout, "5s"; got != want {
+		t.Errorf("foo.ShutdownTimeout = %q, want %q", got, want)
+	}
 }
 
 func TestSetNode(t *testing.T) {
@@ -511,6 +529,34 @@ func TestSetNode(t *testing.T) {
 			},
 			code: codes.AlreadyExists,
 		},
+		{
+			name: "unknown panic policy",
+			req: &pb.SetNodeRequest{
+				Graph: "foo",
+				Node:  "bar",
+				Config: &pb.NodeConfig{
+					Name:        "bar",
+					PartCfg:     []byte("{}"),
+					PartType:    "Code",
+					PanicPolicy: "shrug",
+				},
+			},
+			code: codes.InvalidArgument,
+		},
+		{
+			name: "unknown setting",
+			req: &pb.SetNodeRequest{
+				Graph: "foo",
+				Node:  "bar",
+				Config: &pb.NodeConfig{
+					Name:         "bar",
+					PartCfg:      []byte("{}"),
+					PartType:     "Code",
+					Configurable: []string{"max_items"},
+				},
+			},
+			code: codes.InvalidArgument,
+		},
 		{
 			name: "Ok",
 			req: &pb.SetNodeRequest{
@@ -523,6 +569,9 @@ func TestSetNode(t *testing.T) {
 					Multiplicity: "1",
 					Enabled:      true,
 					Wait:         true,
+					PanicPolicy:  model.PanicRestart,
+					MaxRestarts:  3,
+					Configurable: []string{model.MultiplicitySetting},
 				},
 			},
 			code: codes.OK,
@@ -555,6 +604,15 @@ func TestSetNode(t *testing.T) {
 	if got, want := bax.Wait, true; got != want {
 		t.Errorf("bax.Wait = %t, want %t", got, want)
 	}
+	if got, want := bax.PanicPolicy, model.PanicRestart; got != want {
+		t.Errorf("bax.PanicPolicy = %q, want %q", got, want)
+	}
+	if got, want := bax.MaxRestarts, 3; got != want {
+		t.Errorf("bax.MaxRestarts = %d, want %d", got, want)
+	}
+	if got, want := bax.Configurable, []string{model.MultiplicitySetting}; !reflect.DeepEqual(got, want) {
+		t.Errorf("bax.Configurable = %q, want %q", got, want)
+	}
 	if got, want := bax.Part.TypeKey(), "Code"; got != want {
 		t.Errorf("bax.Part.TypeKey() = %q, want %q", got, want)
 	}
@@ -616,3 +674,193 @@ func TestSetPosition(t *testing.T) {
 		t.Errorf("bar.Y = %f, want %f", got, want)
 	}
 }
+
+func TestSetRunConfig(t *testing.T) {
+	foo := &model.Graph{Name: "foo"}
+	c := &server{
+		loadedGraphs: map[string]*serveGraph{"foo": {Graph: foo}},
+	}
+	tests := []struct {
+		name string
+		req  *pb.SetRunConfigRequest
+		code codes.Code
+	}{
+		{
+			name: "No such graph",
+			req: &pb.SetRunConfigRequest{
+				Graph:  "oof",
+				Name:   "default",
+				Config: &pb.RunConfig{},
+			},
+			code: codes.NotFound,
+		},
+		{
+			name: "No name",
+			req: &pb.SetRunConfigRequest{
+				Graph:  "foo",
+				Config: &pb.RunConfig{},
+			},
+			code: codes.InvalidArgument,
+		},
+		{
+			name: "Bad env",
+			req: &pb.SetRunConfigRequest{
+				Graph:  "foo",
+				Name:   "default",
+				Config: &pb.RunConfig{Env: []string{"=nope"}},
+			},
+			code: codes.InvalidArgument,
+		},
+		{
+			name: "Delete nonexistent",
+			req: &pb.SetRunConfigRequest{
+				Graph: "foo",
+				Name:  "default",
+			},
+			code: codes.NotFound,
+		},
+		{
+			name: "Create",
+			req: &pb.SetRunConfigRequest{
+				Graph: "foo",
+				Name:  "verbose",
+				Config: &pb.RunConfig{
+					Args: []string{"-v"},
+					Env:  []string{"DEBUG=1"},
+					Dir:  "testdata",
+				},
+			},
+			code: codes.OK,
+		},
+		{
+			name: "Create then delete",
+			req: &pb.SetRunConfigRequest{
+				Graph:  "foo",
+				Name:   "temporary",
+				Config: &pb.RunConfig{},
+			},
+			code: codes.OK,
+		},
+		{
+			name: "Delete",
+			req: &pb.SetRunConfigRequest{
+				Graph: "foo",
+				Name:  "temporary",
+			},
+			code: codes.OK,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			_, err := c.SetRunConfig(context.Background(), test.req)
+			if got, want := code(err), test.code; got != want {
+				t.Errorf("c.SetRunConfig(%v) = code %v, want %v", test.req, got, want)
+			}
+		})
+	}
+	if got, want := len(foo.RunConfigs), 1; got != want {
+		t.Fatalf("len(foo.RunConfigs) = %d, want %d", got, want)
+	}
+	rc := foo.RunConfigs["verbose"]
+	if rc == nil {
+		t.Fatal(`foo.RunConfigs["verbose"] = nil`)
+	}
+	if got, want := rc.Dir, "testdata"; got != want {
+		t.Errorf("rc.Dir = %q, want %q", got, want)
+	}
+}
+
+func TestRunConfig(t *testing.T) {
+	sg := &serveGraph{Graph: &model.Graph{
+		FilePath: "/dir/foo.szgo",
+		Channels: map[string]*model.Channel{
+			"ch": {Name: "ch"},
+		},
+		RunConfigs: map[string]*model.RunConfig{
+			"saved": {Args: []string{"a"}, Dir: "sub"},
+		},
+	}}
+	tests := []struct {
+		name           string
+		in             *pb.Input
+		wantDir        string
+		wantRecordFile string
+		code           codes.Code
+	}{
+		{
+			name: "Default",
+			in:   &pb.Input{},
+		},
+		{
+			name:    "Saved",
+			in:      &pb.Input{RunConfig: "saved"},
+			wantDir: "/dir/sub",
+		},
+		{
+			name:    "Explicit absolute dir",
+			in:      &pb.Input{RunConfig: "saved", Config: &pb.RunConfig{Dir: "/elsewhere"}},
+			wantDir: "/elsewhere",
+		},
+		{
+			name: "Missing saved",
+			in:   &pb.Input{RunConfig: "nope"},
+			code: codes.NotFound,
+		},
+		{
+			name: "Go file argument",
+			in:   &pb.Input{Config: &pb.RunConfig{Args: []string{"x.go"}}},
+			code: codes.InvalidArgument,
+		},
+		{
+			name:           "Record",
+			in:             &pb.Input{Config: &pb.RunConfig{Record: []string{"ch"}, RecordFile: "ch.rec"}},
+			wantRecordFile: "/dir/ch.rec",
+		},
+		{
+			name: "Record without file",
+			in:   &pb.Input{Config: &pb.RunConfig{Record: []string{"ch"}}},
+			code: codes.InvalidArgument,
+		},
+		{
+			name: "Replay missing channel",
+			in:   &pb.Input{Config: &pb.RunConfig{Replay: []string{"nope"}, ReplayFile: "ch.rec"}},
+			code: codes.NotFound,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			rc, err := sg.runConfig(test.in)
+			if got, want := code(err), test.code; got != want {
+				t.Fatalf("sg.runConfig(%v) = code %v, want %v", test.in, got, want)
+			}
+			if err != nil {
+				return
+			}
+			if got, want := rc.Dir, filepath.FromSlash(test.wantDir); got != want {
+				t.Errorf("sg.runConfig(%v).Dir = %q, want %q", test.in, got, want)
+			}
+			if got, want := rc.RecordFile, filepath.FromSlash(test.wantRecordFile); got != want {
+				t.Errorf("sg.runConfig(%v).RecordFile = %q, want %q", test.in, got, want)
+			}
+		})
+	}
+}
+
+func TestRunConfigKeepsSaved(t *testing.T) {
+	record := make([]string, 1, 2)
+	record[0] = "ch"
+	saved := &model.RunConfig{Record: record, Replay: []string{"ch2"}}
+	sg := &serveGraph{Graph: &model.Graph{
+		Channels: map[string]*model.Channel{
+			"ch":  {Name: "ch"},
+			"ch2": {Name: "ch2"},
+		},
+		RunConfigs: map[string]*model.RunConfig{"saved": saved},
+	}}
+	if _, err := sg.runConfig(&pb.Input{RunConfig: "saved"}); err != nil {
+		t.Fatalf("sg.runConfig() = error %v", err)
+	}
+	if got := record[:2][1]; got != "" {
+		t.Errorf("after sg.runConfig(), saved Record backing array = %q, want unmodified", record[:2])
+	}
+}
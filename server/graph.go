@@ -27,6 +27,7 @@ import (
 	"strings"
 
 	"github.com/google/shenzhen-go/model"
+	pb "github.com/google/shenzhen-go/proto/go"
 	"github.com/google/shenzhen-go/server/view"
 	"github.com/google/shenzhen-go/source"
 )
@@ -50,7 +51,8 @@ func GuessPackagePath(srcPath string) (string, error) {
 	return strings.TrimSuffix(rel, filepath.Ext(rel)), nil
 }
 
-// SaveJSONFile saves the JSON-encoded Graph to the SourcePath.
+// SaveJSONFile saves the JSON-encoded Graph to the SourcePath, and then
+// removes the journal of unsaved changes.
 func SaveJSONFile(g *model.Graph) error {
 	f, err := ioutil.TempFile(filepath.Dir(g.FilePath), filepath.Base(g.FilePath))
 	if err != nil {
@@ -63,7 +65,19 @@ func SaveJSONFile(g *model.Graph) error {
 	if err := f.Close(); err != nil {
 		return err
 	}
-	return os.Rename(f.Name(), g.FilePath)
+	if err := os.Rename(f.Name(), g.FilePath); err != nil {
+		return err
+	}
+	return removeJournal(g.FilePath)
+}
+
+// generatedPath returns the path GeneratePackage writes to.
+func generatedPath(g *model.Graph) (string, error) {
+	gp, err := source.GoPath()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(gp, "src", g.PackagePath, "generated.go"), nil
 }
 
 // GeneratePackage writes the Go view of the graph to a file called generated.go in
@@ -71,33 +85,42 @@ func SaveJSONFile(g *model.Graph) error {
 // Messages from the generation process will be written to out.
 func GeneratePackage(out io.Writer, g *model.Graph) (string, error) {
 	fmt.Fprintln(out, "[GeneratePackage]")
-	gp, err := source.GoPath()
+	mp, err := generatedPath(g)
 	if err != nil {
 		fmt.Fprintf(out, "source.GoPath() = %v\n(GeneratePackage failed)\n", err)
 		return "", err
 	}
-	pp := filepath.Join(gp, "src", g.PackagePath)
-	if err := os.MkdirAll(pp, os.FileMode(0755)); err != nil {
-		fmt.Fprintf(out, "os.MkdirAll(pp, 0755) = %v)\n", err)
+	if err := writeGenerated(out, mp, g, nil); err != nil {
+		fmt.Fprintln(out, "(GeneratePackage failed)")
 		return "", err
 	}
-	mp := filepath.Join(pp, "generated.go")
+	fmt.Fprintln(out, "(GeneratePackage succeeded)")
+	return mp, nil
+}
+
+// writeGenerated writes the Go view of the graph, generated with opts (which
+// may be nil), to the file mp, creating the directory if needed. Errors are
+// also written to out.
+func writeGenerated(out io.Writer, mp string, g *model.Graph, opts *model.GenOptions) error {
+	if err := os.MkdirAll(filepath.Dir(mp), os.FileMode(0755)); err != nil {
+		fmt.Fprintf(out, "os.MkdirAll(pp, 0755) = %v)\n", err)
+		return err
+	}
 	f, err := os.Create(mp)
 	if err != nil {
-		fmt.Fprintf(out, "os.Create(mp) = %v\n(GeneratePackage failed)\n", err)
-		return "", err
+		fmt.Fprintf(out, "os.Create(mp) = %v\n", err)
+		return err
 	}
 	defer f.Close()
-	if err := g.WriteGoTo(f); err != nil {
-		fmt.Fprintf(out, "g.WriteGoTo(f) = %v\n(GeneratePackage failed)\n", err)
-		return "", err
+	if err := g.WriteGoToWith(f, opts); err != nil {
+		fmt.Fprintf(out, "g.WriteGoToWith(f, opts) = %v\n", err)
+		return err
 	}
 	if err := f.Close(); err != nil {
-		fmt.Fprintf(out, "f.Close() = %v\n(GeneratePackage failed)\n", err)
-		return "", err
+		fmt.Fprintf(out, "f.Close() = %v\n", err)
+		return err
 	}
-	fmt.Fprintln(out, "(GeneratePackage succeeded)")
-	return mp, nil
+	return nil
 }
 
 // GenerateRunner generates a `go run`-able; either the output package itself,
@@ -121,6 +144,69 @@ func GenerateRunner(out io.Writer, g *model.Graph) (string, error) {
 	return path, nil
 }
 
+// runPackage is a temporary package in ${GOPATH}/src that a single run is
+// generated into, so that the generated code can differ from the saved
+// package (for instance, by being instrumented) without touching the saved
+// package or other runs.
+type runPackage struct {
+	dir  string // directory of the package
+	path string // import path of the package
+}
+
+// newRunPackage creates an empty temporary package. Call remove when
+// finished with it.
+func newRunPackage() (*runPackage, error) {
+	gp, err := source.GoPath()
+	if err != nil {
+		return nil, err
+	}
+	src := filepath.Join(gp, "src")
+	if err := os.MkdirAll(src, os.FileMode(0755)); err != nil {
+		return nil, err
+	}
+	dir, err := ioutil.TempDir(src, "shenzhen-go-run")
+	if err != nil {
+		return nil, err
+	}
+	return &runPackage{dir: dir, path: filepath.Base(dir)}, nil
+}
+
+// generatedPath returns the path generate writes the graph to.
+func (p *runPackage) generatedPath() string {
+	return filepath.Join(p.dir, "generated.go")
+}
+
+// generate writes the Go view of the graph, generated with opts, into the
+// package, together with a runner if the graph isn't a command, and returns
+// the full path to the runnable path. Messages from the generation process
+// will be written to out.
+func (p *runPackage) generate(out io.Writer, g *model.Graph, opts *model.GenOptions) (string, error) {
+	fmt.Fprintln(out, "[GenerateRunPackage]")
+	mp := p.generatedPath()
+	if err := writeGenerated(out, mp, g, opts); err != nil {
+		fmt.Fprintln(out, "(GenerateRunPackage failed)")
+		return "", err
+	}
+	if g.IsCommand {
+		fmt.Fprintln(out, "(GenerateRunPackage succeeded)")
+		return mp, nil
+	}
+	// The runner has to be in a different directory to the package it
+	// imports.
+	rp := filepath.Join(p.dir, "runner", "main.go")
+	if err := writeRunner(rp, p.path, g.PackageName()); err != nil {
+		fmt.Fprintf(out, "writeRunner(rp) = %v\n(GenerateRunPackage failed)\n", err)
+		return "", err
+	}
+	fmt.Fprintln(out, "(GenerateRunPackage succeeded)")
+	return rp, nil
+}
+
+// remove removes the package.
+func (p *runPackage) remove() error {
+	return os.RemoveAll(p.dir)
+}
+
 func runCmd(out io.Writer, cmd *exec.Cmd) error {
 	fmt.Fprintf(out, "%v\n", cmd.Args)
 	cmd.Stdout = out
@@ -133,13 +219,138 @@ func runCmd(out io.Writer, cmd *exec.Cmd) error {
 	return nil
 }
 
-// Build saves the graph as Go source code and tries to "go build" it.
+// Build saves the graph as Go source code and tries to "go build" it,
+// optionally with the race detector enabled.
 // Console output from the command (*not* the compiled program) is written to out.
-func Build(out io.Writer, g *model.Graph) error {
+func Build(out io.Writer, g *model.Graph, race bool) error {
 	if _, err := GeneratePackage(out, g); err != nil {
 		return err
 	}
-	return runCmd(out, exec.Command(`go`, `build`, g.PackagePath))
+	return runCmd(out, exec.Command(`go`, goArgs(`build`, race, g.PackagePath)...))
+}
+
+// GenerateTests writes a test for each of the graph's fixtures, and
+// benchmarks, into a file called generated_test.go next to the generated
+// package gp, or removes that file if there would be nothing in it. Messages
+// from the generation process will be written to out.
+func GenerateTests(out io.Writer, g *model.Graph, gp string) error {
+	fmt.Fprintln(out, "[GenerateTests]")
+	tp := filepath.Join(filepath.Dir(gp), "generated_test.go")
+	fs, err := g.LoadFixtures()
+	if err != nil {
+		fmt.Fprintf(out, "g.LoadFixtures() = %v\n(GenerateTests failed)\n", err)
+		return err
+	}
+	if len(fs) == 0 && !g.HasBenchmarks() {
+		if err := os.Remove(tp); err != nil && !os.IsNotExist(err) {
+			fmt.Fprintf(out, "os.Remove(tp) = %v\n(GenerateTests failed)\n", err)
+			return err
+		}
+		fmt.Fprintln(out, "(no fixtures or benchmarks)")
+		return nil
+	}
+	f, err := os.Create(tp)
+	if err != nil {
+		fmt.Fprintf(out, "os.Create(tp) = %v\n(GenerateTests failed)\n", err)
+		return err
+	}
+	defer f.Close()
+	if err := g.WriteTestGoTo(f, fs); err != nil {
+		fmt.Fprintf(out, "g.WriteTestGoTo(f, fs) = %v\n(GenerateTests failed)\n", err)
+		return err
+	}
+	if err := f.Close(); err != nil {
+		fmt.Fprintf(out, "f.Close() = %v\n(GenerateTests failed)\n", err)
+		return err
+	}
+	fmt.Fprintf(out, "(GenerateTests succeeded: %d fixtures)\n", len(fs))
+	return nil
+}
+
+// GenerateTestStubs saves the graph as Go source code, and writes a test stub
+// for each node into the generated package, unless the node already has one.
+// Messages from the generation process will be written to out.
+func GenerateTestStubs(out io.Writer, g *model.Graph) error {
+	gp, err := GeneratePackage(out, g)
+	if err != nil {
+		return err
+	}
+	fmt.Fprintln(out, "[GenerateTestStubs]")
+	dir := filepath.Dir(gp)
+	wrote := 0
+	for _, n := range g.Nodes {
+		tp := filepath.Join(dir, n.TestStubFilename())
+		f, err := os.OpenFile(tp, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0644)
+		if os.IsExist(err) {
+			fmt.Fprintf(out, "%s already exists; skipping\n", tp)
+			continue
+		}
+		if err != nil {
+			fmt.Fprintf(out, "os.OpenFile(tp) = %v\n(GenerateTestStubs failed)\n", err)
+			return err
+		}
+		if err := g.WriteNodeTestGoTo(f, n); err != nil {
+			f.Close()
+			os.Remove(tp)
+			fmt.Fprintf(out, "g.WriteNodeTestGoTo(f, %q) = %v\n(GenerateTestStubs failed)\n", n.Name, err)
+			return err
+		}
+		if err := f.Close(); err != nil {
+			fmt.Fprintf(out, "f.Close() = %v\n(GenerateTestStubs failed)\n", err)
+			return err
+		}
+		fmt.Fprintf(out, "wrote %s\n", tp)
+		wrote++
+	}
+	fmt.Fprintf(out, "(GenerateTestStubs succeeded: %d written)\n", wrote)
+	return nil
+}
+
+// Test saves the graph as Go source code, together with tests generated from
+// its fixtures, and tries to "go test" it, optionally with the race detector
+// enabled. Console output from the command is written to out. Data races
+// found in the generated code are passed to found, which may be nil if race
+// is false.
+func Test(out io.Writer, g *model.Graph, race bool, found func([]*pb.Diagnostic)) error {
+	gp, err := GeneratePackage(out, g)
+	if err != nil {
+		return err
+	}
+	if err := GenerateTests(out, g, gp); err != nil {
+		return err
+	}
+	if race {
+		rw, err := newGeneratedRaceWriter(gp, g.PackagePath, g, found)
+		if err != nil {
+			fmt.Fprintf(out, "(can't map data races to nodes: %v)\n", err)
+		} else {
+			out = io.MultiWriter(out, rw)
+		}
+	}
+	return runCmd(out, exec.Command(`go`, goArgs(`test`, race, g.PackagePath)...))
+}
+
+// Bench saves the graph as Go source code, together with the tests and
+// benchmarks generated for it, and runs the benchmarks. Console output from
+// the command, including the results, is written to out.
+func Bench(out io.Writer, g *model.Graph) error {
+	gp, err := GeneratePackage(out, g)
+	if err != nil {
+		return err
+	}
+	if err := GenerateTests(out, g, gp); err != nil {
+		return err
+	}
+	return runCmd(out, exec.Command(`go`, `test`, `-run=^$`, `-bench=.`, g.PackagePath))
+}
+
+// goArgs returns the arguments for a go subcommand.
+func goArgs(subcmd string, race bool, args ...string) []string {
+	a := []string{subcmd}
+	if race {
+		a = append(a, `-race`)
+	}
+	return append(a, args...)
 }
 
 // Install saves the graph as Go source code and tries to "go install" it.
@@ -153,18 +364,28 @@ func Install(out io.Writer, g *model.Graph) error {
 
 func writeTempRunner(g *model.Graph) (string, error) {
 	fn := filepath.Join(os.TempDir(), fmt.Sprintf("shenzhen-go-runner.%s.go", g.PackageName()))
+	if err := writeRunner(fn, g.PackagePath, g.PackageName()); err != nil {
+		return "", err
+	}
+	return fn, nil
+}
+
+// writeRunner writes a main package to fn, which runs the package at the
+// import path pkgPath, called pkgName.
+func writeRunner(fn, pkgPath, pkgName string) error {
+	if err := os.MkdirAll(filepath.Dir(fn), os.FileMode(0755)); err != nil {
+		return err
+	}
 	f, err := os.Create(fn)
 	if err != nil {
-		return "", err
+		return err
 	}
 	defer f.Close()
-	if err := goRunnerTemplate.Execute(f, g); err != nil {
-		return "", err
-	}
-	if err := f.Close(); err != nil {
-		return "", err
+	in := struct{ PackagePath, PackageName string }{pkgPath, pkgName}
+	if err := goRunnerTemplate.Execute(f, in); err != nil {
+		return err
 	}
-	return fn, nil
+	return f.Close()
 }
 
 // Graph handles displaying/editing a graph.
@@ -187,5 +408,5 @@ func renderGraph(g *serveGraph, w http.ResponseWriter, r *http.Request, uiParams
 		return
 	}
 
-	view.Graph(w, g.Graph, uiParams)
+	view.Graph(w, g.Graph, g.recoverable, uiParams)
 }
@@ -0,0 +1,226 @@
+// Copyright 2018 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"path/filepath"
+
+	"github.com/golang/protobuf/jsonpb"
+	"github.com/golang/protobuf/proto"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	pb "github.com/google/shenzhen-go/proto/go"
+)
+
+// The journal is an append-only log of the mutations made to a graph since it
+// was last saved. It lives next to the graph file, so that unsaved changes can
+// be recovered if the server goes away before the user saves.
+
+// Method names recorded in journal entries.
+const (
+	journalSetChannel         = "SetChannel"
+	journalSetGraphProperties = "SetGraphProperties"
+	journalSetNode            = "SetNode"
+	journalSetPosition        = "SetPosition"
+	journalSetRunConfig       = "SetRunConfig"
+)
+
+// journalPath returns the path of the journal for a graph file.
+// For example, the journal for dir/foo.szgo is dir/.foo.szgo.journal.
+func journalPath(filePath string) string {
+	return filepath.Join(filepath.Dir(filePath), "."+filepath.Base(filePath)+".journal")
+}
+
+// journalEntry is one line of a journal.
+type journalEntry struct {
+	Method  string          `json:"method"`
+	Request json.RawMessage `json:"request"`
+}
+
+// newRequest returns an empty request message of the type used by the method.
+func (e *journalEntry) newRequest() (proto.Message, error) {
+	switch e.Method {
+	case journalSetChannel:
+		return new(pb.SetChannelRequest), nil
+	case journalSetGraphProperties:
+		return new(pb.SetGraphPropertiesRequest), nil
+	case journalSetNode:
+		return new(pb.SetNodeRequest), nil
+	case journalSetPosition:
+		return new(pb.SetPositionRequest), nil
+	case journalSetRunConfig:
+		return new(pb.SetRunConfigRequest), nil
+	default:
+		return nil, fmt.Errorf("unknown journal method %q", e.Method)
+	}
+}
+
+// appendJournal writes a single entry to the end of the journal at path,
+// creating the journal if necessary.
+func appendJournal(path, method string, req proto.Message) error {
+	rj, err := (&jsonpb.Marshaler{}).MarshalToString(req)
+	if err != nil {
+		return err
+	}
+	line, err := json.Marshal(&journalEntry{
+		Method:  method,
+		Request: json.RawMessage(rj),
+	})
+	if err != nil {
+		return err
+	}
+	f, err := os.OpenFile(path, os.O_WRONLY|os.O_APPEND|os.O_CREATE, 0644)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	if _, err := f.Write(append(line, '\n')); err != nil {
+		return err
+	}
+	return f.Close()
+}
+
+// readJournal reads all the entries in a journal.
+func readJournal(r io.Reader) ([]*journalEntry, error) {
+	var es []*journalEntry
+	sc := bufio.NewScanner(r)
+	sc.Buffer(nil, 16<<20) // Part configs can be large.
+	for sc.Scan() {
+		line := bytes.TrimSpace(sc.Bytes())
+		if len(line) == 0 {
+			continue
+		}
+		e := new(journalEntry)
+		if err := json.Unmarshal(line, e); err != nil {
+			// A crash midway through writing an entry leaves a partial
+			// line at the end; everything before it is still good.
+			return es, fmt.Errorf("journal entry %d: %v", len(es)+1, err)
+		}
+		es = append(es, e)
+	}
+	return es, sc.Err()
+}
+
+// staleJournal reports whether there is a journal for the graph file which
+// was written more recently than the graph file itself.
+func staleJournal(filePath string) bool {
+	jfi, err := os.Stat(journalPath(filePath))
+	if err != nil {
+		return false
+	}
+	gfi, err := os.Stat(filePath)
+	if err != nil {
+		// The graph was never saved, but something was journaled.
+		return os.IsNotExist(err)
+	}
+	return jfi.ModTime().After(gfi.ModTime())
+}
+
+// removeJournal deletes the journal for the graph file, if there is one.
+func removeJournal(filePath string) error {
+	if err := os.Remove(journalPath(filePath)); err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	return nil
+}
+
+// record appends a mutation to the graph's journal. Failing to journal isn't
+// fatal to the mutation, so errors are only logged.
+func (sg *serveGraph) record(method string, req proto.Message) {
+	if sg.FilePath == "" {
+		return
+	}
+	if err := appendJournal(journalPath(sg.FilePath), method, req); err != nil {
+		log.Printf("Couldn't append to journal for %q: %v", sg.FilePath, err)
+	}
+}
+
+// apply performs the mutation described by a journal entry.
+func (sg *serveGraph) apply(e *journalEntry) error {
+	req, err := e.newRequest()
+	if err != nil {
+		return err
+	}
+	if err := jsonpb.Unmarshal(bytes.NewReader(e.Request), req); err != nil {
+		return err
+	}
+	switch req := req.(type) {
+	case *pb.SetChannelRequest:
+		return sg.setChannel(req)
+	case *pb.SetGraphPropertiesRequest:
+		return sg.setGraphProperties(req)
+	case *pb.SetNodeRequest:
+		return sg.setNode(req)
+	case *pb.SetPositionRequest:
+		return sg.setPosition(req)
+	case *pb.SetRunConfigRequest:
+		return sg.setRunConfig(req)
+	}
+	return nil
+}
+
+// restore replays the journal on top of the graph. Entries that can't be
+// applied are skipped; the number of skipped entries is returned. Once the
+// stale journal has been restored or discarded, the journal holds this
+// session's edits, so it can't be restored again.
+func (sg *serveGraph) restore() (skipped int, err error) {
+	if !sg.recoverable {
+		return 0, status.Error(codes.FailedPrecondition, "there are no unsaved changes from a previous session to restore")
+	}
+	f, err := os.Open(journalPath(sg.FilePath))
+	if err != nil {
+		return 0, status.Errorf(codes.NotFound, "open journal: %v", err)
+	}
+	defer f.Close()
+	es, err := readJournal(f)
+	if err != nil {
+		log.Printf("Reading journal for %q: %v", sg.FilePath, err)
+	}
+	for i, e := range es {
+		if err := sg.apply(e); err != nil {
+			log.Printf("Skipping journal entry %d (%s): %v", i+1, e.Method, err)
+			skipped++
+		}
+	}
+	sg.recoverable = false
+	return skipped, nil
+}
+
+// discard deletes the journal without applying it.
+func (sg *serveGraph) discard() error {
+	if err := removeJournal(sg.FilePath); err != nil {
+		return status.Errorf(codes.Internal, "remove journal: %v", err)
+	}
+	sg.recoverable = false
+	return nil
+}
+
+// checkMutable returns an error if the graph shouldn't be changed yet.
+// Edits made before a stale journal is restored or discarded can't be
+// reconciled with it, so they are refused.
+func (sg *serveGraph) checkMutable() error {
+	if sg.recoverable {
+		return status.Error(codes.FailedPrecondition, "unsaved changes from a previous session must be restored or discarded first")
+	}
+	return nil
+}
@@ -0,0 +1,113 @@
+// Copyright 2018 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"context"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"google.golang.org/grpc/codes"
+
+	"github.com/google/shenzhen-go/model"
+	pb "github.com/google/shenzhen-go/proto/go"
+)
+
+func TestJournalPath(t *testing.T) {
+	if got, want := journalPath("dir/foo.szgo"), filepath.Join("dir", ".foo.szgo.journal"); got != want {
+		t.Errorf("journalPath(dir/foo.szgo) = %q, want %q", got, want)
+	}
+}
+
+func TestReadJournalPartialLine(t *testing.T) {
+	in := `{"method":"SetPosition","request":{"graph":"foo","node":"bar","x":1}}
+{"method":"SetPosition","request":{"graph":"foo","node":"bar","x":2}}
+{"method":"SetPos`
+	es, err := readJournal(strings.NewReader(in))
+	if err == nil {
+		t.Error("readJournal(partial) = nil error, want error")
+	}
+	if got, want := len(es), 2; got != want {
+		t.Errorf("len(readJournal(partial)) = %d, want %d", got, want)
+	}
+}
+
+func TestJournalRestore(t *testing.T) {
+	dir, err := ioutil.TempDir("", "journal")
+	if err != nil {
+		t.Fatalf("ioutil.TempDir() = error %v", err)
+	}
+	defer os.RemoveAll(dir)
+	fp := filepath.Join(dir, "foo.szgo")
+
+	newGraph := func() (*model.Node, *server) {
+		bar := &model.Node{Name: "bar"}
+		foo := &model.Graph{
+			FilePath: fp,
+			Name:     "foo",
+			Nodes:    map[string]*model.Node{"bar": bar},
+		}
+		return bar, &server{
+			loadedGraphs: map[string]*serveGraph{"foo": {Graph: foo}},
+		}
+	}
+
+	// Make some changes, which should be journaled.
+	_, c := newGraph()
+	req := &pb.SetPositionRequest{Graph: "foo", Node: "bar", X: 42, Y: 17}
+	if _, err := c.SetPosition(context.Background(), req); err != nil {
+		t.Fatalf("c.SetPosition(%v) = error %v", req, err)
+	}
+	if !staleJournal(fp) {
+		t.Errorf("staleJournal(%q) = false, want true", fp)
+	}
+
+	// Start again from the unchanged graph, as though the server restarted.
+	bar, c := newGraph()
+	sg := c.loadedGraphs["foo"]
+	sg.recoverable = true
+	if _, err := c.SetPosition(context.Background(), req); code(err) != codes.FailedPrecondition {
+		t.Errorf("c.SetPosition(%v) = code %v, want %v", req, code(err), codes.FailedPrecondition)
+	}
+	skipped, err := sg.restore()
+	if err != nil {
+		t.Fatalf("sg.restore() = error %v", err)
+	}
+	if skipped != 0 {
+		t.Errorf("sg.restore() skipped %d entries, want 0", skipped)
+	}
+	if got, want := bar.X, 42.; got != want {
+		t.Errorf("bar.X = %f, want %f", got, want)
+	}
+	if got, want := bar.Y, 17.; got != want {
+		t.Errorf("bar.Y = %f, want %f", got, want)
+	}
+
+	// Restoring again would replay this session's edits a second time.
+	if _, err := sg.restore(); code(err) != codes.FailedPrecondition {
+		t.Errorf("sg.restore() again = code %v, want %v", code(err), codes.FailedPrecondition)
+	}
+
+	// Discarding removes the journal.
+	if err := sg.discard(); err != nil {
+		t.Fatalf("sg.discard() = error %v", err)
+	}
+	if _, err := os.Stat(journalPath(fp)); !os.IsNotExist(err) {
+		t.Errorf("os.Stat(journal) = error %v, want not-exist", err)
+	}
+}
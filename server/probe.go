@@ -0,0 +1,201 @@
+// Copyright 2018 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"encoding/json"
+	"io"
+	"log"
+	"net"
+	"sync"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/google/shenzhen-go/probe"
+	pb "github.com/google/shenzhen-go/proto/go"
+)
+
+// listenProbe listens for a connection from an instrumented program.
+func listenProbe() (net.Listener, error) {
+	return net.Listen("tcp", "127.0.0.1:0")
+}
+
+// probeConn is the server end of a connection from an instrumented program.
+type probeConn struct {
+	mu     sync.Mutex
+	enc    *json.Encoder // nil until the program connects
+	closed bool
+	taps   map[string]map[chan *pb.TapSample]struct{} // channel -> subscribers
+	held   map[string]bool                            // channels with a value held at a breakpoint
+}
+
+// serve accepts one connection from an instrumented program, and calls
+// handle with each message it sends, until the connection is closed. If l is
+// closed before the program connects, it returns nil.
+func (pc *probeConn) serve(l net.Listener, handle func(*probe.Message)) error {
+	defer pc.close()
+	conn, err := l.Accept()
+	if err != nil {
+		return nil
+	}
+	defer conn.Close()
+	pc.mu.Lock()
+	pc.enc = json.NewEncoder(conn)
+	pc.mu.Unlock()
+
+	dec := json.NewDecoder(conn)
+	for {
+		msg := new(probe.Message)
+		if err := dec.Decode(msg); err != nil {
+			if err == io.EOF {
+				return nil
+			}
+			return err
+		}
+		if msg.Tap != nil {
+			pc.sample(msg.Tap)
+		}
+		if msg.Held != nil {
+			pc.mu.Lock()
+			if pc.held == nil {
+				pc.held = make(map[string]bool)
+			}
+			pc.held[msg.Held.Channel] = true
+			pc.mu.Unlock()
+		}
+		handle(msg)
+	}
+}
+
+// close ends all taps.
+func (pc *probeConn) close() {
+	pc.mu.Lock()
+	defer pc.mu.Unlock()
+	pc.closed = true
+	for _, subs := range pc.taps {
+		for ch := range subs {
+			close(ch)
+		}
+	}
+	pc.taps = nil
+}
+
+// sample passes a tap sample on to subscribers, dropping it for any that
+// are behind.
+func (pc *probeConn) sample(ts *probe.Value) {
+	pc.mu.Lock()
+	defer pc.mu.Unlock()
+	s := &pb.TapSample{Value: ts.Value, Time: ts.Time}
+	for ch := range pc.taps[ts.Channel] {
+		select {
+		case ch <- s:
+		default:
+		}
+	}
+}
+
+// tap subscribes to samples of the values sent on a channel. The samples
+// channel is closed when the program disconnects. untap must be called when
+// finished with the samples.
+func (pc *probeConn) tap(channel string) (samples <-chan *pb.TapSample, untap func(), err error) {
+	pc.mu.Lock()
+	defer pc.mu.Unlock()
+	if pc.closed {
+		return nil, nil, status.Error(codes.FailedPrecondition, "the program has disconnected")
+	}
+	if pc.enc == nil {
+		return nil, nil, status.Error(codes.Unavailable, "the program hasn't connected yet")
+	}
+	if pc.taps == nil {
+		pc.taps = make(map[string]map[chan *pb.TapSample]struct{})
+	}
+	subs := pc.taps[channel]
+	if subs == nil {
+		if err := pc.enc.Encode(&probe.Command{Channel: channel, Op: probe.OpTap}); err != nil {
+			return nil, nil, status.Errorf(codes.Unavailable, "starting tap: %v", err)
+		}
+		subs = make(map[chan *pb.TapSample]struct{})
+		pc.taps[channel] = subs
+	}
+	ch := make(chan *pb.TapSample, 16)
+	subs[ch] = struct{}{}
+
+	untap = func() {
+		pc.mu.Lock()
+		defer pc.mu.Unlock()
+		if _, ok := pc.taps[channel][ch]; !ok {
+			return // already closed
+		}
+		delete(subs, ch)
+		close(ch)
+		if len(subs) > 0 {
+			return
+		}
+		delete(pc.taps, channel)
+		if err := pc.enc.Encode(&probe.Command{Channel: channel, Op: probe.OpUntap}); err != nil {
+			log.Printf("Couldn't stop tapping channel %q: %v", channel, err)
+		}
+	}
+	return ch, untap, nil
+}
+
+var debugOps = map[pb.DebugCommand_Op]string{
+	pb.DebugCommand_BREAK:    probe.OpBreak,
+	pb.DebugCommand_CLEAR:    probe.OpClear,
+	pb.DebugCommand_STEP:     probe.OpStep,
+	pb.DebugCommand_CONTINUE: probe.OpContinue,
+	pb.DebugCommand_DROP:     probe.OpDrop,
+}
+
+// debug sends a debug command to the program. It reports whether a held
+// value was released (or dropped) as a result.
+func (pc *probeConn) debug(cmd *pb.DebugCommand) (released bool, err error) {
+	op, ok := debugOps[cmd.Op]
+	if !ok {
+		return false, status.Errorf(codes.InvalidArgument, "unknown debug op %v", cmd.Op)
+	}
+	pc.mu.Lock()
+	defer pc.mu.Unlock()
+	if pc.closed {
+		return false, status.Error(codes.FailedPrecondition, "the program has disconnected")
+	}
+	if pc.enc == nil {
+		return false, status.Error(codes.Unavailable, "the program hasn't connected yet")
+	}
+	if err := pc.enc.Encode(&probe.Command{Channel: cmd.Channel, Op: op}); err != nil {
+		return false, status.Errorf(codes.Unavailable, "sending debug command: %v", err)
+	}
+	if op == probe.OpBreak || !pc.held[cmd.Channel] {
+		return false, nil
+	}
+	delete(pc.held, cmd.Channel)
+	return true, nil
+}
+
+// channelStats converts channel samples from an instrumented program.
+func channelStats(css []probe.ChannelStats) []*pb.ChannelStats {
+	out := make([]*pb.ChannelStats, len(css))
+	for i, cs := range css {
+		out[i] = &pb.ChannelStats{
+			Channel: cs.Name,
+			Len:     int64(cs.Len),
+			Cap:     int64(cs.Cap),
+			Sends:   int64(cs.Sends),
+			Rate:    cs.Rate,
+		}
+	}
+	return out
+}
@@ -0,0 +1,212 @@
+// Copyright 2018 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/shenzhen-go/probe"
+	pb "github.com/google/shenzhen-go/proto/go"
+)
+
+func TestServeProbe(t *testing.T) {
+	l, err := listenProbe()
+	if err != nil {
+		t.Fatalf("listenProbe() = error %v", err)
+	}
+	defer l.Close()
+
+	var last *probe.Message
+	done := make(chan error)
+	go func() {
+		done <- new(probeConn).serve(l, func(msg *probe.Message) { last = msg })
+	}()
+
+	ch := make(chan int, 4)
+	conn := probe.Dial(l.Addr().String())
+	if conn == nil {
+		t.Fatal("probe.Dial() = nil")
+	}
+	pc := conn.Channel("foo", cap(ch), func() int { return len(ch) })
+	for i := 0; i < 3; i++ {
+		ch <- i
+		pc.Sent()
+	}
+	conn.Close()
+
+	if err := <-done; err != nil {
+		t.Fatalf("serve() = error %v", err)
+	}
+	if last == nil {
+		t.Fatal("handle not called")
+	}
+	got := channelStats(last.Channels)
+	if len(got) != 1 {
+		t.Fatalf("len(channelStats(...)) = %d, want 1", len(got))
+	}
+	if cs := got[0]; cs.Channel != "foo" || cs.Len != 3 || cs.Cap != 4 || cs.Sends != 3 {
+		t.Errorf("channelStats(...)[0] = %v, want channel:foo len:3 cap:4 sends:3", cs)
+	}
+}
+
+func TestServeProbeNoConnection(t *testing.T) {
+	l, err := listenProbe()
+	if err != nil {
+		t.Fatalf("listenProbe() = error %v", err)
+	}
+	l.Close()
+	if err := new(probeConn).serve(l, func(*probe.Message) { t.Error("handle called") }); err != nil {
+		t.Errorf("serve(closed listener) = error %v, want nil", err)
+	}
+}
+
+func TestProbeTap(t *testing.T) {
+	l, err := listenProbe()
+	if err != nil {
+		t.Fatalf("listenProbe() = error %v", err)
+	}
+	defer l.Close()
+
+	pc := new(probeConn)
+	done := make(chan error)
+	go func() {
+		done <- pc.serve(l, func(*probe.Message) {})
+	}()
+
+	conn := probe.Dial(l.Addr().String())
+	if conn == nil {
+		t.Fatal("probe.Dial() = nil")
+	}
+	ch := conn.Channel("foo", 0, func() int { return 0 })
+
+	// Wait until the server has accepted the connection.
+	var samples <-chan *pb.TapSample
+	var untap func()
+	for deadline := time.Now().Add(5 * time.Second); ; {
+		samples, untap, err = pc.tap("foo")
+		if err == nil {
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("pc.tap(foo) = error %v", err)
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+
+	waitFor := func(want bool) {
+		for deadline := time.Now().Add(5 * time.Second); ch.Tapping() != want; {
+			if time.Now().After(deadline) {
+				t.Fatalf("ch.Tapping() = %t, want %t", !want, want)
+			}
+			time.Sleep(10 * time.Millisecond)
+		}
+	}
+	waitFor(true)
+	ch.Tap(struct{ A int }{42})
+	select {
+	case s := <-samples:
+		if want := "struct { A int }{A:42}"; s.Value != want {
+			t.Errorf("sample value = %q, want %q", s.Value, want)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("no sample received")
+	}
+
+	untap()
+	waitFor(false)
+	conn.Close()
+	if err := <-done; err != nil {
+		t.Fatalf("serve() = error %v", err)
+	}
+	if _, _, err := pc.tap("foo"); err == nil {
+		t.Error("pc.tap(foo) after disconnection = nil error, want error")
+	}
+}
+
+func TestProbeDebug(t *testing.T) {
+	l, err := listenProbe()
+	if err != nil {
+		t.Fatalf("listenProbe() = error %v", err)
+	}
+	defer l.Close()
+
+	pc := new(probeConn)
+	heldMsgs := make(chan *probe.Value, 1)
+	done := make(chan error)
+	go func() {
+		done <- pc.serve(l, func(msg *probe.Message) {
+			if msg.Held != nil {
+				heldMsgs <- msg.Held
+			}
+		})
+	}()
+
+	conn := probe.Dial(l.Addr().String(), "foo")
+	if conn == nil {
+		t.Fatal("probe.Dial() = nil")
+	}
+	ch := conn.Channel("foo", 0, func() int { return 0 })
+	if !ch.Breaking() {
+		t.Fatal("ch.Breaking() = false for initial breakpoint")
+	}
+
+	for _, test := range []struct {
+		op   pb.DebugCommand_Op
+		want bool
+	}{
+		{pb.DebugCommand_STEP, true},
+		{pb.DebugCommand_DROP, false},
+	} {
+		result := make(chan bool)
+		go func() { result <- ch.Hold(42) }()
+		select {
+		case v := <-heldMsgs:
+			if v.Channel != "foo" || v.Value != "42" {
+				t.Errorf("held value = %+v, want channel foo value 42", v)
+			}
+		case <-time.After(5 * time.Second):
+			t.Fatal("no held message received")
+		}
+		released, err := pc.debug(&pb.DebugCommand{Channel: "foo", Op: test.op})
+		if err != nil || !released {
+			t.Errorf("pc.debug(%v) = (%t, %v), want (true, nil)", test.op, released, err)
+		}
+		select {
+		case got := <-result:
+			if got != test.want {
+				t.Errorf("ch.Hold() after %v = %t, want %t", test.op, got, test.want)
+			}
+		case <-time.After(5 * time.Second):
+			t.Fatalf("ch.Hold() didn't return after %v", test.op)
+		}
+	}
+
+	released, err := pc.debug(&pb.DebugCommand{Channel: "foo", Op: pb.DebugCommand_CONTINUE})
+	if err != nil || released {
+		t.Errorf("pc.debug(CONTINUE) with nothing held = (%t, %v), want (false, nil)", released, err)
+	}
+	for deadline := time.Now().Add(5 * time.Second); ch.Breaking(); {
+		if time.Now().After(deadline) {
+			t.Fatal("ch.Breaking() = true after CONTINUE")
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+
+	conn.Close()
+	if err := <-done; err != nil {
+		t.Fatalf("serve() = error %v", err)
+	}
+}
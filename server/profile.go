@@ -0,0 +1,249 @@
+// Copyright 2018 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"bytes"
+	"compress/gzip"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"path/filepath"
+	"sort"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/golang/protobuf/proto"
+
+	"github.com/google/shenzhen-go/model"
+	"github.com/google/shenzhen-go/probe"
+	pb "github.com/google/shenzhen-go/proto/go"
+)
+
+// The following messages are the subset of the pprof profile.proto format
+// needed to attribute samples to functions. Other fields are skipped when
+// unmarshalling.
+
+type profile struct {
+	SampleType  []*profileValueType `protobuf:"bytes,1,rep,name=sample_type"`
+	Sample      []*profileSample    `protobuf:"bytes,2,rep,name=sample"`
+	Location    []*profileLocation  `protobuf:"bytes,4,rep,name=location"`
+	Function    []*profileFunction  `protobuf:"bytes,5,rep,name=function"`
+	StringTable []string            `protobuf:"bytes,6,rep,name=string_table"`
+}
+
+type profileValueType struct {
+	Type int64 `protobuf:"varint,1,opt,name=type"`
+	Unit int64 `protobuf:"varint,2,opt,name=unit"`
+}
+
+type profileSample struct {
+	LocationID []uint64 `protobuf:"varint,1,rep,packed,name=location_id"`
+	Value      []int64  `protobuf:"varint,2,rep,packed,name=value"`
+}
+
+type profileLocation struct {
+	ID   uint64         `protobuf:"varint,1,opt,name=id"`
+	Line []*profileLine `protobuf:"bytes,4,rep,name=line"`
+}
+
+type profileLine struct {
+	FunctionID uint64 `protobuf:"varint,1,opt,name=function_id"`
+}
+
+type profileFunction struct {
+	ID   uint64 `protobuf:"varint,1,opt,name=id"`
+	Name int64  `protobuf:"varint,2,opt,name=name"`
+}
+
+func (m *profile) Reset()                  { *m = profile{} }
+func (m *profile) String() string          { return proto.CompactTextString(m) }
+func (*profile) ProtoMessage()             {}
+func (m *profileValueType) Reset()         { *m = profileValueType{} }
+func (m *profileValueType) String() string { return proto.CompactTextString(m) }
+func (*profileValueType) ProtoMessage()    {}
+func (m *profileSample) Reset()            { *m = profileSample{} }
+func (m *profileSample) String() string    { return proto.CompactTextString(m) }
+func (*profileSample) ProtoMessage()       {}
+func (m *profileLocation) Reset()          { *m = profileLocation{} }
+func (m *profileLocation) String() string  { return proto.CompactTextString(m) }
+func (*profileLocation) ProtoMessage()     {}
+func (m *profileLine) Reset()              { *m = profileLine{} }
+func (m *profileLine) String() string      { return proto.CompactTextString(m) }
+func (*profileLine) ProtoMessage()         {}
+func (m *profileFunction) Reset()          { *m = profileFunction{} }
+func (m *profileFunction) String() string  { return proto.CompactTextString(m) }
+func (*profileFunction) ProtoMessage()     {}
+
+// parseProfile parses a (possibly gzipped) pprof profile.
+func parseProfile(b []byte) (*profile, error) {
+	if len(b) >= 2 && b[0] == 0x1f && b[1] == 0x8b {
+		zr, err := gzip.NewReader(bytes.NewReader(b))
+		if err != nil {
+			return nil, err
+		}
+		if b, err = ioutil.ReadAll(zr); err != nil {
+			return nil, err
+		}
+	}
+	p := new(profile)
+	if err := proto.Unmarshal(b, p); err != nil {
+		return nil, err
+	}
+	return p, nil
+}
+
+func (p *profile) str(i int64) string {
+	if i < 0 || i >= int64(len(p.StringTable)) {
+		return ""
+	}
+	return p.StringTable[i]
+}
+
+// valueIndex returns the index of the sample value with the given type,
+// or -1 if there isn't one.
+func (p *profile) valueIndex(typ string) int {
+	for i, st := range p.SampleType {
+		if p.str(st.Type) == typ {
+			return i
+		}
+	}
+	return -1
+}
+
+// byNode sums the values of type typ, attributing each sample to the
+// innermost node function on its stack. Samples not in any node are ignored.
+// nodeOf maps function names to node names.
+func (p *profile) byNode(typ string, nodeOf func(funcName string) string) map[string]int64 {
+	vi := p.valueIndex(typ)
+	if vi < 0 {
+		return nil
+	}
+	funcs := make(map[uint64]string, len(p.Function))
+	for _, f := range p.Function {
+		funcs[f.ID] = p.str(f.Name)
+	}
+	locs := make(map[uint64]*profileLocation, len(p.Location))
+	for _, l := range p.Location {
+		locs[l.ID] = l
+	}
+	sums := make(map[string]int64)
+	for _, s := range p.Sample {
+		if vi >= len(s.Value) {
+			continue
+		}
+	stack:
+		// Leaf first; within a location, inlined functions come first.
+		for _, id := range s.LocationID {
+			l := locs[id]
+			if l == nil {
+				continue
+			}
+			for _, ln := range l.Line {
+				if n := nodeOf(funcs[ln.FunctionID]); n != "" {
+					sums[n] += s.Value[vi]
+					break stack
+				}
+			}
+		}
+	}
+	return sums
+}
+
+// nodeFuncs returns a func mapping function names in the generated program
+// to the names of the nodes they belong to. Node functions are named by the
+// node's Identifier, and may have closures (e.g. "main.Foo.func1").
+func nodeFuncs(g *model.Graph) func(string) string {
+	byIdent := make(map[string]string, len(g.Nodes))
+	for _, n := range g.Nodes {
+		byIdent[n.Identifier()] = n.Name
+	}
+	return func(fn string) string {
+		// Strip the package path, then the package name.
+		if i := strings.LastIndex(fn, "/"); i >= 0 {
+			fn = fn[i+1:]
+		}
+		i := strings.Index(fn, ".")
+		if i < 0 {
+			return ""
+		}
+		fn = fn[i+1:]
+		if i := strings.Index(fn, "."); i >= 0 {
+			fn = fn[:i]
+		}
+		return byIdent[fn]
+	}
+}
+
+// nodeProfiles reads the profiles written by probe.StartProfiling into dir,
+// and sums CPU time and allocations by node.
+func nodeProfiles(dir string, g *model.Graph) ([]*pb.NodeProfile, error) {
+	nodeOf := nodeFuncs(g)
+	m := make(map[string]*pb.NodeProfile)
+	get := func(n string) *pb.NodeProfile {
+		np := m[n]
+		if np == nil {
+			np = &pb.NodeProfile{Node: n}
+			m[n] = np
+		}
+		return np
+	}
+
+	b, err := ioutil.ReadFile(filepath.Join(dir, probe.CPUProfileFile))
+	if err != nil {
+		return nil, err
+	}
+	cpu, err := parseProfile(b)
+	if err != nil {
+		return nil, fmt.Errorf("parsing CPU profile: %v", err)
+	}
+	for n, v := range cpu.byNode("cpu", nodeOf) {
+		get(n).CpuNanos = v
+	}
+
+	b, err = ioutil.ReadFile(filepath.Join(dir, probe.HeapProfileFile))
+	if err != nil {
+		return nil, err
+	}
+	heap, err := parseProfile(b)
+	if err != nil {
+		return nil, fmt.Errorf("parsing heap profile: %v", err)
+	}
+	for n, v := range heap.byNode("alloc_space", nodeOf) {
+		get(n).AllocBytes = v
+	}
+
+	nps := make([]*pb.NodeProfile, 0, len(m))
+	for _, np := range m {
+		nps = append(nps, np)
+	}
+	sort.Slice(nps, func(i, j int) bool {
+		if nps[i].CpuNanos != nps[j].CpuNanos {
+			return nps[i].CpuNanos > nps[j].CpuNanos
+		}
+		return nps[i].Node < nps[j].Node
+	})
+	return nps, nil
+}
+
+// writeProfileTable summarises node profiles as a table.
+func writeProfileTable(w io.Writer, nps []*pb.NodeProfile) {
+	tw := tabwriter.NewWriter(w, 0, 8, 2, ' ', tabwriter.AlignRight)
+	fmt.Fprintln(tw, "node\tCPU (ms)\tallocated (KiB)\t")
+	for _, np := range nps {
+		fmt.Fprintf(tw, "%s\t%d\t%d\t\n", np.Node, np.CpuNanos/1e6, np.AllocBytes/1024)
+	}
+	tw.Flush()
+}
@@ -0,0 +1,96 @@
+// Copyright 2018 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"io/ioutil"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/google/shenzhen-go/model"
+	"github.com/google/shenzhen-go/probe"
+)
+
+// These stand in for node functions in a generated program.
+
+var sink []byte
+
+func burnCPU() int {
+	x := 0
+	for start := time.Now(); time.Since(start) < 300*time.Millisecond; {
+		for i := 0; i < 1e5; i++ {
+			x += i * i
+		}
+	}
+	return x
+}
+
+func allocSome() {
+	for i := 0; i < 1024; i++ {
+		sink = make([]byte, 64<<10)
+	}
+}
+
+func TestNodeFuncs(t *testing.T) {
+	g := &model.Graph{Nodes: map[string]*model.Node{
+		"Foo Bar": {Name: "Foo Bar"},
+	}}
+	nodeOf := nodeFuncs(g)
+	tests := map[string]string{
+		"main.Foo_Bar":                        "Foo Bar",
+		"main.Foo_Bar.func2":                  "Foo Bar",
+		"example.com/pkg/thing.Foo_Bar.func1": "Foo Bar",
+		"main.main.func1":                     "",
+		"runtime.gopark":                      "",
+	}
+	for fn, want := range tests {
+		if got := nodeOf(fn); got != want {
+			t.Errorf("nodeOf(%q) = %q, want %q", fn, got, want)
+		}
+	}
+}
+
+func TestNodeProfiles(t *testing.T) {
+	dir, err := ioutil.TempDir("", "profile")
+	if err != nil {
+		t.Fatalf("ioutil.TempDir() = error %v", err)
+	}
+	defer os.RemoveAll(dir)
+
+	stop := probe.StartProfiling(dir)
+	burnCPU()
+	allocSome()
+	stop()
+
+	g := &model.Graph{Nodes: map[string]*model.Node{
+		"burnCPU":   {Name: "burnCPU"},
+		"allocSome": {Name: "allocSome"},
+	}}
+	nps, err := nodeProfiles(dir, g)
+	if err != nil {
+		t.Fatalf("nodeProfiles() = error %v", err)
+	}
+	got := make(map[string][2]int64)
+	for _, np := range nps {
+		got[np.Node] = [2]int64{np.CpuNanos, np.AllocBytes}
+	}
+	if got["burnCPU"][0] == 0 {
+		t.Errorf("CPU time for burnCPU = 0, want > 0 (profiles: %v)", nps)
+	}
+	if got["allocSome"][1] == 0 {
+		t.Errorf("allocations for allocSome = 0, want > 0 (profiles: %v)", nps)
+	}
+}
@@ -0,0 +1,138 @@
+// Copyright 2018 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"bytes"
+	"io/ioutil"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/google/shenzhen-go/model"
+	pb "github.com/google/shenzhen-go/proto/go"
+)
+
+var (
+	// e.g. "      /home/me/go/src/example/generated.go:42 +0x3e"
+	raceFrameRE = regexp.MustCompile(`^\s+(\S+\.go):(\d+)(?: \+0x[0-9a-f]+)?$`)
+
+	// e.g. "Previous write at 0x00c4200160b8 by goroutine 6:"
+	raceAddrRE = regexp.MustCompile(` at 0x[0-9a-f]+`)
+)
+
+const (
+	raceSeparator = "=================="
+	raceWarning   = "WARNING: DATA RACE"
+)
+
+// raceWriter scans output for data race reports from the race detector.
+// Stack frames in the generated code are mapped back to nodes, and passed to
+// found as diagnostics at the end of each report.
+type raceWriter struct {
+	lm     model.LineMap
+	suffix string // path suffix of the generated file, with forward slashes
+	found  func([]*pb.Diagnostic)
+
+	partial  []byte
+	inReport bool
+	header   string // the first line of the current part of the report
+	mapped   bool   // whether a frame in the current part was mapped
+	diags    []*pb.Diagnostic
+}
+
+func newRaceWriter(lm model.LineMap, pkgPath string, found func([]*pb.Diagnostic)) *raceWriter {
+	return &raceWriter{
+		lm:     lm,
+		suffix: pkgPath + "/generated.go",
+		found:  found,
+	}
+}
+
+// newGeneratedRaceWriter returns a raceWriter for the generated file at path,
+// which was generated from g into the package with the import path pkgPath.
+func newGeneratedRaceWriter(path, pkgPath string, g *model.Graph, found func([]*pb.Diagnostic)) (*raceWriter, error) {
+	src, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	lm, err := g.LineMap(src)
+	if err != nil {
+		return nil, err
+	}
+	return newRaceWriter(lm, pkgPath, found), nil
+}
+
+func (w *raceWriter) Write(b []byte) (int, error) {
+	w.partial = append(w.partial, b...)
+	for {
+		i := bytes.IndexByte(w.partial, '\n')
+		if i < 0 {
+			break
+		}
+		w.line(strings.TrimRight(string(w.partial[:i]), "\r"))
+		w.partial = w.partial[i+1:]
+	}
+	return len(b), nil
+}
+
+func (w *raceWriter) line(l string) {
+	switch {
+	case l == raceWarning:
+		w.inReport, w.header, w.mapped, w.diags = true, "", false, nil
+
+	case !w.inReport:
+		return
+
+	case l == raceSeparator:
+		if len(w.diags) > 0 {
+			w.found(w.diags)
+		}
+		w.inReport, w.diags = false, nil
+
+	case l == "":
+		return
+
+	case !strings.HasPrefix(l, " ") && strings.HasSuffix(l, ":"):
+		// "Read at 0x... by goroutine 7:", "Goroutine 7 (running) created at:"
+		w.header = raceAddrRE.ReplaceAllString(strings.TrimSuffix(l, ":"), "")
+		w.mapped = false
+
+	default:
+		if w.mapped {
+			// Only the innermost generated frame of each part is interesting.
+			return
+		}
+		m := raceFrameRE.FindStringSubmatch(l)
+		if m == nil || !strings.HasSuffix(strings.Replace(m[1], `\`, "/", -1), w.suffix) {
+			return
+		}
+		n, err := strconv.Atoi(m[2])
+		if err != nil {
+			return
+		}
+		pos, ok := w.lm[n]
+		if !ok {
+			return
+		}
+		w.mapped = true
+		w.diags = append(w.diags, &pb.Diagnostic{
+			Node:    pos.Node,
+			Section: pos.Section,
+			Line:    int64(pos.Line),
+			Message: "data race: " + w.header,
+		})
+	}
+}
@@ -0,0 +1,84 @@
+// Copyright 2018 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/golang/protobuf/proto"
+
+	"github.com/google/shenzhen-go/model"
+	pb "github.com/google/shenzhen-go/proto/go"
+)
+
+const raceReport = `some output
+==================
+WARNING: DATA RACE
+Write at 0x00c4200160b8 by goroutine 7:
+  example/racy.Node_foo.func2()
+      /home/me/go/src/example/racy/generated.go:31 +0x3e
+
+Previous write at 0x00c4200160b8 by goroutine 6:
+  example/racy.Node_foo.func2()
+      /home/me/go/src/example/racy/generated.go:32 +0x3e
+
+Goroutine 7 (running) created at:
+  example/racy.Node_foo()
+      /home/me/go/src/example/racy/generated.go:29 +0x1b3
+  example/racy.Run.func1()
+      /home/me/go/src/example/racy/generated.go:40 +0x38
+==================
+more output
+`
+
+func TestRaceWriter(t *testing.T) {
+	lm := model.LineMap{
+		29: {Node: "foo", Section: model.SectionHead, Line: 1},
+		31: {Node: "foo", Section: model.SectionBody, Line: 2},
+		32: {Node: "foo", Section: model.SectionBody, Line: 3},
+	}
+	var got []*pb.Diagnostic
+	calls := 0
+	w := newRaceWriter(lm, "example/racy", func(ds []*pb.Diagnostic) {
+		calls++
+		got = append(got, ds...)
+	})
+	// Write in awkward chunks, to check lines are reassembled.
+	for i := 0; i < len(raceReport); i += 7 {
+		j := i + 7
+		if j > len(raceReport) {
+			j = len(raceReport)
+		}
+		fmt.Fprint(w, raceReport[i:j])
+	}
+
+	if calls != 1 {
+		t.Errorf("found called %d times, want 1", calls)
+	}
+	want := []*pb.Diagnostic{
+		{Node: "foo", Section: "body", Line: 2, Message: "data race: Write by goroutine 7"},
+		{Node: "foo", Section: "body", Line: 3, Message: "data race: Previous write by goroutine 6"},
+		{Node: "foo", Section: "head", Line: 1, Message: "data race: Goroutine 7 (running) created at"},
+	}
+	if len(got) != len(want) {
+		t.Fatalf("got %d diagnostics %v, want %d", len(got), got, len(want))
+	}
+	for i := range want {
+		if !proto.Equal(got[i], want[i]) {
+			t.Errorf("diagnostic %d = %v, want %v", i, got[i], want[i])
+		}
+	}
+}
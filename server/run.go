@@ -0,0 +1,566 @@
+// Copyright 2018 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net"
+	"os"
+	"os/exec"
+	"sort"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/golang/protobuf/proto"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/google/shenzhen-go/model"
+	"github.com/google/shenzhen-go/probe"
+	pb "github.com/google/shenzhen-go/proto/go"
+)
+
+const (
+	// maxRunOutput is roughly how many bytes of output are kept for each
+	// run session, for replaying to streams that attach later.
+	maxRunOutput = 1 << 20
+
+	// maxFinishedRuns is how many sessions with exited processes are kept
+	// around, so their output can still be read.
+	maxFinishedRuns = 16
+)
+
+// runStream is satisfied by both pb.ShenzhenGo_RunServer and
+// pb.ShenzhenGo_AttachRunServer.
+type runStream interface {
+	Context() context.Context
+	Send(*pb.Output) error
+	Recv() (*pb.Input, error)
+}
+
+// runSession is a process started by Run. Its lifetime is independent of the
+// stream that started it, so that other streams can attach to it later.
+type runSession struct {
+	seq   int
+	id    string
+	graph string
+	args  []string
+	start time.Time
+
+	mu       sync.Mutex
+	proc     *os.Process
+	stdin    io.WriteCloser
+	outputs  []*pb.Output  // buffered output
+	dropped  int           // number of outputs trimmed from the front of outputs
+	size     int           // total bytes in outputs
+	channels *pb.Output    // latest channel stats, kept apart from outputs
+	changed  chan struct{} // closed and replaced whenever outputs, channels or running change
+	running  bool
+	exitErr  error
+	probe    *probeConn // nil unless the program is instrumented
+	debug    bool       // whether breakpoints work
+}
+
+func newRunSession(seq int, graph string, args []string) *runSession {
+	return &runSession{
+		seq:     seq,
+		id:      strconv.Itoa(seq),
+		graph:   graph,
+		args:    args,
+		start:   time.Now(),
+		changed: make(chan struct{}),
+		running: true,
+	}
+}
+
+// notify wakes up attached streams. Must be called with rs.mu held.
+func (rs *runSession) notify() {
+	close(rs.changed)
+	rs.changed = make(chan struct{})
+}
+
+func (rs *runSession) append(o *pb.Output) {
+	rs.mu.Lock()
+	defer rs.mu.Unlock()
+	rs.outputs = append(rs.outputs, o)
+	rs.size += proto.Size(o)
+	for rs.size > maxRunOutput && len(rs.outputs) > 1 {
+		rs.size -= proto.Size(rs.outputs[0])
+		rs.outputs[0] = nil
+		rs.outputs = rs.outputs[1:]
+		rs.dropped++
+	}
+	rs.notify()
+}
+
+// setChannels replaces the latest channel stats. Only the latest are kept,
+// apart from the buffered output, so frequent samples don't push it out.
+func (rs *runSession) setChannels(o *pb.Output) {
+	rs.mu.Lock()
+	defer rs.mu.Unlock()
+	rs.channels = o
+	rs.notify()
+}
+
+// stdout and stderr return writers that append to the output buffer.
+func (rs *runSession) stdout() io.Writer {
+	return runOutputWriter(func(b []byte) { rs.append(&pb.Output{Out: string(b)}) })
+}
+
+func (rs *runSession) stderr() io.Writer {
+	return runOutputWriter(func(b []byte) { rs.append(&pb.Output{Err: string(b)}) })
+}
+
+type runOutputWriter func([]byte)
+
+func (w runOutputWriter) Write(b []byte) (int, error) {
+	w(b)
+	return len(b), nil
+}
+
+// finish records the result of the process.
+func (rs *runSession) finish(err error) {
+	if err != nil {
+		fmt.Fprintf(rs.stderr(), "(process %v)", err)
+	} else {
+		fmt.Fprintln(rs.stderr(), "(process succeeded)")
+	}
+	rs.mu.Lock()
+	defer rs.mu.Unlock()
+	rs.running = false
+	rs.exitErr = err
+	rs.proc = nil
+	rs.stdin = nil
+	rs.notify()
+}
+
+// exec runs the program generated from the graph, and waits for it to exit.
+// breakpoints are the channels to break on from the start, in debug runs.
+func (rs *runSession) exec(g *serveGraph, rc *model.RunConfig, breakpoints []string) {
+	stderr := rs.stderr()
+	opts := new(model.GenOptions)
+	if rc.Profile {
+		dir, err := ioutil.TempDir("", "shenzhen-go-profile")
+		if err != nil {
+			rs.finish(err)
+			return
+		}
+		defer os.RemoveAll(dir)
+		opts.ProfileDir = dir
+	}
+	opts.RecordFile, opts.Record = rc.RecordFile, rc.Record
+	opts.ReplayFile, opts.Replay = rc.ReplayFile, rc.Replay
+	var probeDone chan struct{}
+	if rc.Instrument || rc.Debug {
+		l, err := listenProbe()
+		if err != nil {
+			rs.finish(err)
+			return
+		}
+		opts.ProbeAddr = l.Addr().String()
+		if rc.Debug {
+			opts.Debug, opts.Breakpoints = true, breakpoints
+		}
+		pc := new(probeConn)
+		rs.mu.Lock()
+		rs.probe, rs.debug = pc, rc.Debug
+		rs.mu.Unlock()
+		probeDone = make(chan struct{})
+		go func() {
+			defer close(probeDone)
+			rs.serveProbe(pc, l)
+		}()
+		// Unblocks Accept if the program never connects.
+		defer func() {
+			l.Close()
+			<-probeDone
+		}()
+	}
+	// Instrumented programs are generated into a package of their own, so
+	// they don't overwrite the saved package, or each other.
+	var pkg *runPackage
+	if instrumented(opts) {
+		p, err := newRunPackage()
+		if err != nil {
+			rs.finish(err)
+			return
+		}
+		defer p.remove()
+		pkg = p
+	}
+	g.Lock()
+	var gp string
+	var err error
+	if pkg != nil {
+		gp, err = pkg.generate(stderr, g.Graph, opts)
+	} else {
+		gp, err = GenerateRunner(stderr, g.Graph)
+	}
+	var rw *raceWriter
+	if err == nil && rc.Race {
+		rw, err = rs.raceWriter(g.Graph, pkg)
+		if err != nil {
+			fmt.Fprintf(stderr, "(can't map data races to nodes: %v)\n", err)
+			err = nil
+		}
+	}
+	g.Unlock()
+	if err != nil {
+		rs.finish(err)
+		return
+	}
+
+	cmd := exec.Command("go", append(goArgs("run", rc.Race, gp), rc.Args...)...)
+	if len(rc.Env) > 0 {
+		cmd.Env = append(os.Environ(), rc.Env...)
+	}
+	cmd.Dir = rc.Dir
+	fmt.Fprintf(stderr, "%v\n", cmd.Args)
+	if cmd.Dir != "" {
+		fmt.Fprintf(stderr, "(in %s)\n", cmd.Dir)
+	}
+
+	// A pipe is better for input; managing a buffer is fiddly, and cmd.Wait
+	// will wait until read returns, which doesn't mesh well with the
+	// behaviour of svr.Recv.
+	stdin, err := cmd.StdinPipe()
+	if err != nil {
+		rs.finish(fmt.Errorf("attaching stdin pipe: %v", err))
+		return
+	}
+	cmd.Stdout, cmd.Stderr = rs.stdout(), stderr
+	if rw != nil {
+		cmd.Stderr = io.MultiWriter(stderr, rw)
+	}
+	// go run compiles and forks a temporary binary. Need to control it as a process group.
+	setpgid(cmd)
+	if err := cmd.Start(); err != nil {
+		rs.finish(err)
+		return
+	}
+	rs.mu.Lock()
+	rs.proc, rs.stdin = cmd.Process, stdin
+	rs.mu.Unlock()
+	err = cmd.Wait()
+	if probeDone != nil {
+		// Wait for the last of the channel stats.
+		select {
+		case <-probeDone:
+		case <-time.After(time.Second):
+		}
+	}
+	if opts.ProfileDir != "" {
+		rs.profile(g, opts.ProfileDir)
+	}
+	rs.finish(err)
+}
+
+// instrumented reports whether the code generated with opts differs from the
+// saved package.
+func instrumented(opts *model.GenOptions) bool {
+	return opts.ProfileDir != "" || opts.ProbeAddr != "" || len(opts.Record) > 0 || len(opts.Replay) > 0
+}
+
+// profile reads the profiles from dir and adds them to the output.
+func (rs *runSession) profile(g *serveGraph, dir string) {
+	g.Lock()
+	nps, err := nodeProfiles(dir, g.Graph)
+	g.Unlock()
+	if err != nil {
+		fmt.Fprintf(rs.stderr(), "(couldn't read profiles: %v)\n", err)
+		return
+	}
+	var buf bytes.Buffer
+	buf.WriteString("(profile by node)\n")
+	writeProfileTable(&buf, nps)
+	rs.append(&pb.Output{Err: buf.String(), Profile: nps})
+}
+
+// serveProbe adds the channel stats from an instrumented program to the
+// output.
+func (rs *runSession) serveProbe(pc *probeConn, l net.Listener) {
+	err := pc.serve(l, func(msg *probe.Message) {
+		if len(msg.Channels) > 0 {
+			rs.setChannels(&pb.Output{Channels: channelStats(msg.Channels)})
+		}
+		if msg.Held != nil {
+			rs.append(&pb.Output{Held: &pb.HeldValue{
+				Channel: msg.Held.Channel,
+				Value:   msg.Held.Value,
+			}})
+		}
+	})
+	if err != nil {
+		fmt.Fprintf(rs.stderr(), "(instrumentation: %v)\n", err)
+	}
+}
+
+// tap subscribes to samples of the values sent on a channel.
+func (rs *runSession) tap(channel string) (<-chan *pb.TapSample, func(), error) {
+	rs.mu.Lock()
+	pc := rs.probe
+	rs.mu.Unlock()
+	if pc == nil {
+		return nil, nil, status.Errorf(codes.FailedPrecondition, "run session %s isn't showing channel activity, so can't be tapped", rs.id)
+	}
+	return pc.tap(channel)
+}
+
+// debugCommand sends a debug command to the program.
+func (rs *runSession) debugCommand(cmd *pb.DebugCommand) error {
+	rs.mu.Lock()
+	pc, debug := rs.probe, rs.debug
+	rs.mu.Unlock()
+	if !debug {
+		return status.Errorf(codes.FailedPrecondition, "run session %s isn't a debug run", rs.id)
+	}
+	released, err := pc.debug(cmd)
+	if err != nil {
+		return err
+	}
+	if released {
+		rs.append(&pb.Output{Released: cmd.Channel})
+	}
+	return nil
+}
+
+// raceWriter returns a raceWriter which adds diagnostics to the output. pkg
+// is the package the program was generated into, or nil if it was generated
+// into the saved package.
+func (rs *runSession) raceWriter(g *model.Graph, pkg *runPackage) (*raceWriter, error) {
+	gp, pkgPath := "", g.PackagePath
+	if pkg != nil {
+		gp, pkgPath = pkg.generatedPath(), pkg.path
+	} else {
+		p, err := generatedPath(g)
+		if err != nil {
+			return nil, err
+		}
+		gp = p
+	}
+	return newGeneratedRaceWriter(gp, pkgPath, g, func(ds []*pb.Diagnostic) {
+		rs.append(&pb.Output{Diagnostics: ds})
+	})
+}
+
+// input writes to the process' stdin. ^C interrupts the process.
+func (rs *runSession) input(in string) error {
+	rs.mu.Lock()
+	defer rs.mu.Unlock()
+	if rs.stdin == nil {
+		return nil
+	}
+	if _, err := io.WriteString(rs.stdin, in); err != nil {
+		return err
+	}
+	if in == "\x03" { // ^C
+		return interrupt(rs.proc)
+	}
+	return nil
+}
+
+// signal interrupts (or kills) the process.
+func (rs *runSession) signal(force bool) error {
+	rs.mu.Lock()
+	defer rs.mu.Unlock()
+	if rs.proc == nil {
+		return nil
+	}
+	if force {
+		return kill(rs.proc)
+	}
+	return interrupt(rs.proc)
+}
+
+// attach streams the session output to a stream, starting with the buffered
+// output and the latest channel stats, and forwards input from the stream to
+// the process. It returns when the process has exited and all output is sent,
+// or the stream ends.
+func (rs *runSession) attach(svr runStream) error {
+	if err := svr.Send(&pb.Output{RunId: rs.id}); err != nil {
+		return err
+	}
+	go func() {
+		for {
+			in, err := svr.Recv()
+			if err != nil {
+				return
+			}
+			if err := rs.input(in.In); err != nil {
+				fmt.Fprintf(rs.stderr(), "(input: %v)\n", err)
+			}
+			if in.Debug != nil {
+				if err := rs.debugCommand(in.Debug); err != nil {
+					fmt.Fprintf(rs.stderr(), "(debug: %v)\n", err)
+				}
+			}
+		}
+	}()
+
+	next := 0 // index of the next output to send, counting trimmed outputs
+	var sentChannels *pb.Output
+	for {
+		rs.mu.Lock()
+		if next < rs.dropped {
+			next = rs.dropped
+		}
+		// Copy, since append trims outputs in place once the lock is released.
+		pending := append([]*pb.Output(nil), rs.outputs[next-rs.dropped:]...)
+		next += len(pending)
+		channels := rs.channels
+		running, exitErr, changed := rs.running, rs.exitErr, rs.changed
+		rs.mu.Unlock()
+
+		for _, o := range pending {
+			if err := svr.Send(o); err != nil {
+				return err
+			}
+		}
+		if channels != sentChannels {
+			if err := svr.Send(channels); err != nil {
+				return err
+			}
+			sentChannels = channels
+		}
+		if !running {
+			if exitErr != nil {
+				return status.Errorf(codes.Aborted, "process: %v", exitErr)
+			}
+			return nil
+		}
+		select {
+		case <-changed:
+		case <-svr.Context().Done():
+			return svr.Context().Err()
+		}
+	}
+}
+
+func (rs *runSession) info() *pb.RunInfo {
+	rs.mu.Lock()
+	defer rs.mu.Unlock()
+	ri := &pb.RunInfo{
+		Id:        rs.id,
+		Graph:     rs.graph,
+		Args:      rs.args,
+		StartTime: rs.start.Unix(),
+		Running:   rs.running,
+	}
+	if !rs.running {
+		ri.ExitStatus = "succeeded"
+		if rs.exitErr != nil {
+			ri.ExitStatus = rs.exitErr.Error()
+		}
+	}
+	return ri
+}
+
+// runSessions tracks the run sessions of a server.
+type runSessions struct {
+	mu     sync.Mutex
+	lastID int
+	m      map[string]*runSession
+}
+
+// new creates and registers a new session, and forgets old finished ones.
+func (r *runSessions) new(graph string, args []string) *runSession {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.lastID++
+	rs := newRunSession(r.lastID, graph, args)
+	if r.m == nil {
+		r.m = make(map[string]*runSession)
+	}
+	r.m[rs.id] = rs
+
+	var finished []*runSession
+	for _, s := range r.m {
+		s.mu.Lock()
+		if !s.running {
+			finished = append(finished, s)
+		}
+		s.mu.Unlock()
+	}
+	if len(finished) > maxFinishedRuns {
+		sort.Slice(finished, func(i, j int) bool { return finished[i].seq < finished[j].seq })
+		for _, s := range finished[:len(finished)-maxFinishedRuns] {
+			delete(r.m, s.id)
+		}
+	}
+	return rs
+}
+
+func (r *runSessions) lookup(id string) (*runSession, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	rs := r.m[id]
+	if rs == nil {
+		return nil, status.Errorf(codes.NotFound, "no such run session %q", id)
+	}
+	return rs, nil
+}
+
+// latest returns the most recently started session for the graph that is
+// still running.
+func (r *runSessions) latest(graph string) (*runSession, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	var latest *runSession
+	for _, rs := range r.m {
+		if rs.graph != graph || (latest != nil && rs.seq < latest.seq) {
+			continue
+		}
+		rs.mu.Lock()
+		running := rs.running
+		rs.mu.Unlock()
+		if running {
+			latest = rs
+		}
+	}
+	if latest == nil {
+		return nil, status.Errorf(codes.NotFound, "graph %q isn't running", graph)
+	}
+	return latest, nil
+}
+
+func (r *runSessions) remove(id string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	delete(r.m, id)
+}
+
+// list returns info about the sessions for a graph (or all graphs, if graph
+// is empty), in order of starting.
+func (r *runSessions) list(graph string) []*pb.RunInfo {
+	r.mu.Lock()
+	rss := make([]*runSession, 0, len(r.m))
+	for _, rs := range r.m {
+		if graph == "" || rs.graph == graph {
+			rss = append(rss, rs)
+		}
+	}
+	r.mu.Unlock()
+	sort.Slice(rss, func(i, j int) bool { return rss[i].seq < rss[j].seq })
+	ris := make([]*pb.RunInfo, len(rss))
+	for i, rs := range rss {
+		ris[i] = rs.info()
+	}
+	return ris
+}
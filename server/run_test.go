@@ -0,0 +1,251 @@
+// Copyright 2018 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"context"
+	"errors"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"google.golang.org/grpc/codes"
+
+	"github.com/google/shenzhen-go/model"
+	pb "github.com/google/shenzhen-go/proto/go"
+)
+
+type fakeRunStream struct {
+	ctx  context.Context
+	sent []*pb.Output
+}
+
+func (f *fakeRunStream) Context() context.Context { return f.ctx }
+func (f *fakeRunStream) Send(o *pb.Output) error  { f.sent = append(f.sent, o); return nil }
+func (f *fakeRunStream) Recv() (*pb.Input, error) { return nil, io.EOF }
+
+func (f *fakeRunStream) output() string {
+	var sb strings.Builder
+	for _, o := range f.sent {
+		sb.WriteString(o.Out)
+		sb.WriteString(o.Err)
+	}
+	return sb.String()
+}
+
+func TestRunSessionAttachReplays(t *testing.T) {
+	var r runSessions
+	rs := r.new("foo", []string{"-v"})
+	rs.stdout().Write([]byte("hello\n"))
+	rs.stderr().Write([]byte("oops\n"))
+
+	done := make(chan error)
+	svr := &fakeRunStream{ctx: context.Background()}
+	go func() { done <- rs.attach(svr) }()
+	rs.finish(nil)
+	if err := <-done; err != nil {
+		t.Fatalf("rs.attach() = error %v", err)
+	}
+	if got, want := svr.sent[0].RunId, rs.id; got != want {
+		t.Errorf("first Output.RunId = %q, want %q", got, want)
+	}
+	if got, want := svr.output(), "hello\noops\n(process succeeded)\n"; got != want {
+		t.Errorf("attached output = %q, want %q", got, want)
+	}
+
+	// Attaching after the process has exited still gets all the output.
+	svr2 := &fakeRunStream{ctx: context.Background()}
+	if err := rs.attach(svr2); err != nil {
+		t.Fatalf("rs.attach() = error %v", err)
+	}
+	if got, want := svr2.output(), svr.output(); got != want {
+		t.Errorf("second attached output = %q, want %q", got, want)
+	}
+}
+
+func TestRunSessionAttachFailure(t *testing.T) {
+	var r runSessions
+	rs := r.new("foo", nil)
+	rs.finish(errors.New("exit status 1"))
+	err := rs.attach(&fakeRunStream{ctx: context.Background()})
+	if got, want := code(err), codes.Aborted; got != want {
+		t.Errorf("rs.attach() = code %v, want %v", got, want)
+	}
+	if got, want := rs.info().ExitStatus, "exit status 1"; got != want {
+		t.Errorf("rs.info().ExitStatus = %q, want %q", got, want)
+	}
+}
+
+func TestRunSessionOutputTrimmed(t *testing.T) {
+	var r runSessions
+	rs := r.new("foo", nil)
+	chunk := strings.Repeat("x", maxRunOutput/4)
+	for i := 0; i < 8; i++ {
+		rs.stdout().Write([]byte(chunk))
+	}
+	if rs.size > maxRunOutput {
+		t.Errorf("rs.size = %d, want at most %d", rs.size, maxRunOutput)
+	}
+	if rs.dropped == 0 {
+		t.Error("rs.dropped = 0, want > 0")
+	}
+}
+
+func TestRunSessionAttachWhileTrimming(t *testing.T) {
+	var r runSessions
+	rs := r.new("foo", nil)
+	go func() {
+		chunk := []byte(strings.Repeat("x", maxRunOutput/4))
+		for i := 0; i < 100; i++ {
+			rs.stdout().Write(chunk)
+		}
+		rs.finish(nil)
+	}()
+	svr := &fakeRunStream{ctx: context.Background()}
+	if err := rs.attach(svr); err != nil {
+		t.Fatalf("rs.attach() = error %v", err)
+	}
+	for i, o := range svr.sent {
+		if o == nil {
+			t.Fatalf("attached output %d is nil", i)
+		}
+	}
+}
+
+func TestRunSessionChannelsKeepOutput(t *testing.T) {
+	var r runSessions
+	rs := r.new("foo", nil)
+	rs.stdout().Write([]byte("hello\n"))
+	stats := strings.Repeat("x", maxRunOutput/4)
+	for i := 0; i < 8; i++ {
+		rs.setChannels(&pb.Output{Channels: []*pb.ChannelStats{{Channel: stats}}})
+	}
+	latest := &pb.Output{Channels: []*pb.ChannelStats{{Channel: "latest"}}}
+	rs.setChannels(latest)
+
+	done := make(chan error)
+	svr := &fakeRunStream{ctx: context.Background()}
+	go func() { done <- rs.attach(svr) }()
+	rs.finish(nil)
+	if err := <-done; err != nil {
+		t.Fatalf("rs.attach() = error %v", err)
+	}
+	if got, want := svr.output(), "hello\n(process succeeded)\n"; got != want {
+		t.Errorf("attached output = %q, want %q", got, want)
+	}
+	var channels []*pb.Output
+	for _, o := range svr.sent {
+		if len(o.Channels) > 0 {
+			channels = append(channels, o)
+		}
+	}
+	if len(channels) != 1 || channels[0] != latest {
+		t.Errorf("attached channel stats = %v, want only %v", channels, latest)
+	}
+}
+
+func TestRunSessionsList(t *testing.T) {
+	var r runSessions
+	a := r.new("foo", nil)
+	r.new("bar", nil)
+	c := r.new("foo", nil)
+	c.finish(nil)
+
+	ris := r.list("foo")
+	if got, want := len(ris), 2; got != want {
+		t.Fatalf("len(r.list(foo)) = %d, want %d", got, want)
+	}
+	if got, want := ris[0].Id, a.id; got != want {
+		t.Errorf("r.list(foo)[0].Id = %q, want %q", got, want)
+	}
+	if !ris[0].Running || ris[1].Running {
+		t.Errorf("r.list(foo) running = [%t, %t], want [true, false]", ris[0].Running, ris[1].Running)
+	}
+	if got, want := len(r.list("")), 3; got != want {
+		t.Errorf("len(r.list()) = %d, want %d", got, want)
+	}
+
+	for i := 0; i < maxFinishedRuns+4; i++ {
+		r.new("baz", nil).finish(nil)
+	}
+	r.new("baz", nil)
+	if _, err := r.lookup(a.id); err != nil {
+		t.Errorf("r.lookup(%q) = error %v, want running session kept", a.id, err)
+	}
+	if _, err := r.lookup(c.id); code(err) != codes.NotFound {
+		t.Errorf("r.lookup(%q) = code %v, want %v", c.id, code(err), codes.NotFound)
+	}
+}
+
+func TestRunPackage(t *testing.T) {
+	gopath, err := ioutil.TempDir("", "run_test")
+	if err != nil {
+		t.Fatalf("ioutil.TempDir() = error %v", err)
+	}
+	defer os.RemoveAll(gopath)
+	defer os.Setenv("GOPATH", os.Getenv("GOPATH"))
+	os.Setenv("GOPATH", gopath)
+
+	g := model.NewGraph("foo.szgo", "", "example.com/foo")
+	opts := &model.GenOptions{ProbeAddr: "localhost:1"}
+	p1, err := newRunPackage()
+	if err != nil {
+		t.Fatalf("newRunPackage() = error %v", err)
+	}
+	defer p1.remove()
+	p2, err := newRunPackage()
+	if err != nil {
+		t.Fatalf("newRunPackage() = error %v", err)
+	}
+	defer p2.remove()
+	if p1.dir == p2.dir {
+		t.Errorf("newRunPackage() twice = the same directory %q", p1.dir)
+	}
+
+	runner, err := p1.generate(ioutil.Discard, g, opts)
+	if err != nil {
+		t.Fatalf("p.generate() = error %v", err)
+	}
+	if got, want := filepath.Dir(filepath.Dir(runner)), p1.dir; got != want {
+		t.Errorf("p.generate() = %q, want a runner in a subdirectory of %q", runner, want)
+	}
+	src, err := ioutil.ReadFile(runner)
+	if err != nil {
+		t.Fatalf("ioutil.ReadFile(runner) = error %v", err)
+	}
+	if want := `import "` + p1.path + `"`; !strings.Contains(string(src), want) {
+		t.Errorf("runner = %s, want it to contain %s", src, want)
+	}
+	if _, err := os.Stat(p1.generatedPath()); err != nil {
+		t.Errorf("os.Stat(p.generatedPath()) = error %v", err)
+	}
+	saved, err := generatedPath(g)
+	if err != nil {
+		t.Fatalf("generatedPath() = error %v", err)
+	}
+	if _, err := os.Stat(saved); !os.IsNotExist(err) {
+		t.Errorf("os.Stat(saved package) = error %v, want not exist", err)
+	}
+
+	if err := p1.remove(); err != nil {
+		t.Errorf("p.remove() = error %v", err)
+	}
+	if _, err := os.Stat(p1.dir); !os.IsNotExist(err) {
+		t.Errorf("os.Stat(p.dir) after remove = error %v, want not exist", err)
+	}
+}
@@ -43,6 +43,7 @@ func New(uiParams view.Params) *server {
 type server struct {
 	uiParams     *view.Params
 	loadedGraphs map[string]*serveGraph
+	runs         runSessions
 	sync.Mutex
 }
 
@@ -69,6 +70,7 @@ func (c *server) createGraph(key string, graph *model.Graph) (*serveGraph, error
 
 type serveGraph struct {
 	*model.Graph
+	recoverable bool // a stale journal exists, and hasn't been restored or discarded yet
 	sync.Mutex
 }
 
@@ -83,7 +85,8 @@ func (sg *serveGraph) reload() error {
 		return status.Errorf(codes.FailedPrecondition, "load from JSON: %v", err)
 	}
 	sg.Graph = g
-	return nil
+	// Reverting throws away unsaved changes, including any in the journal.
+	return sg.discard()
 }
 
 func (sg *serveGraph) lookupChannel(channel string) (*model.Channel, error) {
@@ -138,6 +141,7 @@ func (c *server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 			http.ServeContent(w, r, f.Name(), fi.ModTime(), f)
 			return
 		}
+		sg.recoverable = staleJournal(base)
 		renderGraph(sg, w, r, c.uiParams)
 		return
 	}
@@ -155,9 +159,10 @@ func (c *server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 			log.Printf("Guessing a package path: %v", err)
 		}
 		urlPath := path.Join(r.URL.Path, nu)
-		if _, err := c.createGraph(urlPath, model.NewGraph(nfp, urlPath, pkgp)); err != nil {
+		if sg, err := c.createGraph(urlPath, model.NewGraph(nfp, urlPath, pkgp)); err != nil {
 			log.Printf("Graph already created in server: %v", err)
 		} else {
+			sg.recoverable = staleJournal(nfp)
 			log.Printf("Created new graph: %v", nfp)
 		}
 		http.Redirect(w, r, urlPath, http.StatusSeeOther)
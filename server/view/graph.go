@@ -29,6 +29,7 @@ type editorInput struct {
 	Params              *Params
 	Graph               *model.Graph
 	GraphJSON           string
+	Recoverable         bool
 	PartTypes           map[string]*model.PartType
 	PartTypesByCategory map[string]map[string]*model.PartType
 	Licenses            []license
@@ -39,8 +40,9 @@ type license struct {
 	URL       template.URL
 }
 
-// Graph displays a graph.
-func Graph(w http.ResponseWriter, g *model.Graph, params *Params) {
+// Graph displays a graph. If recoverable is set, the user is offered the
+// chance to restore unsaved changes from a previous session.
+func Graph(w http.ResponseWriter, g *model.Graph, recoverable bool, params *Params) {
 	gj, err := json.Marshal(g)
 	if err != nil {
 		log.Printf("Could not execute graph editor template: %v", err)
@@ -52,6 +54,7 @@ func Graph(w http.ResponseWriter, g *model.Graph, params *Params) {
 		Params:              params,
 		Graph:               g,
 		GraphJSON:           string(gj),
+		Recoverable:         recoverable,
 		PartTypes:           model.PartTypes,
 		PartTypesByCategory: model.PartTypesByCategory,
 	}
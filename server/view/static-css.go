@@ -3,8 +3,8 @@
 package view
 
 var cssResources = map[string][]byte{
-	"css/fonts.css": []byte("\x1f\x8b\b\b\x17\xa4\a\\\x02\xfffonts.css\x00̓\xb1J\xc50\x14\x86盧Ȗ{\x87{\xdb\xc5%]ĥ8t\xf1\rb\x9a\xd4\xc0i\x8e$'H\x11\xdf]Z\xdbE\x04[R\x8bc\xc2\xe1\xf0\xf1\u007f\xff\xb9\xb7\xe8\xe9j\x956\xfc\x9d\x9d\xe6G\xef`\x90\\\xd4(*v\x8aAK\x9e\x02\x9cEq\x8b\xa4\xc8\xe9b\x1c\x8bE\x8d\x8di]ꯏ\xa4\xc0\xe9\x1b\x91\x15\x17n1\xf4\x8a\u0382B24\xbc\x1aq\xa9\xe6\xbdo\xc6u/$\xf9]Y._\x91\x060\x92\xbbiA\xc5>\x18\xcb\xe2\xd9J\xe2\xc7\t\xf8+\x98\a\x84v5\xca3B\xfb\rd\xa1\xcb\x05\xf9\xb2\x94\xa1g/\x90)\x91\x8d\x8a~\xc8e/AO\xa6K\xa0B^[Vg\xc3\x1b\xf4\xbf\x9aB\u007f`m\xd6\x12\xfd\xb7\x80\x0e=\xf2M\xdav\xec\xf6g\x00\x00\x00\xff\xffP\xa3B\xc1\x98\x05\x00\x00"),
-	"css/main.css": []byte("\x1f\x8b\b\b\x17\xa4\a\\\x02\xffmain.css\x00\xacWݎ\xe3(\x13\xbd\x1e?\x05\xd2蓾\x95\xdaQ\xd2\xea\x1d\xb5<\xda'Y\xed\x056\x15\xbb\xd4\x18\x10\xe0ęU\xbf\xfb\n\f\xfe\x89\xed\xc4\xe9\xc9Uw\x8a\xe2Pu\xaa\xea\x80s\xc9.\xe4߄\x10B\x8eR\xd8\xf4Hk䗌\x9c\xa8\xfe\u007f\x9a\x8eL\u007f\xfc\xf4N\x85\xe4R\xc7e\v\xadM\x9d\xa5\xd1a9\xa7\xc5G\xa9e#X:\xf1\xbc\xb2\xf7\xfeG.\xa9͈\x90\x02:CMu\x89\"#\xfb\xee'C\xa38\xbdd\xe4ȡ\x8d[\xa0M\x8f\\\x9e3\x17LS\x8b\xce\\\x01\x96\x95\xcd\xc8a\xbf\xff_\x84jө\xf53I\x8c\xa2b\xc7Q|\x84\xa4'Q:\xfb4>\x9f!\x83BjjQ\x8aq\xa4E\xa3\x8d۪$\n\vz\x8a\xbe3\xc0\xa1\xb0\xc0\xc6ܞC0\xb9\xe4l\xea\x9eU\xf2\x04z5$\xbfz'\xb0F0\xd0\x1c]t\x93@\x18\x18\xab\x9b\xc2\xe2\tV\xf1G>\xc3)k(wb\x1dc]\xc5\xfd\x99$4sN/\t\xcdNhp\xe0\xe7ke\xf0\x80Ϧ\x8eN\x92\r\xe1Nl\xf7B_\xa3\x93>\x8d\xc6B2\xd84\xb5i-\x85\xbc3\xba\xa9C\xebpQ\xa8ƾ$]\xf3\xbe$Ήj\xa0\x0f\x1f\xe5\xcd\x06\u007f\xc1\xc4\xcf\x19\xbe\xa0\x13\xdfnhN\xf2-\x97\x9a\x05vz\x1f\x86'dcʢ\x97\xb1\x17\x0e\x191\x92#\x1b\xac\x9a2lLF\xdeT;\x18\xcf\xc8l\x95\x91\x83\xb7)\xca\x18\x8a2#\xef\ue9db\f\xcf\xd0V\xe1\xfcL\x12\x86\xa7\xddQ\xea\x9ax\x8a\xff\xb6\x17\x05\u007f\xb9T\xfey\x19\x96\"\xed\xbd\xe1\x8a\xff\x18\xd2H\xe1:\xb1|W\xedL0Q\xb8\x8eNs.\x8b\x8f\xc0\xb9l]\t|\x1e!\xc9\\\xb6\x93\xe8\x8e\b\x9c\xdd>-\xb5Re\xe4𪆝\xb9\x96g\xe3Z]X\x8a\xa2o\xea^\xca\tm\xac\xec\xa8\xf3v\x14\x91\xdd\xf7\xfd~\x84S\x01\x8d\x87\xf7\x84\xffP#\xd9wX\a\x8f\x163\nYX+\xeb\xab\xe2\xce\xd7o\xb7\xc8\xdc\u007f\xdc\x011Sن\x00o_LZ\x9e\x97\xa3\x0e8\xd7Tun\ar \u007f\x86{\x8a\xe1\xe9;CZjZψurНc\n-9\xef7(*\x80\x9b\x99\xff\xb6K4\x84\xe61\x1e\xda8$0Tf-\xc4\x0e~\xe7\xca\v˵\x0e~B2H\u007f?\x96\xd8Y\x05\b\xab\xa3dz\t\xa1\x1cK\x91\x11\xb7\x10.oKs\x0e\xa1\x99\x9f\xafx\x93\xe9\xf9\xa1\xac?\xd2ϛ\x01{51o\x81\x88\xb8\xfc]Q[Y\xa8\x15\xa7\x16\xae\xf9\xe8\xaf\xc2\x1d\xd3R1y\x16\x91Wi\xb0\xbb\xe04p\xean\x92\xbb\x12\x11\x82\xe4p\xb4\x19y\x85v\x8a\xeb\x1b\v\x84]\x8e`z$͍䍅\xdbr\xdf#\xaf\xbd\x0f\xbdfU\x94\xb9\x1a\xefU\xebZ\xc4k\x8f\xff1`\x04\x9f\xe9\u07be\xaf\xdeT\xdbQꬿR\x14\xccO\xdb4\xb9p\x1f\xdfM6\xb0\xb5\xc8K\x13\x9b\x95\xa3\xb1\x9d\x1e\xa5N\xe9o<p\xfb \xf7׃S!c \xd6O\xe2\x18\x85\xbaB\v\xa9Q\xb4\xf0\a\x9d5U?\x17۩S\x1e\x06\xc0\xd0>\xbf\xc1\x17\xc6\u0382\xaeQP~\xa7!g7\xcd\xfc\xd5~*\xa3\x1a\x06\xac\xa1a\x06E\xef\xd4r\xa9\x95\xae vLӲt\xe3\x1e\xdf_\xe1-_j\x9a\xdf\xf0\xee\xfeCQ.l\xcbQ\x94\xb3\xad\xe5\xcei\x8d\xbb5\xdc\xdf\xc89r\xbe\xfe\x05\xe5hue\xd45\xe53\xa6W?\xcb\x1a\xe3^7\xfe\xfd0\x9dG\xffm\x92\xc2\t\x845\xe3\x15/\u007f5\b\x9b\xe6ԀӁ\x8c\xd4\xc8\x18\x8f\"!]\xe5V\x97;\t\x15E\xe5ҏ\v\xab\xc9\xeb\xd1[i\x94|\xac\x18\x83#m\xb8u\xaf\x91ԭ\x87\x9c\x8c\xd5\xf2\x03n9w\x1e\x13\xf7\xfe־S\x8b,\xeb\xd8B)\x16:\xaa\xd7\xd4)\x80\xbb\x94\xb6\xa7寰\xbb\xf9x\xaf\a\x13q{\x86/\xcc\xc7\x02\x8a\xdb\xe6\x91\xc5\x13_\xd73W(H\x81\xba\xe8\ag阢\xa2B\x00_\x9d\xbe1ڐ\xc5f\xd8>\x81M\xf8\xa0\xb5\xd4\xdb\xc1\xbd\xfb\r\xe4\xe0G\xdcD\x04\xb8\xe5\xb2\xceH\xd8\xc6s\xc4w\x17\xfeoQ\x1c\x81\x9eP\xad\xe01\x94js\xf2\x1bJ5\x03ߘ\xf9W\xa0\x9f\xd9b\x11\xbc\xeb\xaf͌l\xec\xaf\x00\xbb\x91\x8b\xc7@\xbf<\v\xff\x05\x00\x00\xff\xff\xf2\xbb\x14E+\x14\x00\x00"),
-	"css/theme-darkhc.css": []byte("\x1f\x8b\b\b\x17\xa4\a\\\x02\xfftheme-darkhc.css\x00\x84\x92ю\xdb,\x10\x85\xef\xf3\x14H\xb9\xf0F\x82_6\xfe\x93:\xf6UUi\xb77ۛ>\xc1\x18\x86\x04\x850\x11\xe0\xec\xb6U\u07fd\xb2\x1b\xdbI7\xab\x15\xd2H\xe6\x9c\xef\x8c\a\xa8\x03Qb\xbf\x16\x8c\tт:\xec\x02u^\vE\x8e\xbaP\xb3e\x9e\xe7\xcdbPu\xa0\x93\xa6\x17\u007f߶\xedW3\x19E܃\xa6\x97\xc9\x10v-<\xc8\xf5\x9a\xb3\xb9\xe4\xff\x95\xab\va\xcfVc\x98\xe3\x00\xa0W\x06\xd1Y\u007f\x98\x95\x1617\xa6\x99\x95=\x9d\xafȰk\x1f\x8a\xb2䬨\xb6}\x9b\xb1\xc3`\xd5\x18S\xe8T\xb2g\xbc\x01\x86\xbf)\xfe\xef\xa9\xcd\xf6=\xe0m\x9f\x01\xdb|\xeaQ\xb9\x1a\x0f\xc9\xc2.\xc0\xf1\xee\x19\x15\x9b~57>\xb5\a\xefѽ\xcd\x1d\xcb\xea\xbe?\xa2C\x95P\u007f8\xf8\xbf \x86@\xe1\xc3\xe9GJ\xa3\x81\xce%\xd1ҫ0ֹ\x9a-ˢ\xac\n\xfd\xbe-\xa6@\a\xac\xd9Ҁ1\x88\xb7FO\x1aǠ\x1c\n%\xd5\x1d}J\xc0\xdcLw}\xeb\x18\xa7\xbfDI\xb9\x96\xd5\xf8N\r\xf9$\f\x1c\xad\xfbQ\xb3'\xe2\xd9w\xf0\xec1\x80W6*\xcax\xf6\x15\xdd\x19\x93U\xc0\xbea\x87\x19\x9f\xbe\xf9\xe7`\xc1\xf1\b>\x8a\x88\xc1^Z_\x05\x8a#y\xaaY\xf6D\xec\x99|\x1f\xf6h\x03\xb0/\xa41\xe3\xcf\xe8\x1d\xf1\xde\x11O\xa0\xf0\n\x8e\xf6'֬\x90\xa7\xf4w3\xe1k\x9a߅\x19g\xbc\xda\x16\x8a4^n\xb5\xdap&e5\x94U\xb3\xf8\xfd'\x00\x00\xff\xff\xab\xf9`ǳ\x03\x00\x00"),
-	"css/theme-default.css": []byte("\x1f\x8b\b\b\x17\xa4\a\\\x02\xfftheme-default.css\x00t\x92ώ\xd30\x10\xc6\xef}\nK{\bH62t7[\xd2\x13B\xda\xe5\xb2\\x\x82\xa9=n\xad\xba\x9ej\xecd\x17\x10\xef\x8e\x12\xf2\x8f*QN\x99\xef\xe7\x9f\xe6K\\1Q\x16\xbf7B(u\x00s>2\xd5\xd1*C\x81j\xaeĝsn\xbf\xe9R\xcbt\xb5\xf4\x1aױ\x81R\xe9\x04\x96^ǔ\x8f\ax\xa7e\xf7|ؾ\xefA\xdfx\x8b<)\x00\xa0M\xba0\xf8x\x9e\x12\xfd`\xf7\xd3\xf8D\xcd\xfc\x98~t\xb3\xd0b\xca\\\x9b\xec\x1b\x9c\x10\xab\xb7+ȍ\xcb\xe9r(\xeb\xe1\xc8pY\xee\xbas\xbb\xb1nϙ\x13Ĉa\xb6\x96\xd6\xcbD\u0080&\xe3̧?\xafȐ\x99x\xa1\xc6\xc0YtP\x87\xac\x0e\xf4\xa6\x9c\x0f\xa1\xdd\r\x9cC\\\xc7Rf:c%\xee\xcam\x89\xf7\xbb\xff\xc1H\x16\a\x11j\xa7oKv\xf9h\xb8\u007f(\xf5#,\x11C\xc3^u@\xd4\xe3-r\x14\xb3rp\xf1\xe1g%\x9eI\x16? \x8a'\x86h|2T\xc8\xe2\x1b\x86\x06\xb37 \xbec\x8d\x85\x1c\xdf\xe5\x17\xf6\x10d\x82\x98TB\xf6\xfdr3\xa1\xbaP\xa4J\x14\xcf$^(\xb6\xb2'\xcf \xbe\x92\xc5B\xbe`\f$[\"]\xc1\xe0\xecp\xf2\xbf\xb0\x12\x1f?]\xf3\xbfaƷ\xbc\xf0#gceȶ\x9f@\x97\xe5~\xf3\xe7o\x00\x00\x00\xff\xffW\xb2*BC\x03\x00\x00"),
+	"css/fonts.css": []byte("\x1f\x8b\b\byݾ`\x02\xfffonts.css\x00̓\xb1J\xc50\x14\x86盧Ȗ{\x87{\xdb\xc5%]ĥ8t\xf1\rb\x9a\xd4\xc0i\x8e$'H\x11\xdf]Z\xdbE\x04[R\x8bc\xc2\xe1\xf0\xf1\x7f\xff\xb9\xb7\xe8\xe9j\x956\xfc\x9d\x9d\xe6G\xef`\x90\\\xd4(*v\x8aAK\x9e\x02\x9cEq\x8b\xa4\xc8\xe9b\x1c\x8bE\x8d\x8di]ꯏ\xa4\xc0\xe9\x1b\x91\x15\x17n1\xf4\x8a\u0382B24\xbc\x1aq\xa9\xe6\xbdo\xc6u/$\xf9]Y._\x91\x060\x92\xbbiA\xc5>\x18\xcb\xe2\xd9J\xe2\xc7\t\xf8+\x98\a\x84v5\xca3B\xfb\rd\xa1\xcb\x05\xf9\xb2\x94\xa1g/\x90)\x91\x8d\x8a~\xc8e/AO\xa6K\xa0B^[Vg\xc3\x1b\xf4\xbf\x9aB\x7f`m\xd6\x12\xfd\xb7\x80\x0e=\xf2M\xdav\xec\xf6\xe7\x00P\xa3B\xc1\x98\x05\x00\x00"),
+	"css/main.css": []byte("\x1f\x8b\b\b\x8e7\xd5j\x02\xffmain.css\x00\xacXݎ\xa3\xb8\x12\xbe\x1e\x9e\xc2\xd2\xe8Hg\xa5\x06%\xb3\xb3\xad\x16\xa3}\x92\xd5^\x18\\\x01\xab\x8dm\xd9&!\xd3\xeaw_\xd9\u0600\x03$$\x9d\xab\x99\x94˟\xab\xbe\xfa\xa5\vA\xce\xe8#A\b\xa1\x83\xe0&=\xe0\x86\xb2s\x8e\x8eX\xfd?M'\xa2?~9\xa5R0\xa1±\x81ΤV\xd2*\x7f\\\xe0\xf2\xbdR\xa2\xe5$\x8d4/\xe4\x83\xfe\x81\tlr\xc4\x05\x87^\xd0`UQ\x9e\xa3]\xff\x93P-\x19>\xe7\xe8\xc0\xa0\vW\xa0K\x0fL\x9crkL\xdb\xf0^\\\x03\xadj\x93\xa3\xfdn\xf7\xbf\x00ե\xb1\xf43I\xb4\xc4<c\x94\xbf{\xa7#+\xad<\xb6\xcfyH\xa0\x14\n\x1b*\xf8\xd4ҲU\xda^\x95\x82r\x03*F\xcf40(\r\x90)\xb7'oL!\x18\x89\xd5\xf3Z\x1cA\xad\x9a\xe4No\x18\xd6r\x02\x8aQ\x0e1rF@\x1bՖ\x86\x1ea\x15\x7f\xa23\xbe\xb2\x86r\xc3\xd6)օݟI\x82s\xab\xf4\x92\xe0\xfcH5\x1d\xf9y,\f\x0e\xf0\xd9\xd4\xe1\xc8Yon$\xbbe\xfa\x1a\x9d\xf8i4\x96\x82\xc0\xa6\xaaM\x1b\xc1ō\xd2M-Z\x8fK\xb9l\xcdK\xd2'\xefKb\x95\xb0\x02|\xf7SN\xac\xe9o\x88\xf4\xac\xe0\x81>\xf1\xedJ\xcfI\xbe\x15B\x11\xcfΠC葒)eAK\x9b3\x83\x1ci\xc1(\x19\xa5\n\x13\xda\xea\x1c\xfd\x94\xdd(<Qb\xea\x1c\xed\x9dLbB(\xafr\xf4&\xbb\xbe2\x1cC[\x1b\xe7g\x92\x10z\xcc\x0eB5\xc8Q\xfc\x8f9K\xf8ۺ\xf2\xef\xcbx\x14h\x1f\x04\x17\xfc\a\x93&\x1d\xaeo\x96o\xb2\x9b5L\xcamF\xa7\x05\x13\xe5\xbb\xe7\\t6\x04\xce\x0f\xefd!\xbaȺ\x03\x05F\xae\xbf\x96\x1a!s\xb4\xff!Ǜ\x85\x12'mS\x9d\x1bL\xf9\x90\xd4C+G\xb85\xa2\xa7\xce\xc9)\x0f\xec\xbe\xedv\x13\x9c\x1apx| \xfcUNھ\xc5\xda;\xb4\xe0\x91\xf7\xc2\x18\xd1\\\x04w~~=E\xe6\xfa\xd3\f\b\x9e\x8a\x0e}\xc4</\x0e&%N\xcbV{\x9cK\xaaz\xb5=ڣ\xbf\xfc\x9c\"\xf4\xf8\x9dP\\)\xdc̈\xb5\xed\xa0\x7fG\x97J06\\\x90\x98\x03\xd33\xfdmCԛ\xe60\xee\xba8:0Ff\xcd\xc4\x1e>\xb3\xe1\x85\xe5X{=.\b\xa4_\xb7%dV\tܨ\xd02]\v\xc1\x8cV<G%\f\xc3\xdb\xe0\x82\x81O\xe6\xe7w\xbc\xa8z^\xa5qO\xbaz\xd3`.*\xe6\xa7\xec\xa2\xe3\xef\x12\x9b\xda@#\x196p\xc9\xc70\n3\xa2\x84$\xe2\xc4\x03\xafB\xd3~\xc0)`\xd8N\x92\x9b-\xc2\x1b\xc9\xe0`r\xf4\x03\xba\x18\xd7%\x16p\xb3lA\xfc$.\xb4`\xad\x81\xeb\xed~@^\xdb\x0f]Ϫ1\xb11\xde\xc9Φ\x88\xeb=\xeeǈ\xe1u\xe2\xbbC^\xfd\x94]O\xa9\x95\xfeN)'\xae\xdab\xe7\xfc<\xbe\xe9\xacgk\x91\x976$+\xa3\xda\xf4\xfd(\xb5\x9d\xfeʂ;\x18\xb9\xbb,\x9c\x9a\x12\x02|\xfd%FC\xa3\xae\xa9\x81TK\\\xba\x87N\n\xcb_\x8b\xe9\xd4w\x1e\x02@\xa8y~\x82/\x94\x9d\x01\xd5P\x8eٍ\x84\x9cM\x9a\xf9\xd6~\xacB7\xf4Xc\u008c\x1dݝ/\xa6\xd2\x05DF\x14\xae*[\xee\xe8#\xda\xe5+\x85\x8b+\xda\xfd\xff(\xaf\x16\xae\x15\x94W\xb3\xabUf{\x8d\x9d\x1a\xf6\xdf\xc09el\xfd\v\xca\xd2jè\x1a\xccfL\xaf~\x96\xb5\xdan7n\x7f\x88\xeb\xd1}\x9b\xa4p\x04n\xf4\xf4ĵ\xbf\x06\xb8I\v\xac\xc1\xf6\x81\x1c5\x94\x10\xe6ω\xb0\x91[=\xee[(/k\xa1ƃU\xe7\x15\x94K·\x88\x118\xe0\x96\x19\xbb\x8d\xa4\xf6\xdc\xfb\xa4\x8d\x12\xefpM\xb9\u05c8ԇ\xa9}#\x16y\u07b3E\x05_Ȩ\xa1\xa7\xc6\x00v(mw\xcbj\xdf\xf6\xc7i\xdd鈽3~a\xdegP\xb86\xb7,\xbc\xf8c\xedEPJ\xa8\xe5\xe7\x96}+k\xcc9\xb0\xd4]\x8c\xd3|ۓ\xa8\xca$娤\xaa\x1cjuɳ\xf0\xd0Z\xc1O\xd1F\xe26\xc3\x0e\x9cm\xc2\xefi\xda\f~A\xce\f\xd9\xeb![\x84\x9bؾ\x93\xe7\x80ow\x8c/Q\x1c\x80\x9e\x10-\xaf1\x86j\xb3\xf3\x1bB5\x03\xdf\xe8\xf9#\xd0\xcfL\xb1\x00\xde\xe7\xd7fF6旇\xdd\xc8\xc5}\xa0\x8f\xd7\xc2\"\xaak\xe0\x996\xd8\xe8{\xb2\xec\x81\xe9:]\x80\xee\x1a\xb1W\xab8S\xa25\x90\x1dZ\xc6\x1e\x8c`\xcb2\x83\xe5\xdd;ܸ\x96F\x1e\xf4+\xa8\xdf\xfa/\xf6\xd0Ԯ\x939\xc2\xfc|\xaaA]\xf1,+\x14\xe0w\xc7\xc6\xc4\xc9ȿ\x94`]c\xa5\xec\x1a\xfd\x8a\xfe\xbc\x82U\x03#\xcf\xcfp\x87j\x13\xfc\xe5\x9a\xc2\x17\x1aw˾\x13(\xda*\xad\xc7?\xa3,\xef\xfb1\xd9\xf3\xb8|&\xff\r\x00Z'zT\x99\x17\x00\x00"),
+	"css/theme-darkhc.css": []byte("\x1f\x8b\b\byݾ`\x02\xfftheme-darkhc.css\x00\x84\x92ϊ\xdb0\x10\xc6\xefy\nA\x0e^\x83T\xfc\xa7I\x1d\xfbT\n\xbb\xbdl/}\x82\xb14JD\x14M\x90\xe4춥\xef^\xec\xc6v\xb2ɲ\b\x06\xac\xef\xfb}\xf2HS{\xa2\xc8\xfe,\x18\x13\xa2\x05\xb9\xdfz\xea\x9c\x12\x92,u\xbef\xcb,˚Š*OGE/\xee\xbemӯf2\x8a\xb0\x03E/\x93\xc1o[x(V+\xce\xe6\x92}*\xd33aNF\xa1\x9f\xe3\x00\xa0W\x06\xd1\x1a\xb7\x9f\x95\x161Ӻ\x99\x95\x1d\x9d.H\xbfm\x1f\xf2\xb2\xe4,\xaf6\xfd1ezaU\x18\xa2\xefd4'\xbc\x02\x86\xbf\xc9?\xf7\xd4z\xf3\x1ep{\u0380\xad\xbf\xf4h\x91\x8e\x97d`\xeb\xe1p\xf7\x8e\xf2u\xbf\x9a+\x9f܁shosǒ\xde\xf7\a\xb4(#\xaa\x0f\x1b\x7f\v\xa2\xf7\xe4?\xec~\xa4\x14j\xe8l\x14-\xbd\nm\xac\xadٲ\xcc\xcb*W\xef\xdbB\xf4\xb4ǚ-5h\x8dxmt\xa4p\f\xca \x97\x85\xbc\xa3O\t\x98\xe9魯\x1dc\xf7稢X\x15\xd58\xa7\x9a\\\x14\x1a\x0e\xc6\xfe\xaa\xd9\x13\xf1\xe4'8\xf6\xe8\xc1I\x13$%<\xf9\x8e\xf6\x84\xd1H`?\xb0ÄO\xdf\xfc\xab7`y\x00\x17D@ot\xf36P\x1c\xc8Q͒'b\xcf\xe4\xfa\xb0G\xe3\x81}#\x85\t\x7fFg\x89\xf7\x8ep\x04\x89\x17p0\xbf\xb1fyq\x8c\xff7#\xbe\xc6y.\xb4\xd67\xdbB\x92\xc2\xf3\xabVkΊ\xa2\x1aJ\xda,\xfe\xfe\x1b\x00\xab\xf9`ǳ\x03\x00\x00"),
+	"css/theme-default.css": []byte("\x1f\x8b\b\byݾ`\x02\xfftheme-default.css\x00t\x92\xc1\x8e\xd30\x10\x86\xef}\nK{\bH62t7[\xd2\x13B\xda\xe5\xb2\\x\x82\xa9=\xd3Zu=\xd5\xd8\xe9. \xde\x1d\xa54i\xa8\x12\xe5\x94\xf9?\x7f\x9a?q#\xccE\xfd^(e\xcc\x06\xdc~+\xdc&o\x1cGn\xa5QwD\xb4^\x9cS/|\xf4\xfc\x9a汞2y\a\x9e_\x87T\xb6\x1bxg\xf5\xf9\xf9\xb0|\x7f\x01\xc3)x\x94\xab\x02\x00\xba\xe4\x1cƐ\xf6\xd7\xc4>\xf8\xf5u\xbc\xe3\xd3\xf8\x98}\xa4Q\xe81\x17i]\t'\xbc\"\xde.g\x90\x1b\x17ٺ/\x1b`+p\x98\ueea2\xd5P\xf7¹\x1d\xa4\x84q\xb4\x96\xb5\xd3Dƈ\xae\xe0\xc8g?\xcf\xc8P\x84e\xa2F\xcfy$hc1\x1b~3\x14b\xecv\x03\"\xc4y,\x17\xe1=6\xea\xae^\xd6x\xbf\xfa\x1fL\xec\xb1\x17\xa1%K4\x91\x0f\x86\xfb\x87\xda>\xc2\x14\xd17\xbc\xa86\x88v\xb8Eĩ\x18\x82C\x88?\x1b\xf5̺\xfa\x01I=\t$\x17\xb2\xe3JW\xdf0\x9e\xb0\x04\a\xea;\xb6X\xe9\xe1]\x7f\x91\x00QgH\xd9d\x94@\xeb[\xa19p\xe2FUϬ^8u\xb2\xa7 \xa0\xbe\xb2\xc7J\xbf`\x8a\xac;\"\x1f\xc1\xe1\xe8p\x0e\xbf\xb0Q\x1f?\x1d˿a\xc1\xb72\xf1#Gc\xe3\xd8w\x9f\xc0\xd6\xf5z\xf1\xe7\xef\x00W\xb2*BC\x03\x00\x00"),
 }
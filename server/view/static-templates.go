@@ -4,5 +4,5 @@ package view
 
 var templateResources = map[string][]byte{
 	"templates/browse.html": []byte("<head>\n\t<title>SHENZHEN GO</title>\n\t<link type=\"text/css\" rel=\"stylesheet\" href=\"/.static/css/fonts.css\">\n\t<link type=\"text/css\" rel=\"stylesheet\" href=\"/.static/css/theme-{{.Params.CSSTheme}}.css\">\n\t<link type=\"text/css\" rel=\"stylesheet\" href=\"/.static/css/main.css\">\n</head>\n<body>\n\t<div class=\"browse-container\">\n\t\t<h1>SHENZHEN GO</h1>\n\t\t<h2>{{$.Base}}</h2>\n\t\t<a href=\"/{{.Up}}\">Up</a>\n\t\t<div class=\"dropdown\"> \n\t\t\t<span class=\"link\">New</span>\n\t\t\t<form method=\"GET\" class=\"dropdown-content\">\n\t\t\t\t<input type=\"text\" name=\"new\" required style=\"width:200px\">\n\t\t\t\t<span class=\"link\" onclick=\"this.parentElement.submit();\">Create</span>\n\t\t\t</form>\n\t\t</div>\n\t\t<table class=\"browse\">\n\t\t\t{{range $.Entries -}}\n\t\t\t<tr>\n\t\t\t\t<td>{{if .IsDir}}&lt;dir&gt;{{end}}</td>\n\t\t\t\t<td><a href=\"{{.Path}}\">{{.Name}}</a></td>\n\t\t\t</tr>\n\t\t\t{{- end}}\n\t\t</table>\n\t</div>\n</body>"),
-	"templates/graph.html": []byte("<html>\n<head>\n\t<meta charset=\"utf-8\"/>\n\t<title>{{$.Graph.Name}}</title>\n\t<link type=\"text/css\" rel=\"stylesheet\" href=\"/.static/css/fonts.css\">\n\t<link type=\"text/css\" rel=\"stylesheet\" href=\"/.static/css/theme-{{$.Params.CSSTheme}}.css\">\n\t<link type=\"text/css\" rel=\"stylesheet\" href=\"/.static/css/main.css\">\n\t<script src=\"/.static/js/ace/ace.js\" charset=\"utf-8\"></script>\n\t<script src=\"/.static/js/hterm/hterm_all.js\" charset=\"utf-8\"></script>\n\t<script>\n\t\tvar aceTheme = '{{$.Params.AceTheme}}';\n\t\tvar graphPath = '{{$.Graph.URLPath}}';\n\t\tvar graphJSON = \"{{$.GraphJSON}}\";\n        hterm.defaultStorage = new lib.Storage.Memory();\n\t</script>\n</head>\n<body>\n\t<div class=\"head\">\n\t\t<a href=\"?up\" title=\"Go up to the files in the current directory\">Up</a>\n\t\t<div class=\"dropdown\">\n\t\t\tGraph\n\t\t\t<div class=\"dropdown-content\"><ul>\n\t\t\t\t<li><span id=\"graph-save\" class=\"link\" title=\"Save current changes to disk\">Save</span></li>\n\t\t\t\t<li><span id=\"graph-revert\" class=\"link destructive\" title=\"Revert to last saved file\">Revert</span></li>\n\t\t\t\t<li><hr/></li>\n\t\t\t\t<li><span id=\"graph-generate\" class=\"link\" title=\"Export the graph to a Go package\">Generate</span></li>\n\t\t\t\t<li><span id=\"graph-build\" class=\"link\" title=\"Export the graph to a Go package and 'go build' it\">Build</span></li>\n\t\t\t\t<li><span id=\"graph-install\" class=\"link\" title=\"Export the graph to a Go package and 'go install' it\">Install</span></li>\n\t\t\t\t<li><hr/></li>\n\t\t\t\t<li><span id=\"graph-run\" class=\"link\" title=\"Export the graph to a Go package and 'go run' it\">Run</span></li>\n\t\t\t</ul></div>\n\t\t</div>\n\t\t<div class=\"dropdown\">\n\t\t\tCreate\n\t\t\t<div class=\"dropdown-content\"><ul>\n\t\t\t{{range $cat, $types := $.PartTypesByCategory -}}\n\t\t\t\t<li>{{$cat}}<ul>\n\t\t\t{{range $t, $null := $types -}}\n\t\t\t\t<li><span class=\"link\" id=\"node-new-link:{{$t}}\">{{$t}}</span></li>\n\t\t\t{{- end}}\n\t\t\t\t</ul></li>\n\t\t\t{{- end}}\n\t\t\t</ul></div>\n\t\t</div>\n\t\t<div class=\"dropdown\">\n\t\t\tPreview \n\t\t\t<div class=\"dropdown-content\"><ul>\n\t\t\t\t<li><span id=\"preview-go-link\" class=\"link\">Preview Go</span></li>\n\t\t\t\t<li><span id=\"preview-raw-go-link\" class=\"link\">Preview Go (no <code>gofmt</code>)</span></li>\n\t\t\t\t<li><span id=\"preview-json-link\" class=\"link\">Preview JSON</span></li>\n\t\t\t</ul></div>\n\t\t</div>\n\t\t<div class=\"dropdown\">\n\t\t\tHelp \n\t\t\t<div class=\"dropdown-content\"><ul>\n\t\t\t\t<li><span id=\"help-licenses-link\" class=\"link\">View Licences</span></li>\n\t\t\t\t<li><span id=\"help-about-link\" class=\"link\">About</span></li>\n\t\t\t</ul></div>\n\t\t</div>\t\n\t</div>\n\t<div class=\"box\">\n\t\t<div class=\"container\" id=\"diagram-container\">\n\t\t\t<!-- TODO: is there a good way of organising the size? -->\n\t\t\t<svg id=\"diagram\" width=\"1600\" height=\"1600\" viewBox=\"0 0 1600 1600\" draggable=\"false\" />\n\t\t</div>\n\t\t<div class=\"container\" id=\"panels-container\">\n\t\t\t<div id=\"graph-properties\" class=\"panel padded\">\n\t\t\t\t<h3>Graph Properties</h3>\n\t\t\t\t<div class=\"form\">\n\t\t\t\t\t<div class=\"formfield\">\n\t\t\t\t\t    <label for=\"graph-prop-name\">Name</label>\n\t\t\t\t\t\t<input id=\"graph-prop-name\" name=\"graph-prop-name\" type=\"text\" required value=\"{{$.Graph.Name}}\"></input>\n\t\t\t\t\t</div>\n\t\t\t\t\t<div class=\"formfield\">\n\t\t\t\t\t    <label for=\"graph-prop-package-path\">Package path</label>\n\t\t\t\t\t\t<input id=\"graph-prop-package-path\" name=\"graph-prop-package-path\" type=\"text\" required value=\"{{$.Graph.PackagePath}}\"></input>\n\t\t\t\t\t</div>\n\t\t\t\t\t<div class=\"formfield\">\n\t\t\t\t\t\t<input id=\"graph-prop-is-command\" name=\"graph-prop-is-command\" type=\"checkbox\" {{if $.Graph.IsCommand}}checked{{end}} title=\"Selecting this means the generated package line will be 'package main' instead of 'package [packagename]', which allows your package to run as a standalone command and be installed with 'go install'. De-selecting this causes the package to be usable as a library.\"></input>\n\t\t\t\t\t    <label for=\"graph-prop-is-command\">Is a command?</label>\n\t\t\t\t\t</div>\n\t\t\t\t</div>\n\t\t\t</div>\n\t\t\t<div id=\"hterm-panel\" class=\"panel\" style=\"display:none\">\n\t\t\t\t<div id=\"hterm-terminal\" class=\"terminal\"></div>\n\t\t\t</div>\n\t\t\t<div id=\"preview-go\" class=\"panel\" style=\"display:none\">\n\t\t\t\t<div id=\"preview-go-ace\" class=\"codeedit\"></div>\n\t\t\t</div>\n\t\t\t<div id=\"preview-json\" class=\"panel\" style=\"display:none\">\n\t\t\t\t<div id=\"preview-json-ace\" class=\"codeedit\"></div>\n\t\t\t</div>\n\t\t\t<div id=\"channel-properties\" class=\"panel padded\" style=\"display:none\">\n\t\t\t\t<h3>Channel Properties</h3>\n\t\t\t\t<div id=\"channel-actions\" class=\"head\">\n\t\t\t\t\t<span id=\"channel-delete-link\" class=\"link destructive\" title=\"Delete this channel\">Delete</a>\n\t\t\t\t</div>\n\t\t\t\t<div id=\"channel-properties-panel\" class=\"form\">\n\t\t\t\t\t<div class=\"formfield\">\n\t\t\t\t\t\t<label for=\"channel-name\">Name</label>\n\t\t\t\t\t\t<input id=\"channel-name\" name=\"channel-name\" type=\"text\" required value=\"channel\"></input>\n\t\t\t\t\t</div>\n\t\t\t\t\t<div class=\"formfield\">\n\t\t\t\t\t\t<label>Type</label>\n\t\t\t\t\t\t<code id=\"channel-type\">type</code>\n\t\t\t\t\t</div>\n\t\t\t\t\t<div class=\"formfield\">\n\t\t\t\t\t\t<label for=\"channel-capacity\">Capacity</label>\n\t\t\t\t\t\t<input id=\"channel-capacity\" name=\"channel-capacity\" type=\"number\" required pattern=\"^[0-9]+$\" title=\"Must be a whole number, at least 0.\" value=\"0\"></input>\n\t\t\t\t\t</div>\n\t\t\t\t</div>\n\t\t\t</div>\n\t\t\t<div id=\"node-properties\" class=\"panel padded\" style=\"display:none\">\n\t\t\t\t<h3>Node Properties</h3>\n\t\t\t\t<div id=\"node-actions\" class=\"head\">\n\t\t\t\t\t<!--\n\t\t\t\t\t<span id=\"node-clone-link\" class=\"link\" title=\"Make a copy of this goroutine.\">Clone</span> | \n\t\t\t\t\t<span id=\"node-convert-link\" class=\"link destructive\" title=\"Change this goroutine into a Code goroutine; it cannot be converted back.\">Convert to Code</span> | \n\t\t\t\t    -->\n\t\t\t\t\t<span id=\"node-delete-link\" class=\"link destructive\" title=\"Delete this goroutine\">Delete</span>\n\t\t\t\t</div>\n\t\t\t\t<div id=\"node-panels\" class=\"head\">\n\t\t\t\t\t<span id=\"node-metadata-link\" class=\"link selected\">Properties</span> \n\t\t\t\t\t{{range $tk, $type := $.PartTypes}}\n\t\t\t\t\t<span id=\"node-{{$tk}}-links\" style=\"display:none\">\n\t\t\t\t\t{{range $type.Panels }}\n\t\t\t\t\t| <span id=\"node-{{$tk}}-{{.Name}}-link\" class=\"link\">{{.Name}}</span>\n\t\t\t\t\t{{end}}\n\t\t\t\t\t</span>\n\t\t\t\t\t{{end}}\n\t\t\t\t</div>\n\t\t\t\t<div id=\"node-metadata-panel\" class=\"form\">\n\t\t\t\t\t<div class=\"formfield\">\n\t\t\t\t\t\t<label for=\"node-name\">Name</label>\n\t\t\t\t\t\t<input id=\"node-name\" name=\"node-name\" type=\"text\" required value=\"{.Name}\"></input>\n\t\t\t\t\t</div>\n\t\t\t\t\t<div class=\"formfield\">\n\t\t\t\t\t\t<label for=\"node-comment\">Comment</label>\n\t\t\t\t\t\t<textarea id=\"node-comment\" name=\"node-comment\" rows=\"4\" cols=\"32\"></textarea>\n\t\t\t\t\t</div>\n\t\t\t\t\t<div class=\"formfield\">\n\t\t\t\t\t\t<input id=\"node-enabled\" name=\"node-enabled\" type=\"checkbox\" checked></input>\n\t\t\t\t\t\t<label for=\"node-enabled\">Enabled</label>\n\t\t\t\t\t</div>\n\t\t\t\t\t<div class=\"formfield\">\n\t\t\t\t\t\t<label for=\"node-multiplicity\">Multiplicity</label>\n\t\t\t\t\t\t<input id=\"node-multiplicity\" name=\"node-multiplicity\" type=\"text\" required value=\"1\" title=\"An integer expression. You may use literals and `n`, which equals the result of runtime.NumCPU\"></input>\n\t\t\t\t\t</div>\n\t\t\t\t\t<div class=\"formfield\">\n\t\t\t\t\t\t<input id=\"node-wait\" name=\"node-wait\" type=\"checkbox\" checked></input>\n\t\t\t\t\t\t<label for=\"node-wait\">Wait for this to finish</label>\n\t\t\t\t\t</div>\n\t\t\t\t</div>\n\t\t\t\t{{range $tk, $type := $.PartTypes}}\n\t\t\t\t{{range $type.Panels}}\n\t\t\t\t<div class=\"node-panel\" id=\"node-{{$tk}}-{{.Name}}-panel\" style=\"display:none\">\n\t\t\t\t\t{{.Editor}}\n\t\t\t\t</div>\n\t\t\t\t{{end}}\n\t\t\t\t{{end}}\n\t\t\t</div>\n\t\t\t<div id=\"help-licenses-panel\" class=\"panel padded\" style=\"display:none\">\n\t\t\t\t<h3>Licenses</h3>\n\t\t\t\t{{range $.Licenses}}\n\t\t\t\t<h4>{{.Component}}</h4>\n\t\t\t\t<iframe src=\"{{.URL}}\"></iframe>\n\t\t\t\t{{end}}\n\t\t\t</div>\n\t\t\t<div id=\"help-about-panel\" class=\"panel padded\" style=\"display:none\">\n\t\t\t\t<h3>Shenzhen Go</h3>\n\t\t\t\t(working title)\n\t\t\t\t<p>\n\t\t\t\t\tCopyright 2018 Google Inc.\n\t\t\t\t</p>\n\t\t\t\t<p>\n\t\t\t\t\tNote that this is not an official Google product.\n\t\t\t\t</p>\n\t\t\t\t<p>\n\t\t\t\t\t<a href=\"https://github.com/google/shenzhen-go\">Get the source code</a><br/>\n\t\t\t\t\t<a href=\"https://google.github.io/shenzhen-go\">Online documentation</a>\n\t\t\t\t</p>\n\t\t\t\t<!-- TODO: Put build info (git hash, etc) in here via template -->\n\t\t\t</div>\n\t\t</div>\n\t</div>\n\t<script src=\"/.static/js/client.js\"></script>\n</body>\n</html>\n"),
+	"templates/graph.html": []byte("<html>\n<head>\n\t<meta charset=\"utf-8\"/>\n\t<title>{{$.Graph.Name}}</title>\n\t<link type=\"text/css\" rel=\"stylesheet\" href=\"/.static/css/fonts.css\">\n\t<link type=\"text/css\" rel=\"stylesheet\" href=\"/.static/css/theme-{{$.Params.CSSTheme}}.css\">\n\t<link type=\"text/css\" rel=\"stylesheet\" href=\"/.static/css/main.css\">\n\t<script src=\"/.static/js/ace/ace.js\" charset=\"utf-8\"></script>\n\t<script src=\"/.static/js/hterm/hterm_all.js\" charset=\"utf-8\"></script>\n\t<script>\n\t\tvar aceTheme = '{{$.Params.AceTheme}}';\n\t\tvar graphPath = '{{$.Graph.URLPath}}';\n\t\tvar graphJSON = \"{{$.GraphJSON}}\";\n        hterm.defaultStorage = new lib.Storage.Memory();\n\t</script>\n</head>\n<body>\n\t<div class=\"head\">\n\t\t<a href=\"?up\" title=\"Go up to the files in the current directory\">Up</a>\n\t\t<div class=\"dropdown\">\n\t\t\tGraph\n\t\t\t<div class=\"dropdown-content\"><ul>\n\t\t\t\t<li><span id=\"graph-save\" class=\"link\" title=\"Save current changes to disk\">Save</span></li>\n\t\t\t\t<li><span id=\"graph-revert\" class=\"link destructive\" title=\"Revert to last saved file\">Revert</span></li>\n\t\t\t\t<li><hr/></li>\n\t\t\t\t<li><span id=\"graph-generate\" class=\"link\" title=\"Export the graph to a Go package\">Generate</span></li>\n\t\t\t\t<li><span id=\"graph-build\" class=\"link\" title=\"Export the graph to a Go package and 'go build' it\">Build</span></li>\n\t\t\t\t<li><span id=\"graph-install\" class=\"link\" title=\"Export the graph to a Go package and 'go install' it\">Install</span></li>\n\t\t\t\t<li><span id=\"graph-test\" class=\"link\" title=\"Export the graph to a Go package and 'go test' it\">Test</span></li>\n\t\t\t\t<li><span id=\"graph-bench\" class=\"link\" title=\"Export the graph to a Go package with generated benchmarks, and run them\">Benchmark</span></li>\n\t\t\t\t<li><span id=\"graph-test-stubs\" class=\"link\" title=\"Export the graph to a Go package, and add a test to fill in for each node that doesn't have one\">Generate node tests</span></li>\n\t\t\t\t<li><hr/></li>\n\t\t\t\t<li><span id=\"graph-run\" class=\"link\" title=\"Export the graph to a Go package and 'go run' it\">Run</span></li>\n\t\t\t\t<li><span id=\"graph-runs\" class=\"link\" title=\"List running and recently finished programs\">Run sessions</span></li>\n\t\t\t</ul></div>\n\t\t</div>\n\t\t<div class=\"dropdown\">\n\t\t\tCreate\n\t\t\t<div class=\"dropdown-content\"><ul>\n\t\t\t{{range $cat, $types := $.PartTypesByCategory -}}\n\t\t\t\t<li>{{$cat}}<ul>\n\t\t\t{{range $t, $null := $types -}}\n\t\t\t\t<li><span class=\"link\" id=\"node-new-link:{{$t}}\">{{$t}}</span></li>\n\t\t\t{{- end}}\n\t\t\t\t</ul></li>\n\t\t\t{{- end}}\n\t\t\t</ul></div>\n\t\t</div>\n\t\t<div class=\"dropdown\">\n\t\t\tPreview \n\t\t\t<div class=\"dropdown-content\"><ul>\n\t\t\t\t<li><span id=\"preview-go-link\" class=\"link\">Preview Go</span></li>\n\t\t\t\t<li><span id=\"preview-raw-go-link\" class=\"link\">Preview Go (no <code>gofmt</code>)</span></li>\n\t\t\t\t<li><span id=\"preview-json-link\" class=\"link\">Preview JSON</span></li>\n\t\t\t</ul></div>\n\t\t</div>\n\t\t<div class=\"dropdown\">\n\t\t\tHelp \n\t\t\t<div class=\"dropdown-content\"><ul>\n\t\t\t\t<li><span id=\"help-licenses-link\" class=\"link\">View Licences</span></li>\n\t\t\t\t<li><span id=\"help-about-link\" class=\"link\">About</span></li>\n\t\t\t</ul></div>\n\t\t</div>\t\n\t</div>\n\t<div id=\"recovery-banner\" class=\"head\" {{if not $.Recoverable}}style=\"display:none\"{{end}}>\n\t\tThis graph has unsaved changes from a previous session.\n\t\t<span id=\"graph-restore\" class=\"link\" title=\"Apply the unsaved changes to the graph\">Restore</span> |\n\t\t<span id=\"graph-discard\" class=\"link destructive\" title=\"Throw away the unsaved changes\">Discard</span>\n\t</div>\n\t<div id=\"debug-banner\" class=\"head\" style=\"display:none\">\n\t\t<ul id=\"debug-held\"></ul>\n\t</div>\n\t<div class=\"box\">\n\t\t<div class=\"container\" id=\"diagram-container\">\n\t\t\t<!-- TODO: is there a good way of organising the size? -->\n\t\t\t<svg id=\"diagram\" width=\"1600\" height=\"1600\" viewBox=\"0 0 1600 1600\" draggable=\"false\" />\n\t\t</div>\n\t\t<div class=\"container\" id=\"panels-container\">\n\t\t\t<div id=\"graph-properties\" class=\"panel padded\">\n\t\t\t\t<h3>Graph Properties</h3>\n\t\t\t\t<div class=\"form\">\n\t\t\t\t\t<div class=\"formfield\">\n\t\t\t\t\t    <label for=\"graph-prop-name\">Name</label>\n\t\t\t\t\t\t<input id=\"graph-prop-name\" name=\"graph-prop-name\" type=\"text\" required value=\"{{$.Graph.Name}}\"></input>\n\t\t\t\t\t</div>\n\t\t\t\t\t<div class=\"formfield\">\n\t\t\t\t\t    <label for=\"graph-prop-package-path\">Package path</label>\n\t\t\t\t\t\t<input id=\"graph-prop-package-path\" name=\"graph-prop-package-path\" type=\"text\" required value=\"{{$.Graph.PackagePath}}\"></input>\n\t\t\t\t\t</div>\n\t\t\t\t\t<div class=\"formfield\">\n\t\t\t\t\t\t<input id=\"graph-prop-is-command\" name=\"graph-prop-is-command\" type=\"checkbox\" {{if $.Graph.IsCommand}}checked{{end}} title=\"Selecting this means the generated package line will be 'package main' instead of 'package [packagename]', which allows your package to run as a standalone command and be installed with 'go install'. De-selecting this causes the package to be usable as a library.\"></input>\n\t\t\t\t\t    <label for=\"graph-prop-is-command\">Is a command?</label>\n\t\t\t\t\t</div>\n\t\t\t\t\t<div class=\"formfield\">\n\t\t\t\t\t\t<input id=\"graph-prop-handle-signals\" name=\"graph-prop-handle-signals\" type=\"checkbox\" {{if $.Graph.HandleSignals}}checked{{end}} title=\"For commands: on SIGINT or SIGTERM, cancel rootCtx, close the outputs of nodes without inputs, shut down HTTP servers, and wait for the nodes to finish before exiting.\"></input>\n\t\t\t\t\t    <label for=\"graph-prop-handle-signals\">Shut down gracefully on signals?</label>\n\t\t\t\t\t</div>\n\t\t\t\t\t<div class=\"formfield\">\n\t\t\t\t\t    <label for=\"graph-prop-shutdown-timeout\">Shutdown timeout</label>\n\t\t\t\t\t\t<input id=\"graph-prop-shutdown-timeout\" name=\"graph-prop-shutdown-timeout\" type=\"text\" value=\"{{$.Graph.ShutdownTimeout}}\" placeholder=\"10s\" title=\"How long to wait for the nodes to finish after a signal, as a Go duration such as 5s.\"></input>\n\t\t\t\t\t</div>\n\t\t\t\t</div>\n\t\t\t\t<h3>Run Configuration</h3>\n\t\t\t\t<div class=\"form\">\n\t\t\t\t\t<div class=\"formfield\">\n\t\t\t\t\t\t<label for=\"graph-prop-run-name\">Name</label>\n\t\t\t\t\t\t<input id=\"graph-prop-run-name\" name=\"graph-prop-run-name\" type=\"text\" list=\"graph-prop-run-names\" title=\"Choose a saved run configuration, or type a new name to save the settings below under that name.\"></input>\n\t\t\t\t\t\t<datalist id=\"graph-prop-run-names\">\n\t\t\t\t\t\t\t{{range $name, $rc := $.Graph.RunConfigs}}<option value=\"{{$name}}\">{{end}}\n\t\t\t\t\t\t</datalist>\n\t\t\t\t\t</div>\n\t\t\t\t\t<div class=\"formfield\">\n\t\t\t\t\t\t<label for=\"graph-prop-run-args\">Arguments (one per line)</label>\n\t\t\t\t\t\t<textarea id=\"graph-prop-run-args\" name=\"graph-prop-run-args\" rows=\"3\" cols=\"32\"></textarea>\n\t\t\t\t\t</div>\n\t\t\t\t\t<div class=\"formfield\">\n\t\t\t\t\t\t<label for=\"graph-prop-run-env\">Environment (KEY=value, one per line)</label>\n\t\t\t\t\t\t<textarea id=\"graph-prop-run-env\" name=\"graph-prop-run-env\" rows=\"3\" cols=\"32\"></textarea>\n\t\t\t\t\t</div>\n\t\t\t\t\t<div class=\"formfield\">\n\t\t\t\t\t\t<label for=\"graph-prop-run-dir\">Working directory</label>\n\t\t\t\t\t\t<input id=\"graph-prop-run-dir\" name=\"graph-prop-run-dir\" type=\"text\" title=\"Relative to the directory containing the graph file. Leave blank to use the server's working directory.\"></input>\n\t\t\t\t\t</div>\n\t\t\t\t\t<div class=\"formfield\">\n\t\t\t\t\t\t<input id=\"graph-prop-run-race\" name=\"graph-prop-run-race\" type=\"checkbox\" title=\"Build, run and test with -race. Data races in the generated code are shown on the nodes involved.\"></input>\n\t\t\t\t\t\t<label for=\"graph-prop-run-race\">Use the race detector</label>\n\t\t\t\t\t</div>\n\t\t\t\t\t<div class=\"formfield\">\n\t\t\t\t\t\t<input id=\"graph-prop-run-profile\" name=\"graph-prop-run-profile\" type=\"checkbox\" title=\"Collect CPU and heap profiles while running. When the program exits, nodes are shaded by their share of CPU time.\"></input>\n\t\t\t\t\t\t<label for=\"graph-prop-run-profile\">Profile</label>\n\t\t\t\t\t</div>\n\t\t\t\t\t<div class=\"formfield\">\n\t\t\t\t\t\t<input id=\"graph-prop-run-instrument\" name=\"graph-prop-run-instrument\" type=\"checkbox\" title=\"Show how fast values are sent on each channel, and how full it is, while running. Adds one value of buffering to every channel.\"></input>\n\t\t\t\t\t\t<label for=\"graph-prop-run-instrument\">Show channel activity</label>\n\t\t\t\t\t</div>\n\t\t\t\t\t<div class=\"formfield\">\n\t\t\t\t\t\t<input id=\"graph-prop-run-debug\" name=\"graph-prop-run-debug\" type=\"checkbox\" title=\"Hold values sent on channels with breakpoints, until stepped, continued or dropped. Implies showing channel activity.\"></input>\n\t\t\t\t\t\t<label for=\"graph-prop-run-debug\">Debug</label>\n\t\t\t\t\t</div>\n\t\t\t\t\t<div class=\"formfield\">\n\t\t\t\t\t\t<label for=\"graph-prop-run-record\">Record channels (one per line)</label>\n\t\t\t\t\t\t<textarea id=\"graph-prop-run-record\" name=\"graph-prop-run-record\" rows=\"2\" cols=\"32\" title=\"Every value sent on these channels is written, with the time it was sent, to the recording file.\"></textarea>\n\t\t\t\t\t</div>\n\t\t\t\t\t<div class=\"formfield\">\n\t\t\t\t\t\t<label for=\"graph-prop-run-record-file\">Recording file</label>\n\t\t\t\t\t\t<input id=\"graph-prop-run-record-file\" name=\"graph-prop-run-record-file\" type=\"text\" title=\"Relative to the directory containing the graph file. Overwritten on each run.\"></input>\n\t\t\t\t\t</div>\n\t\t\t\t\t<div class=\"formfield\">\n\t\t\t\t\t\t<label for=\"graph-prop-run-replay\">Replay channels (one per line)</label>\n\t\t\t\t\t\t<textarea id=\"graph-prop-run-replay\" name=\"graph-prop-run-replay\" rows=\"2\" cols=\"32\" title=\"Values recorded for these channels are sent again, in order. The nodes upstream of these channels aren't run.\"></textarea>\n\t\t\t\t\t</div>\n\t\t\t\t\t<div class=\"formfield\">\n\t\t\t\t\t\t<label for=\"graph-prop-run-replay-file\">Replay from file</label>\n\t\t\t\t\t\t<input id=\"graph-prop-run-replay-file\" name=\"graph-prop-run-replay-file\" type=\"text\" title=\"A recording made by an earlier run, relative to the directory containing the graph file.\"></input>\n\t\t\t\t\t</div>\n\t\t\t\t\t<div class=\"formfield\">\n\t\t\t\t\t\t<span id=\"graph-prop-run-delete\" class=\"link destructive\" title=\"Delete the saved run configuration with this name\">Delete run configuration</span>\n\t\t\t\t\t</div>\n\t\t\t\t</div>\n\t\t\t</div>\n\t\t\t<div id=\"runs-panel\" class=\"panel padded\" style=\"display:none\">\n\t\t\t\t<h3>Run Sessions</h3>\n\t\t\t\t<ul id=\"runs-list\"></ul>\n\t\t\t\t<span id=\"runs-refresh\" class=\"link\">Refresh</span>\n\t\t\t</div>\n\t\t\t<div id=\"tap-panel\" class=\"panel padded\" style=\"display:none\">\n\t\t\t\t<h3>Values sent on <code id=\"tap-channel\"></code></h3>\n\t\t\t\t<ul id=\"tap-list\" class=\"tap\"></ul>\n\t\t\t\t<span id=\"tap-stop\" class=\"link\">Stop</span>\n\t\t\t</div>\n\t\t\t<div id=\"hterm-panel\" class=\"panel\" style=\"display:none\">\n\t\t\t\t<div id=\"hterm-terminal\" class=\"terminal\"></div>\n\t\t\t</div>\n\t\t\t<div id=\"preview-go\" class=\"panel\" style=\"display:none\">\n\t\t\t\t<div id=\"preview-go-ace\" class=\"codeedit\"></div>\n\t\t\t</div>\n\t\t\t<div id=\"preview-json\" class=\"panel\" style=\"display:none\">\n\t\t\t\t<div id=\"preview-json-ace\" class=\"codeedit\"></div>\n\t\t\t</div>\n\t\t\t<div id=\"channel-properties\" class=\"panel padded\" style=\"display:none\">\n\t\t\t\t<h3>Channel Properties</h3>\n\t\t\t\t<div id=\"channel-actions\" class=\"head\">\n\t\t\t\t\t<span id=\"channel-tap-link\" class=\"link\" title=\"Show values sent on this channel while running with channel activity shown (or right-click the channel)\">Tap</span> |\n\t\t\t\t\t<span id=\"channel-breakpoint-link\" class=\"link\" title=\"Hold values sent on this channel in debug runs\">Set breakpoint</span> |\n\t\t\t\t\t<span id=\"channel-delete-link\" class=\"link destructive\" title=\"Delete this channel\">Delete</a>\n\t\t\t\t</div>\n\t\t\t\t<div id=\"channel-properties-panel\" class=\"form\">\n\t\t\t\t\t<div class=\"formfield\">\n\t\t\t\t\t\t<label for=\"channel-name\">Name</label>\n\t\t\t\t\t\t<input id=\"channel-name\" name=\"channel-name\" type=\"text\" required value=\"channel\"></input>\n\t\t\t\t\t</div>\n\t\t\t\t\t<div class=\"formfield\">\n\t\t\t\t\t\t<label>Type</label>\n\t\t\t\t\t\t<code id=\"channel-type\">type</code>\n\t\t\t\t\t</div>\n\t\t\t\t\t<div class=\"formfield\">\n\t\t\t\t\t\t<label for=\"channel-capacity\">Capacity</label>\n\t\t\t\t\t\t<input id=\"channel-capacity\" name=\"channel-capacity\" type=\"number\" required pattern=\"^[0-9]+$\" title=\"Must be a whole number, at least 0.\" value=\"0\"></input>\n\t\t\t\t\t</div>\n\t\t\t\t</div>\n\t\t\t</div>\n\t\t\t<div id=\"node-properties\" class=\"panel padded\" style=\"display:none\">\n\t\t\t\t<h3>Node Properties</h3>\n\t\t\t\t<div id=\"node-actions\" class=\"head\">\n\t\t\t\t\t<!--\n\t\t\t\t\t<span id=\"node-clone-link\" class=\"link\" title=\"Make a copy of this goroutine.\">Clone</span> | \n\t\t\t\t\t<span id=\"node-convert-link\" class=\"link destructive\" title=\"Change this goroutine into a Code goroutine; it cannot be converted back.\">Convert to Code</span> | \n\t\t\t\t    -->\n\t\t\t\t\t<span id=\"node-delete-link\" class=\"link destructive\" title=\"Delete this goroutine\">Delete</span>\n\t\t\t\t</div>\n\t\t\t\t<div id=\"node-panels\" class=\"head\">\n\t\t\t\t\t<span id=\"node-metadata-link\" class=\"link selected\">Properties</span> \n\t\t\t\t\t{{range $tk, $type := $.PartTypes}}\n\t\t\t\t\t<span id=\"node-{{$tk}}-links\" style=\"display:none\">\n\t\t\t\t\t{{range $type.Panels }}\n\t\t\t\t\t| <span id=\"node-{{$tk}}-{{.Name}}-link\" class=\"link\">{{.Name}}</span>\n\t\t\t\t\t{{end}}\n\t\t\t\t\t</span>\n\t\t\t\t\t{{end}}\n\t\t\t\t</div>\n\t\t\t\t<div id=\"node-metadata-panel\" class=\"form\">\n\t\t\t\t\t<div class=\"formfield\">\n\t\t\t\t\t\t<label for=\"node-name\">Name</label>\n\t\t\t\t\t\t<input id=\"node-name\" name=\"node-name\" type=\"text\" required value=\"{.Name}\"></input>\n\t\t\t\t\t</div>\n\t\t\t\t\t<div class=\"formfield\">\n\t\t\t\t\t\t<label for=\"node-comment\">Comment</label>\n\t\t\t\t\t\t<textarea id=\"node-comment\" name=\"node-comment\" rows=\"4\" cols=\"32\"></textarea>\n\t\t\t\t\t</div>\n\t\t\t\t\t<div class=\"formfield\">\n\t\t\t\t\t\t<input id=\"node-enabled\" name=\"node-enabled\" type=\"checkbox\" checked></input>\n\t\t\t\t\t\t<label for=\"node-enabled\">Enabled</label>\n\t\t\t\t\t</div>\n\t\t\t\t\t<div class=\"formfield\">\n\t\t\t\t\t\t<label for=\"node-multiplicity\">Multiplicity</label>\n\t\t\t\t\t\t<input id=\"node-multiplicity\" name=\"node-multiplicity\" type=\"text\" required value=\"1\" title=\"An integer expression. You may use literals and `n`, which equals the result of runtime.NumCPU\"></input>\n\t\t\t\t\t</div>\n\t\t\t\t\t<div class=\"formfield\">\n\t\t\t\t\t\t<input id=\"node-wait\" name=\"node-wait\" type=\"checkbox\" checked></input>\n\t\t\t\t\t\t<label for=\"node-wait\">Wait for this to finish</label>\n\t\t\t\t\t</div>\n\t\t\t\t\t<div class=\"formfield\">\n\t\t\t\t\t\t<label for=\"node-panic-policy\">On panic</label>\n\t\t\t\t\t\t<select id=\"node-panic-policy\" name=\"node-panic-policy\">\n\t\t\t\t\t\t\t<option value=\"crash\" selected>Crash the program</option>\n\t\t\t\t\t\t\t<option value=\"stop\">Log and stop the node</option>\n\t\t\t\t\t\t\t<option value=\"restart\">Log and restart the node</option>\n\t\t\t\t\t\t</select>\n\t\t\t\t\t</div>\n\t\t\t\t\t<div class=\"formfield\">\n\t\t\t\t\t\t<label for=\"node-max-restarts\">Max restarts</label>\n\t\t\t\t\t\t<input id=\"node-max-restarts\" name=\"node-max-restarts\" type=\"number\" required pattern=\"^[0-9]+$\" title=\"Must be a whole number, at least 0. Only used when restarting the node.\" value=\"0\"></input>\n\t\t\t\t\t</div>\n\t\t\t\t\t<div class=\"formfield\">\n\t\t\t\t\t\t<label for=\"node-configurable\">Configurable settings</label>\n\t\t\t\t\t\t<input id=\"node-configurable\" name=\"node-configurable\" type=\"text\" title=\"Names of settings, separated by spaces, that a generated command lets you override with a flag or environment variable. The placeholder lists the settings of this node.\"></input>\n\t\t\t\t\t</div>\n\t\t\t\t</div>\n\t\t\t\t{{range $tk, $type := $.PartTypes}}\n\t\t\t\t{{range $type.Panels}}\n\t\t\t\t<div class=\"node-panel\" id=\"node-{{$tk}}-{{.Name}}-panel\" style=\"display:none\">\n\t\t\t\t\t{{.Editor}}\n\t\t\t\t</div>\n\t\t\t\t{{end}}\n\t\t\t\t{{end}}\n\t\t\t</div>\n\t\t\t<div id=\"help-licenses-panel\" class=\"panel padded\" style=\"display:none\">\n\t\t\t\t<h3>Licenses</h3>\n\t\t\t\t{{range $.Licenses}}\n\t\t\t\t<h4>{{.Component}}</h4>\n\t\t\t\t<iframe src=\"{{.URL}}\"></iframe>\n\t\t\t\t{{end}}\n\t\t\t</div>\n\t\t\t<div id=\"help-about-panel\" class=\"panel padded\" style=\"display:none\">\n\t\t\t\t<h3>Shenzhen Go</h3>\n\t\t\t\t(working title)\n\t\t\t\t<p>\n\t\t\t\t\tCopyright 2018 Google Inc.\n\t\t\t\t</p>\n\t\t\t\t<p>\n\t\t\t\t\tNote that this is not an official Google product.\n\t\t\t\t</p>\n\t\t\t\t<p>\n\t\t\t\t\t<a href=\"https://github.com/google/shenzhen-go\">Get the source code</a><br/>\n\t\t\t\t\t<a href=\"https://google.github.io/shenzhen-go\">Online documentation</a>\n\t\t\t\t</p>\n\t\t\t\t<!-- TODO: Put build info (git hash, etc) in here via template -->\n\t\t\t</div>\n\t\t</div>\n\t</div>\n\t<script src=\"/.static/js/client.js\"></script>\n</body>\n</html>\n"),
 }